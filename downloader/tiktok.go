@@ -57,12 +57,15 @@ type tikwmResp struct {
 	} `json:"data"`
 }
 
+// TikwmBaseURL adalah root API TikWM; bisa diganti lewat TIKWM_BASE_URL.
+var TikwmBaseURL = "https://www.tikwm.com"
+
 // getFromTikwm memanggil API TikWM dan mengekstrak video/audio/images.
 func getFromTikwm(client *http.Client, link string) (video, audio string, images []string, err error) {
 	if client == nil {
 		client = &http.Client{Timeout: 30 * time.Second}
 	}
-	api := "https://www.tikwm.com/api/?url=" + url.QueryEscape(strings.TrimSpace(link))
+	api := strings.TrimRight(TikwmBaseURL, "/") + "/api/?url=" + url.QueryEscape(strings.TrimSpace(link))
 
 	req, _ := http.NewRequest(http.MethodGet, api, nil)
 	req.Header.Set("Accept", "application/json")
@@ -0,0 +1,64 @@
+package downloader
+
+import (
+	"testing"
+
+	"wa-elaina/internal/fakeapi"
+)
+
+const testLink = "https://www.tiktok.com/@elaina/video/7300000000000000000"
+
+func fakeTikWM(t *testing.T) *fakeapi.TikWMServer {
+	t.Helper()
+	srv := fakeapi.NewTikWM()
+	t.Cleanup(srv.Close)
+	old := TikwmBaseURL
+	TikwmBaseURL = srv.URL
+	t.Cleanup(func() { TikwmBaseURL = old })
+	return srv
+}
+
+func TestTikwmVideo(t *testing.T) {
+	srv := fakeTikWM(t)
+	video, audio, images, err := getFromTikwm(srv.Client(), testLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if video != srv.URL+"/media/video.mp4" || audio != srv.URL+"/media/audio.mp3" || len(images) != 0 {
+		t.Fatalf("video=%q audio=%q images=%v", video, audio, images)
+	}
+	if r := srv.Requests(); len(r) != 1 || r[0].Path != "/api/" {
+		t.Fatalf("request = %+v", r)
+	}
+}
+
+func TestTikwmSlides(t *testing.T) {
+	srv := fakeTikWM(t)
+	srv.SetSlides(3)
+	video, _, images, err := getFromTikwm(srv.Client(), testLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if video != "" || len(images) != 3 {
+		t.Fatalf("video=%q images=%v", video, images)
+	}
+}
+
+func TestTikwmErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		setup func(s *fakeapi.TikWMServer)
+	}{
+		{"429", func(s *fakeapi.TikWMServer) { s.RateLimit(1) }},
+		{"JSON rusak", func(s *fakeapi.TikWMServer) { s.Malformed(1) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := fakeTikWM(t)
+			tc.setup(srv)
+			if v, _, _, err := getFromTikwm(srv.Client(), testLink); err == nil {
+				t.Fatalf("mau error, dapat video %q", v)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ toolchain go1.24.5
 require (
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/joho/godotenv v1.5.1
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	go.mau.fi/whatsmeow v0.0.0-20250820160106-21f5124c7602
 	golang.org/x/image v0.15.0
 	google.golang.org/protobuf v1.36.8
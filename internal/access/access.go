@@ -0,0 +1,180 @@
+// Package access menyimpan daftar ban chat dan allowlist grup.
+//
+// Semua entri dimuat ke memori saat start (daftarnya kecil) dan ditulis
+// langsung ke db (tabel access_list) setiap kali diubah lewat !ban chat/
+// !unban chat/!allowgroup. Ban user bukan di sini: user di-ban lewat role
+// perm.Banned (!ban @user sama dengan !role grant banned). Dipakai router dan
+// handler welcome.
+package access
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/perm"
+)
+
+// Jenis entri di tabel access_list.
+const (
+	BanChat   = "ban_chat"
+	AllowChat = "allow_chat"
+
+	// legacyBanUser: ban user versi lama; dipindah ke role perm.Banned saat
+	// start (lihat migrateUserBans).
+	legacyBanUser = "ban_user"
+)
+
+// List aman dipakai dari banyak goroutine.
+type List struct {
+	store         *db.Store
+	roles         *perm.Checker
+	allowlistOnly bool
+
+	mu      sync.RWMutex
+	entries map[string]map[string]db.AccessEntry // kind → jid → entri
+}
+
+// New memuat entri dari store (boleh nil: hanya di memori, tidak persisten).
+// roles menjadi sumber ban user (role perm.Banned).
+func New(store *db.Store, roles *perm.Checker, allowlistOnly bool) *List {
+	l := &List{
+		store:         store,
+		roles:         roles,
+		allowlistOnly: allowlistOnly,
+		entries: map[string]map[string]db.AccessEntry{
+			BanChat:   {},
+			AllowChat: {},
+		},
+	}
+	if store == nil {
+		return l
+	}
+	rows, err := store.ListAccess()
+	if err != nil {
+		log.Printf("[ACCESS] gagal memuat daftar: %v", err)
+		return l
+	}
+	var legacy []db.AccessEntry
+	for _, e := range rows {
+		if e.Kind == legacyBanUser {
+			legacy = append(legacy, e)
+			continue
+		}
+		if m, ok := l.entries[e.Kind]; ok {
+			m[e.JID] = e
+		}
+	}
+	l.migrateUserBans(legacy)
+	return l
+}
+
+// migrateUserBans memindahkan entri ban_user lama ke role perm.Banned
+// (sekali jalan; role yang sudah ada tidak ditimpa) lalu menghapusnya.
+func (l *List) migrateUserBans(rows []db.AccessEntry) {
+	if l.roles == nil {
+		return
+	}
+	for _, e := range rows {
+		j, err := types.ParseJID(e.JID)
+		if err != nil {
+			log.Printf("[ACCESS] lewati ban lama %q: %v", e.JID, err)
+			continue
+		}
+		if l.roles.Of(j) == perm.User {
+			if err := l.roles.Grant(j, perm.Banned, e.AddedBy); err != nil {
+				log.Printf("[ACCESS] pindah ban %s ke role: %v", e.JID, err)
+				continue
+			}
+		}
+		if _, err := l.store.RemoveAccess(legacyBanUser, e.JID); err != nil {
+			log.Printf("[ACCESS] hapus ban lama %s: %v", e.JID, err)
+			continue
+		}
+		log.Printf("[ACCESS] ban lama %s dipindah ke role banned", e.JID)
+	}
+}
+
+// Key: kunci entri untuk JID (tanpa device).
+func Key(j types.JID) string { return j.ToNonAD().String() }
+
+// AllowlistOnly: mode hanya-grup-terdaftar aktif (ENV ALLOWLIST_ONLY).
+func (l *List) AllowlistOnly() bool { return l != nil && l.allowlistOnly }
+
+func (l *List) has(kind string, j types.JID) bool {
+	l.mu.RLock()
+	_, ok := l.entries[kind][Key(j)]
+	l.mu.RUnlock()
+	return ok
+}
+
+// UserBanned: pengirim punya role perm.Banned.
+func (l *List) UserBanned(user types.JID) bool {
+	return l != nil && l.roles != nil && l.roles.Of(user) == perm.Banned
+}
+
+// ChatAllowed: chat tidak di-ban dan, jika mode allowlist aktif, grup
+// terdaftar di !allowgroup. Chat pribadi tidak terkena allowlist.
+func (l *List) ChatAllowed(chat types.JID) bool {
+	if l == nil {
+		return true
+	}
+	if l.has(BanChat, chat) {
+		return false
+	}
+	if l.allowlistOnly && chat.Server == types.GroupServer {
+		return l.has(AllowChat, chat)
+	}
+	return true
+}
+
+// Add menambah/memperbarui entri kind untuk j.
+func (l *List) Add(kind string, j types.JID, reason, by string) error {
+	if _, ok := l.entries[kind]; !ok {
+		return errors.New("jenis daftar tidak dikenal: " + kind)
+	}
+	e := db.AccessEntry{Kind: kind, JID: Key(j), Reason: reason, AddedBy: by, Created: time.Now()}
+	if l.store != nil {
+		if err := l.store.AddAccess(e); err != nil {
+			return err
+		}
+	}
+	l.mu.Lock()
+	l.entries[kind][e.JID] = e
+	l.mu.Unlock()
+	return nil
+}
+
+// Remove menghapus entri; removed=false jika j tidak ada di daftar.
+func (l *List) Remove(kind string, j types.JID) (removed bool, err error) {
+	key := Key(j)
+	if !l.has(kind, j) {
+		return false, nil
+	}
+	if l.store != nil {
+		if _, err := l.store.RemoveAccess(kind, key); err != nil {
+			return false, err
+		}
+	}
+	l.mu.Lock()
+	delete(l.entries[kind], key)
+	l.mu.Unlock()
+	return true, nil
+}
+
+// Entries mengembalikan salinan entri satu jenis, urut waktu dibuat.
+func (l *List) Entries(kind string) []db.AccessEntry {
+	l.mu.RLock()
+	out := make([]db.AccessEntry, 0, len(l.entries[kind]))
+	for _, e := range l.entries[kind] {
+		out = append(out, e)
+	}
+	l.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Created.Before(out[j].Created) })
+	return out
+}
@@ -0,0 +1,74 @@
+package access
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/perm"
+)
+
+func TestLegacyUserBansMoveToRole(t *testing.T) {
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	user := types.NewJID("6282222222222", types.DefaultUserServer)
+	mod := types.NewJID("6283333333333", types.DefaultUserServer)
+	for _, j := range []types.JID{user, mod} {
+		if err := store.AddAccess(db.AccessEntry{Kind: legacyBanUser, JID: j.String(), AddedBy: "owner", Created: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	roles := perm.New(store)
+	if err := roles.Grant(mod, perm.Moderator, "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(store, roles, false)
+	if !l.UserBanned(user) || roles.Of(user) != perm.Banned {
+		t.Fatalf("ban lama tidak dipindah ke role (role %s)", roles.Of(user))
+	}
+	if roles.Of(mod) != perm.Moderator {
+		t.Fatalf("role yang sudah ada ditimpa: %s", roles.Of(mod))
+	}
+	rows, err := store.ListAccess()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range rows {
+		if e.Kind == legacyBanUser {
+			t.Fatalf("entri ban_user lama masih ada: %+v", e)
+		}
+	}
+}
+
+func TestChatAllowed(t *testing.T) {
+	group := types.NewJID("120363000000000001", types.GroupServer)
+	dm := types.NewJID("6282222222222", types.DefaultUserServer)
+
+	l := New(nil, nil, true)
+	if l.ChatAllowed(group) {
+		t.Fatal("grup belum terdaftar lolos allowlist")
+	}
+	if !l.ChatAllowed(dm) {
+		t.Fatal("chat pribadi terkena allowlist")
+	}
+	if err := l.Add(AllowChat, group, "", "owner"); err != nil {
+		t.Fatal(err)
+	}
+	if !l.ChatAllowed(group) {
+		t.Fatal("grup terdaftar ditolak")
+	}
+	if err := l.Add(BanChat, group, "", "owner"); err != nil {
+		t.Fatal(err)
+	}
+	if l.ChatAllowed(group) {
+		t.Fatal("grup yang di-ban lolos")
+	}
+}
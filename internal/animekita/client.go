@@ -35,6 +35,16 @@ func New(httpClient *http.Client) *Client {
 	}
 }
 
+// NewWithBaseURL is like New but targets a custom API root (e.g. a mirror or a
+// local fake server). An empty baseURL falls back to the public endpoint.
+func NewWithBaseURL(httpClient *http.Client, baseURL string) *Client {
+	c := New(httpClient)
+	if u := strings.TrimRight(strings.TrimSpace(baseURL), "/"); u != "" {
+		c.baseURL = u
+	}
+	return c
+}
+
 // SimpleEntry captures common lightweight anime metadata returned by multiple endpoints.
 type SimpleEntry struct {
 	ID       int    `json:"id"`
@@ -0,0 +1,67 @@
+package animekita
+
+import (
+	"context"
+	"testing"
+
+	"wa-elaina/internal/fakeapi"
+)
+
+func fakeClient(t *testing.T) (*Client, *fakeapi.AnimeKitaServer) {
+	t.Helper()
+	srv := fakeapi.NewAnimeKita()
+	t.Cleanup(srv.Close)
+	return NewWithBaseURL(srv.Client(), srv.URL+"/"), srv
+}
+
+func TestClientHappyPath(t *testing.T) {
+	c, srv := fakeClient(t)
+	ctx := context.Background()
+
+	ups, err := c.NewUploads(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ups) != 1 || ups[0].Title != "Frieren" {
+		t.Fatalf("NewUploads = %+v", ups)
+	}
+	movies, err := c.Movies(ctx)
+	if err != nil || len(movies) != 1 {
+		t.Fatalf("Movies = %+v, %v", movies, err)
+	}
+
+	det, err := c.Detail(ctx, "frieren-sousou-no-frieren")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if det.Title != "Frieren" || len(det.Chapters) != 1 {
+		t.Fatalf("Detail = %+v", det)
+	}
+
+	ep, err := c.Episode(ctx, "frieren-episode-28", "720p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ep.Streams) != 1 || ep.Streams[0].Link != srv.URL+"/stream/720p.mp4" {
+		t.Fatalf("Episode = %+v", ep)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		setup func(s *fakeapi.AnimeKitaServer)
+	}{
+		{"429", func(s *fakeapi.AnimeKitaServer) { s.RateLimit(1) }},
+		{"JSON rusak", func(s *fakeapi.AnimeKitaServer) { s.Malformed(1) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, srv := fakeClient(t)
+			tc.setup(srv)
+			if det, err := c.Detail(context.Background(), "frieren-sousou-no-frieren"); err == nil {
+				t.Fatalf("mau error, dapat %+v", det)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/access"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+)
+
+// Access mengekspos daftar ban/allow (dipakai handler welcome).
+func (r *Router) Access() *access.List { return r.acl }
+
+// admitted: bot boleh membalas pesan ini — pengirim tidak berrole banned dan
+// chat lolos daftar ban & allowlist. Owner/co-owner selalu lolos agar tetap
+// bisa !unban atau !allowgroup dari chat yang diblokir.
+func (r *Router) admitted(m *feature.Msg) bool {
+	switch {
+	case m.Role == perm.Banned:
+		log.Printf("[ACCESS] user banned %s: hanya moderasi", m.Sender.String())
+		return false
+	case r.acl.ChatAllowed(m.Chat), m.Can(perm.CoOwner):
+		return true
+	}
+	log.Printf("[ACCESS] chat=%s di luar ACL: hanya moderasi", m.Chat.String())
+	return false
+}
+
+// isStaff: user adalah owner/co-owner (tidak boleh di-ban).
+func (r *Router) isStaff(user types.JID) bool {
+	isOwner := r.owner.IsOwner(types.MessageInfo{MessageSource: types.MessageSource{Sender: user}})
+	return r.roles.RoleOf(user, isOwner) >= perm.CoOwner
+}
+
+// chatArg: JID chat dari argumen (mis. 1203...@g.us); kosong = chat ini.
+func chatArg(m *feature.Msg, args []string) (types.JID, bool) {
+	if len(args) == 0 {
+		return m.Chat, true
+	}
+	j, err := types.ParseJID(args[0])
+	if err != nil || j.Server == "" || j.User == "" {
+		return types.JID{}, false
+	}
+	return j, true
+}
+
+// handleBanCmd: !ban [list] | !ban @user|nomor [alasan] | !ban chat [jid]
+func (r *Router) handleBanCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	if !m.Can(perm.CoOwner) {
+		deny(m, "access.owner_only")
+		return true
+	}
+	args := strings.Fields(m.Args)
+	if len(args) == 0 || strings.EqualFold(args[0], "list") {
+		replyText(context.Background(), client, ev, r.banList(m))
+		return true
+	}
+	by := perm.Key(m.Sender)
+
+	if strings.EqualFold(args[0], "chat") {
+		chat, ok := chatArg(m, args[1:])
+		if !ok {
+			replyText(context.Background(), client, ev, m.T("access.ban_usage"))
+			return true
+		}
+		if err := r.acl.Add(access.BanChat, chat, "", by); err != nil {
+			replyText(context.Background(), client, ev, m.T("access.save_failed", err))
+			return true
+		}
+		log.Printf("[ACCESS] ban chat %s oleh %s", chat.String(), by)
+		replyText(context.Background(), client, ev, m.T("access.chat_banned", chat.String()))
+		return true
+	}
+
+	target, rest, ok := targetJID(m, args)
+	if !ok {
+		replyText(context.Background(), client, ev, m.T("access.ban_usage"))
+		return true
+	}
+	if r.isStaff(target) {
+		replyText(context.Background(), client, ev, m.T("access.staff"))
+		return true
+	}
+	// Sama dengan !role grant banned; alasan hanya dicatat di log & audit.
+	reason := strings.Join(rest, " ")
+	if err := r.roles.Grant(target, perm.Banned, by); err != nil {
+		replyText(context.Background(), client, ev, m.T("access.save_failed", err))
+		return true
+	}
+	log.Printf("[ACCESS] ban user %s oleh %s (%s)", perm.Key(target), by, reason)
+	replyTextMention(context.Background(), client, ev, m.T("access.user_banned", target.User), []types.JID{target.ToNonAD()})
+	return true
+}
+
+// handleUnbanCmd: !unban @user|nomor | !unban chat [jid]
+func (r *Router) handleUnbanCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	if !m.Can(perm.CoOwner) {
+		deny(m, "access.owner_only")
+		return true
+	}
+	args := strings.Fields(m.Args)
+
+	isChat := len(args) > 0 && strings.EqualFold(args[0], "chat")
+	var (
+		target types.JID
+		label  string
+		ok     bool
+	)
+	if isChat {
+		target, ok = chatArg(m, args[1:])
+		label = target.String()
+	} else {
+		target, _, ok = targetJID(m, args)
+		label = target.User
+	}
+	if !ok {
+		replyText(context.Background(), client, ev, m.T("access.unban_usage"))
+		return true
+	}
+
+	var (
+		removed bool
+		err     error
+	)
+	if isChat {
+		removed, err = r.acl.Remove(access.BanChat, target)
+	} else if r.roles.Of(target) == perm.Banned {
+		removed, err = true, r.roles.Grant(target, perm.User, perm.Key(m.Sender))
+	}
+	switch {
+	case err != nil:
+		replyText(context.Background(), client, ev, m.T("access.save_failed", err))
+	case !removed:
+		replyText(context.Background(), client, ev, m.T("access.not_banned", label))
+	default:
+		log.Printf("[ACCESS] unban %s oleh %s", label, perm.Key(m.Sender))
+		replyText(context.Background(), client, ev, m.T("access.unbanned", label))
+	}
+	return true
+}
+
+// handleAllowGroupCmd: !allowgroup [list] | add [jid] | del [jid]
+func (r *Router) handleAllowGroupCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	if !m.Can(perm.CoOwner) {
+		deny(m, "access.owner_only")
+		return true
+	}
+	args := strings.Fields(m.Args)
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+	if sub == "list" {
+		replyText(context.Background(), client, ev, r.allowList(m))
+		return true
+	}
+	chat, ok := chatArg(m, args)
+	if !ok || chat.Server != types.GroupServer || (sub != "add" && sub != "del") {
+		replyText(context.Background(), client, ev, m.T("access.allow_usage"))
+		return true
+	}
+
+	if sub == "add" {
+		if err := r.acl.Add(access.AllowChat, chat, "", perm.Key(m.Sender)); err != nil {
+			replyText(context.Background(), client, ev, m.T("access.save_failed", err))
+			return true
+		}
+		replyText(context.Background(), client, ev, m.T("access.allowed", chat.String()))
+		return true
+	}
+	removed, err := r.acl.Remove(access.AllowChat, chat)
+	switch {
+	case err != nil:
+		replyText(context.Background(), client, ev, m.T("access.save_failed", err))
+	case !removed:
+		replyText(context.Background(), client, ev, m.T("access.not_allowed", chat.String()))
+	default:
+		replyText(context.Background(), client, ev, m.T("access.disallowed", chat.String()))
+	}
+	return true
+}
+
+func (r *Router) banList(m *feature.Msg) string {
+	var users []string
+	entries, err := r.roles.List()
+	if err != nil {
+		log.Printf("[ACCESS] gagal memuat role: %v", err)
+	}
+	for _, e := range entries {
+		if e.Role == perm.Banned {
+			users = append(users, e.User)
+		}
+	}
+	chats := r.acl.Entries(access.BanChat)
+	if len(users) == 0 && len(chats) == 0 {
+		return m.T("access.ban_empty")
+	}
+	lines := []string{m.T("access.ban_title")}
+	for _, u := range users {
+		lines = append(lines, "- "+strings.TrimSuffix(u, "@"+types.DefaultUserServer))
+	}
+	for _, e := range chats {
+		lines = append(lines, "- "+m.T("access.chat_label", e.JID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Router) allowList(m *feature.Msg) string {
+	mode := m.T("access.mode_off")
+	if r.acl.AllowlistOnly() {
+		mode = m.T("access.mode_on")
+	}
+	lines := []string{m.T("access.allow_title", mode)}
+	entries := r.acl.Entries(access.AllowChat)
+	if len(entries) == 0 {
+		lines = append(lines, m.T("access.allow_empty"))
+	}
+	for _, e := range entries {
+		lines = append(lines, "- "+e.JID)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+)
+
+const (
+	auditArgsMax    = 200 // argumen lebih panjang dipotong
+	auditDefaultCmd = 10
+	auditMaxCmd     = 50
+
+	auditBotActor  = "bot"        // pelaku tindakan moderasi otomatis
+	auditModPrefix = "peraturan:" // awalan Command untuk tindakan moderasi
+)
+
+// deny membalas penolakan izin dan menandainya untuk audit log.
+func deny(m *feature.Msg, key string, args ...any) {
+	m.Outcome = feature.OutcomeDenied
+	replyText(context.Background(), m.Client, m.Event, m.T(key, args...))
+}
+
+// audit mencatat satu perintah "!xxx" beserta hasilnya ke audit_log.
+func (r *Router) audit(m *feature.Msg, handled bool) {
+	if r.store == nil || !m.IsCmd || m.Muted {
+		return
+	}
+	outcome := m.Outcome
+	if outcome == "" {
+		outcome = feature.OutcomeUnhandled
+		if handled {
+			outcome = feature.OutcomeOK
+		}
+	}
+	r.record(db.AuditEntry{
+		Chat:    m.Chat.String(),
+		Sender:  perm.Key(m.Sender),
+		Command: m.Cmd,
+		Args:    m.Args,
+		Outcome: outcome,
+	})
+}
+
+// auditModeration mencatat tindakan moderasi otomatis peraturan (warn,
+// revoke, kick, redeem). Pelakunya bot, jadi Sender berisi auditBotActor dan
+// target ditaruh di awal Args.
+func (r *Router) auditModeration(chat types.JID, action, target, detail string, ok bool) {
+	if r.store == nil {
+		return
+	}
+	outcome := feature.OutcomeOK
+	if !ok {
+		outcome = feature.OutcomeFailed
+	}
+	args := target
+	if detail != "" {
+		args += " " + detail
+	}
+	r.record(db.AuditEntry{
+		Chat:    chat.String(),
+		Sender:  auditBotActor,
+		Command: auditModPrefix + action,
+		Args:    args,
+		Outcome: outcome,
+	})
+}
+
+func (r *Router) record(e db.AuditEntry) {
+	e.At = time.Now()
+	if rs := []rune(e.Args); len(rs) > auditArgsMax {
+		e.Args = string(rs[:auditArgsMax]) + "…"
+	}
+	if err := r.store.AddAudit(e); err != nil {
+		log.Printf("[AUDIT] gagal mencatat %s: %v", e.Command, err)
+	}
+}
+
+// handleAuditCmd: !audit [n] — n perintah terakhir dari semua chat (owner).
+func (r *Router) handleAuditCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	if !m.Can(perm.Owner) {
+		deny(m, "audit.owner_only")
+		return true
+	}
+	n := auditDefaultCmd
+	if a := strings.TrimSpace(m.Args); a != "" {
+		v, err := strconv.Atoi(a)
+		if err != nil || v <= 0 {
+			replyText(context.Background(), client, ev, m.T("audit.usage", auditMaxCmd))
+			return true
+		}
+		n = min(v, auditMaxCmd)
+	}
+	entries, err := r.store.RecentAudit(n, "")
+	if err != nil {
+		replyText(context.Background(), client, ev, m.T("audit.failed", err))
+		return true
+	}
+	if len(entries) == 0 {
+		replyText(context.Background(), client, ev, m.T("audit.empty"))
+		return true
+	}
+	lines := []string{m.T("audit.title", len(entries))}
+	for _, e := range entries {
+		sender, _, _ := strings.Cut(e.Sender, "@")
+		cmd := e.Command
+		if !strings.HasPrefix(cmd, auditModPrefix) {
+			cmd = "!" + cmd
+		}
+		line := fmt.Sprintf("%s %s", e.At.Format("02/01 15:04"), cmd)
+		if e.Args != "" {
+			line += " " + e.Args
+		}
+		line += fmt.Sprintf(" → %s (%s @ %s)", e.Outcome, sender, e.Chat)
+		lines = append(lines, line)
+	}
+	replyText(context.Background(), client, ev, strings.Join(lines, "\n"))
+	return true
+}
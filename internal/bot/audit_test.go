@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/wa/watest"
+)
+
+// auditByCommand mengelompokkan entri audit chat per Command.
+func auditByCommand(t *testing.T, h *harness) map[string][]db.AuditEntry {
+	t.Helper()
+	entries, err := h.store.RecentAudit(50, groupJID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string][]db.AuditEntry)
+	for _, e := range entries {
+		out[e.Command] = append(out[e.Command], e)
+	}
+	return out
+}
+
+func TestModerationActionsAudited(t *testing.T) {
+	h := newHarness(t, nil)
+	if err := h.store.SetPeraturanState(groupJID.String(), true, "1. Dilarang spam"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := h.store.AddWarn(groupJID.String(), userJID.String(), "lama"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.gemini.SetReply(`{"violation":true,"reason":"spam link","redeem":false}`)
+
+	h.send(watest.Text(groupJID, userJID, "promo murah klik link ini"))
+
+	got := auditByCommand(t, h)
+	target := perm.Key(userJID)
+	for _, action := range []string{"revoke", "warn", "kick"} {
+		es := got[auditModPrefix+action]
+		if len(es) != 1 {
+			t.Fatalf("entri %s = %d, mau 1 (semua: %v)", action, len(es), got)
+		}
+		e := es[0]
+		if e.Sender != auditBotActor || e.Outcome != feature.OutcomeOK {
+			t.Fatalf("%s: sender=%q outcome=%q", action, e.Sender, e.Outcome)
+		}
+		if !strings.HasPrefix(e.Args, target) || !strings.Contains(e.Args, "spam link") {
+			t.Fatalf("%s: args %q tidak memuat target %s dan alasan", action, e.Args, target)
+		}
+	}
+	if es := got[auditModPrefix+"warn"]; !strings.Contains(es[0].Args, "5/5") {
+		t.Fatalf("warn args = %q, mau memuat 5/5", es[0].Args)
+	}
+	if n := len(h.client.ParticipantChanges()); n != 1 {
+		t.Fatalf("perubahan peserta = %d, mau 1 (kick)", n)
+	}
+}
+
+func TestPeraturanDeniedAudited(t *testing.T) {
+	h := newHarness(t, nil)
+	h.send(watest.Text(groupJID, userJID, "!peraturan on"))
+
+	es := auditByCommand(t, h)["peraturan"]
+	if len(es) != 1 || es[0].Outcome != feature.OutcomeDenied {
+		t.Fatalf("audit !peraturan oleh non-admin = %+v, mau 1 entri denied", es)
+	}
+}
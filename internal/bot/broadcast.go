@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"wa-elaina/internal/broadcast"
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+)
+
+const broadcastTimeFmt = "Mon 02/01/2006 15:04 MST"
+
+// Broadcasts mengekspos scheduler siaran (dijalankan dari main & dipakai HTTP API).
+func (r *Router) Broadcasts() *broadcast.Scheduler { return r.bcast }
+
+// handleBroadcastCmd:
+//
+//	!broadcast add <jadwal> | <target,...|sini> | <teks> [| <url media>]
+//	!broadcast list | hapus <id> | pause <id> | resume <id> | run <id>
+//	!broadcast catchup <id> skip|once|all
+func (r *Router) handleBroadcastCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	if !m.Can(perm.CoOwner) {
+		deny(m, "access.owner_only")
+		return true
+	}
+	sub, rest, _ := strings.Cut(strings.TrimSpace(m.Args), " ")
+	rest = strings.TrimSpace(rest)
+	reply := func(key string, args ...any) {
+		replyText(context.Background(), client, ev, m.T(key, args...))
+	}
+
+	switch strings.ToLower(sub) {
+	case "", "list":
+		replyText(context.Background(), client, ev, r.broadcastList(m))
+		return true
+	case "add", "tambah":
+		r.addBroadcast(m, rest)
+		return true
+	}
+
+	idStr, arg, _ := strings.Cut(rest, " ")
+	id, err := strconv.ParseInt(strings.TrimPrefix(idStr, "#"), 10, 64)
+	if err != nil {
+		reply("broadcast.usage")
+		return true
+	}
+	switch strings.ToLower(sub) {
+	case "hapus", "del", "delete", "remove":
+		ok, err := r.bcast.Delete(id)
+		switch {
+		case err != nil:
+			reply("broadcast.failed", err)
+		case !ok:
+			reply("broadcast.not_found", id)
+		default:
+			log.Printf("[BROADCAST] #%d dihapus oleh %s", id, perm.Key(m.Sender))
+			reply("broadcast.deleted", id)
+		}
+	case "pause", "resume":
+		paused := strings.EqualFold(sub, "pause")
+		b, err := r.bcast.SetPaused(id, paused)
+		switch {
+		case errors.Is(err, broadcast.ErrNotFound):
+			reply("broadcast.not_found", id)
+		case err != nil:
+			reply("broadcast.failed", err)
+		case paused:
+			reply("broadcast.paused", id)
+		default:
+			reply("broadcast.resumed", id, b.NextRun.In(r.bcast.Location()).Format(broadcastTimeFmt))
+		}
+	case "run":
+		reply("broadcast.running", id)
+		n, err := r.bcast.RunNow(id)
+		switch {
+		case errors.Is(err, broadcast.ErrNotFound):
+			reply("broadcast.not_found", id)
+		case n == 0 && err != nil:
+			reply("broadcast.failed", err)
+		default:
+			reply("broadcast.ran", id, n)
+		}
+	case "catchup":
+		ok, err := r.bcast.SetCatchUp(id, arg)
+		switch {
+		case err != nil:
+			reply("broadcast.failed", err)
+		case !ok:
+			reply("broadcast.not_found", id)
+		default:
+			p, _ := broadcast.ValidCatchUp(arg)
+			reply("broadcast.catchup_set", id, p)
+		}
+	default:
+		reply("broadcast.usage")
+	}
+	return true
+}
+
+func (r *Router) addBroadcast(m *feature.Msg, args string) {
+	client, ev := m.Client, m.Event
+	parts := strings.Split(args, "|")
+	if len(parts) < 3 {
+		replyText(context.Background(), client, ev, m.T("broadcast.usage"))
+		return
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	var targets []string
+	for _, t := range strings.FieldsFunc(parts[1], func(c rune) bool { return c == ',' || c == ' ' }) {
+		switch strings.ToLower(t) {
+		case "sini", "here":
+			targets = append(targets, m.Chat.String())
+		default:
+			targets = append(targets, t)
+		}
+	}
+	spec := broadcast.Spec{
+		Schedule:  parts[0],
+		Targets:   targets,
+		Text:      parts[2],
+		CreatedBy: perm.Key(m.Sender),
+	}
+	if len(parts) > 3 {
+		spec.MediaURL = parts[3]
+	}
+	b, err := r.bcast.Add(spec)
+	if err != nil {
+		replyText(context.Background(), client, ev, m.T("broadcast.invalid", err))
+		return
+	}
+	log.Printf("[BROADCAST] #%d dibuat oleh %s: %q → %d target", b.ID, spec.CreatedBy, b.Schedule, len(b.Targets))
+	replyText(context.Background(), client, ev, m.T("broadcast.saved", b.ID, b.Schedule, len(b.Targets),
+		b.NextRun.In(r.bcast.Location()).Format(broadcastTimeFmt)))
+}
+
+func (r *Router) broadcastList(m *feature.Msg) string {
+	list, err := r.bcast.List()
+	if err != nil {
+		return m.T("broadcast.failed", err)
+	}
+	if len(list) == 0 {
+		return m.T("broadcast.list_empty")
+	}
+	lines := []string{m.T("broadcast.list_title")}
+	for _, b := range list {
+		lines = append(lines, r.broadcastLine(m, b))
+	}
+	lines = append(lines, "", m.T("broadcast.list_footer"))
+	return strings.Join(lines, "\n")
+}
+
+func (r *Router) broadcastLine(m *feature.Msg, b db.Broadcast) string {
+	status := m.T("broadcast.next", b.NextRun.In(r.bcast.Location()).Format(broadcastTimeFmt))
+	if b.Paused {
+		status = m.T("broadcast.status_paused")
+	}
+	what := b.Text
+	if len([]rune(what)) > 40 {
+		what = string([]rune(what)[:40]) + "…"
+	}
+	if b.MediaURL != "" {
+		what = "📎 " + what
+	}
+	return fmt.Sprintf("#%d • `%s` • %d target • catchup=%s • %s\n   %s", b.ID, b.Schedule, len(b.Targets), b.CatchUp, status, what)
+}
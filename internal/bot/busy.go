@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/wa"
+)
+
+// busyEvery: jeda minimum pemberitahuan "sedang sibuk" per chat agar banjir
+// pesan tidak dibalas banjir pemberitahuan.
+const busyEvery = time.Minute
+
+// busyNotes: waktu pemberitahuan sibuk terakhir per chat.
+type busyNotes struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// NotifyBusy memberi tahu chat bahwa pesannya dibuang karena antrean
+// dispatcher penuh (dipasang sebagai Dispatcher.OnDrop).
+func (r *Router) NotifyBusy(chat string) {
+	jid, err := types.ParseJID(chat)
+	if err != nil || !r.ready.Load() {
+		return
+	}
+	now := time.Now()
+	b := &r.busy
+	b.mu.Lock()
+	if now.Sub(b.last[chat]) < busyEvery {
+		b.mu.Unlock()
+		return
+	}
+	for k, t := range b.last {
+		if now.Sub(t) >= busyEvery {
+			delete(b.last, k)
+		}
+	}
+	b.last[chat] = now
+	b.mu.Unlock()
+
+	if err := r.send.Text(wa.DestJID(jid), r.chatLang(chat).T("chat.busy")); err != nil {
+		log.Printf("[DISPATCH] gagal kirim pemberitahuan sibuk ke %s: %v", chat, err)
+	}
+}
@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Mode evaluasi ulang pesan yang diedit (EDIT_MODE).
+const (
+	EditOff       = "off"
+	EditUnhandled = "unhandled"
+	EditAll       = "all"
+)
+
+const recentSize = 2048 // jumlah pesan terakhir yang diingat status handled-nya
+
+// unwrapEdit: jika m adalah edit (ProtocolMessage MESSAGE_EDIT), kembalikan
+// salinan event berisi isi baru dengan Info.ID = ID pesan asli sehingga
+// balasan meng-quote pesan yang diedit.
+func unwrapEdit(m *events.Message) (*events.Message, bool) {
+	pm := m.Message.GetProtocolMessage()
+	if pm.GetType() != waProto.ProtocolMessage_MESSAGE_EDIT || pm.GetEditedMessage() == nil || pm.GetKey().GetID() == "" {
+		return nil, false
+	}
+	ed := *m
+	ed.Message = pm.GetEditedMessage()
+	ed.Info.ID = pm.GetKey().GetID()
+	ed.IsEdit = true
+	return &ed, true
+}
+
+// RevokedID: ID pesan yang ditarik jika m adalah revoke ("hapus untuk semua").
+func RevokedID(m *events.Message) (string, bool) {
+	pm := m.Message.GetProtocolMessage()
+	if pm.GetType() != waProto.ProtocolMessage_REVOKE || pm.GetKey().GetID() == "" {
+		return "", false
+	}
+	return pm.GetKey().GetID(), true
+}
+
+// recent mengingat status pesan terakhir: handled (untuk EDIT_MODE=unhandled)
+// dan cancel context pesan yang sedang diproses (untuk revoke).
+type recent struct {
+	mu       sync.Mutex
+	handled  map[string]bool
+	order    []string // ring FIFO untuk membatasi handled
+	next     int
+	inflight map[string]context.CancelFunc
+}
+
+func newRecent() *recent {
+	return &recent{
+		handled:  make(map[string]bool, recentSize),
+		order:    make([]string, recentSize),
+		inflight: make(map[string]context.CancelFunc),
+	}
+}
+
+func (r *recent) wasHandled(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.handled[key]
+}
+
+func (r *recent) mark(key string, handled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handled[key]; !ok {
+		if old := r.order[r.next]; old != "" {
+			delete(r.handled, old)
+		}
+		r.order[r.next] = key
+		r.next = (r.next + 1) % len(r.order)
+	}
+	r.handled[key] = r.handled[key] || handled
+}
+
+// start mendaftarkan pesan yang mulai diproses; done wajib dipanggil setelahnya.
+func (r *recent) start(key string) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	r.inflight[key] = cancel
+	r.mu.Unlock()
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.inflight, key)
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+func (r *recent) cancel(key string) bool {
+	r.mu.Lock()
+	cancel, ok := r.inflight[key]
+	r.mu.Unlock()
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// reevaluate: edit boleh diproses ulang sesuai EDIT_MODE.
+func (r *Router) reevaluate(key string) bool {
+	switch r.cfg.EditMode {
+	case EditOff:
+		return false
+	case EditAll:
+		return true
+	}
+	return !r.recent.wasHandled(key)
+}
+
+// CancelMessage membatalkan pekerjaan untuk pesan yang ditarik. Dipanggil
+// langsung dari event handler (bukan lewat antrean dispatcher, karena revoke
+// akan antre di belakang job yang mau dibatalkan). dropped = job masih antre
+// dan sudah dibuang dispatcher.
+func (r *Router) CancelMessage(chat types.JID, id string, dropped bool) {
+	key := chat.String() + "/" + id
+	running := r.recent.cancel(key)
+	if dropped || running {
+		log.Printf("[REVOKE] batalkan chat=%s id=%s (antre=%t berjalan=%t)", chat.String(), id, dropped, running)
+	}
+}
@@ -0,0 +1,453 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/feature/anime"
+	"wa-elaina/internal/feature/baimg"
+	"wa-elaina/internal/feature/brat"
+	"wa-elaina/internal/feature/hijabin"
+	"wa-elaina/internal/feature/imggen"
+	"wa-elaina/internal/feature/pap"
+	"wa-elaina/internal/feature/peraturan"
+	"wa-elaina/internal/feature/rvo"
+	"wa-elaina/internal/feature/sticker"
+	"wa-elaina/internal/feature/tagall"
+	"wa-elaina/internal/feature/tkwrap"
+	"wa-elaina/internal/feature/tts"
+	"wa-elaina/internal/feature/vision"
+	"wa-elaina/internal/feature/vn"
+	"wa-elaina/internal/memory"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/reminder"
+)
+
+// Prioritas fitur: makin besar makin dulu dicoba.
+const (
+	prioFirst    = 1000 // image generation selalu dicek pertama
+	prioCommand  = 900  // perintah "!xxx"
+	prioObserver = 800  // pengamat pasif (moderasi), tidak menghentikan rantai
+	prioUtility  = 700  // rvo/tagall
+	prioReminder = 650  // "ingatkan aku ..." (bahasa alami)
+	prioTikTok   = 600
+	prioMedia    = 500 // fitur non-perintah (perlu trigger di grup)
+	prioVoice    = 300
+	prioChat     = 0 // fallback obrolan AI
+)
+
+// registerFeatures mendaftarkan semua fitur bawaan. Menambah fitur baru cukup
+// dengan menambah entri di sini (atau Register dari luar via Features()).
+// Lines berisi kunci katalog i18n; teks literal tetap tampil apa adanya.
+func (r *Router) registerFeatures() {
+	cfg := r.cfg
+
+	img := imggen.New(cfg, r.trig)
+	rv := rvo.New(r.trig)
+	tall := tagall.New(r.trig)
+	tk := tkwrap.New(cfg, r.send)
+	pp := pap.New(cfg)
+	ba := baimg.New(cfg)
+	hij := hijabin.New(cfg, r.send)
+	br := brat.New(r.trig)
+	stik := sticker.New(r.trig)
+	vis := vision.New(cfg, r.send, r.trig, r.owner)
+	an := anime.New(cfg, r.trig, r.send)
+	tt := tts.New(cfg, r.trig)
+	vnote := vn.New(cfg, r.send, r.trig, r.owner)
+	pr := peraturan.New(r.store)
+	pr.UseDedup(r.dedup)
+	pr.UseAuditor(r.auditModeration)
+
+	r.features.Register(
+		&feature.Spec{
+			ID:      "imggen",
+			Prio:    prioFirst,
+			Lines:   []string{"help.imggen"},
+			MatchFn: func(m *feature.Msg) bool { return img.Matches(m.Chat.String(), m.Text) },
+			HandleFn: func(m *feature.Msg) bool {
+				return result(m)(img.TryHandle(m.Ctx, m.Client, m.Event, m.Text, m.IsOwner))
+			},
+		},
+
+		// ---- perintah ----
+		&feature.Spec{
+			ID:       "help",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.help"},
+			MatchFn:  isCmd("help"),
+			HandleFn: r.handleHelp,
+		},
+		&feature.Spec{
+			ID:       "whoami",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.whoami"},
+			MatchFn:  isCmd("whoami"),
+			HandleFn: r.handleWhoami,
+		},
+		&feature.Spec{
+			ID:      "anime-cmd",
+			Prio:    prioCommand,
+			Toggle:  "anime",
+			MatchFn: isCmd("anime"),
+			HandleFn: func(m *feature.Msg) bool {
+				return an.TryHandle(m.Client, m.Event, m.Text)
+			},
+		},
+		&feature.Spec{
+			ID:       "reminder-cmd",
+			Prio:     prioCommand,
+			Toggle:   "reminder",
+			Lines:    []string{"help.reminder"},
+			MatchFn:  isCmd("reminder"),
+			HandleFn: r.handleReminderCmd,
+		},
+		&feature.Spec{
+			ID:      "peraturan-cmd",
+			Prio:    prioCommand,
+			Core:    true,
+			MatchFn: func(m *feature.Msg) bool { return pr != nil && isCmd("peraturan")(m) },
+			HandleFn: func(m *feature.Msg) bool {
+				handled, denied := pr.TryCommand(m.Client, m.Event, m.Args, m.Can(perm.Moderator))
+				if denied {
+					m.Outcome = feature.OutcomeDenied
+				}
+				return handled
+			},
+		},
+		&feature.Spec{
+			ID:   "persona",
+			Prio: prioCommand,
+			Core: true,
+			Lines: []string{
+				"help.persona",
+				"help.mode_pro",
+			},
+			MatchFn:  isCmd("elaina"),
+			HandleFn: r.handlePersonaCmd,
+		},
+		&feature.Spec{
+			ID:       "fitur",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.fitur"},
+			MatchFn:  isCmd("fitur"),
+			HandleFn: r.handleFiturCmd,
+		},
+		&feature.Spec{
+			ID:       "kuota",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.kuota"},
+			MatchFn:  isCmd("kuota"),
+			HandleFn: r.handleKuotaCmd,
+		},
+		&feature.Spec{
+			ID:       "trigger",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.trigger"},
+			MatchFn:  isCmd("trigger"),
+			HandleFn: r.handleTriggerCmd,
+		},
+		&feature.Spec{
+			ID:       "role",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.role"},
+			MatchFn:  isCmd("role"),
+			HandleFn: r.handleRoleCmd,
+		},
+		&feature.Spec{
+			ID:       "ban",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.ban"},
+			MatchFn:  isCmd("ban"),
+			HandleFn: r.handleBanCmd,
+		},
+		&feature.Spec{
+			ID:       "unban",
+			Prio:     prioCommand,
+			Core:     true,
+			MatchFn:  isCmd("unban"),
+			HandleFn: r.handleUnbanCmd,
+		},
+		&feature.Spec{
+			ID:       "allowgroup",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.allowgroup"},
+			MatchFn:  isCmd("allowgroup"),
+			HandleFn: r.handleAllowGroupCmd,
+		},
+		&feature.Spec{
+			ID:       "broadcast",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.broadcast"},
+			MatchFn:  isCmd("broadcast"),
+			HandleFn: r.handleBroadcastCmd,
+		},
+		&feature.Spec{
+			ID:       "audit",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.audit"},
+			MatchFn:  isCmd("audit"),
+			HandleFn: r.handleAuditCmd,
+		},
+		&feature.Spec{
+			ID:       "llmkeys",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.llmkeys"},
+			MatchFn:  isCmd("llmkeys"),
+			HandleFn: r.handleLLMKeysCmd,
+		},
+		&feature.Spec{
+			ID:       "lang",
+			Prio:     prioCommand,
+			Core:     true,
+			Lines:    []string{"help.lang"},
+			MatchFn:  isCmd("lang"),
+			HandleFn: r.handleLangCmd,
+		},
+
+		// ---- moderasi pasif ----
+		&feature.Spec{
+			ID:      "peraturan",
+			Prio:    prioObserver,
+			Core:    true,
+			Observe: true,
+			MatchFn: func(m *feature.Msg) bool { return pr != nil },
+			HandleFn: func(m *feature.Msg) bool {
+				pr.HandleMessage(m.Client, m.Event, m.Text)
+				return false
+			},
+		},
+
+		// ---- utilitas grup ----
+		&feature.Spec{
+			ID:      "rvo",
+			Prio:    prioUtility,
+			Lines:   []string{"help.rvo"},
+			MatchFn: allowUtility,
+			HandleFn: func(m *feature.Msg) bool {
+				return rv.TryHandle(m.Client, m.Event, m.Text)
+			},
+		},
+		&feature.Spec{
+			ID:      "tagall",
+			Prio:    prioUtility,
+			Lines:   []string{"help.tagall"},
+			MatchFn: allowUtility,
+			HandleFn: func(m *feature.Msg) bool {
+				return tall.TryHandle(m.Client, m.Event, m.Text)
+			},
+		},
+
+		&feature.Spec{
+			ID:       "reminder",
+			Prio:     prioReminder,
+			MatchFn:  func(m *feature.Msg) bool { return allowNonCommand(m) && reminder.Detect(m.Text) },
+			HandleFn: r.handleReminderNL,
+		},
+
+		&feature.Spec{
+			ID:    "tiktok",
+			Prio:  prioTikTok,
+			Lines: []string{"help.tiktok"},
+			MatchFn: func(m *feature.Msg) bool {
+				return m.Addressed && (!m.IsGroup || m.HasTrigTikTok || m.HasTikTokLink)
+			},
+			HandleFn: func(m *feature.Msg) bool {
+				return tk.TryHandle(m.Ctx, m.Event.Info, m.TikTokText)
+			},
+		},
+
+		// ---- fitur non-perintah ----
+		&feature.Spec{
+			ID:      "pap",
+			Prio:    prioMedia,
+			MatchFn: allowNonCommand,
+			HandleFn: func(m *feature.Msg) bool {
+				return pp.TryHandle(m.Client, m.Event, m.Text)
+			},
+		},
+		&feature.Spec{
+			ID:      "ba",
+			Prio:    prioMedia,
+			Lines:   []string{"help.ba"},
+			MatchFn: allowNonCommand,
+			HandleFn: func(m *feature.Msg) bool {
+				return ba.TryHandleText(context.Background(), m.Client, m.Event, m.Text, m.IsOwner)
+			},
+		},
+		&feature.Spec{
+			ID:      "hijabin",
+			Prio:    prioMedia,
+			Lines:   []string{"help.hijabin"},
+			MatchFn: func(m *feature.Msg) bool { return allowNonCommand(m) && hij.Matches(m.Event, m.Text) },
+			HandleFn: func(m *feature.Msg) bool {
+				return result(m)(hij.TryHandle(m.Ctx, m.Client, m.Event, m.Text, m.IsOwner))
+			},
+		},
+		// Brat dicek sebelum sticker biasa
+		&feature.Spec{
+			ID:      "brat",
+			Prio:    prioMedia,
+			Lines:   []string{"help.brat"},
+			MatchFn: allowNonCommand,
+			HandleFn: func(m *feature.Msg) bool {
+				return br.TryHandle(m.Client, m.Event, m.Text, m.IsOwner)
+			},
+		},
+		// Sticker diprioritaskan sebelum vision
+		&feature.Spec{
+			ID:      "sticker",
+			Prio:    prioMedia,
+			MatchFn: allowNonCommand,
+			HandleFn: func(m *feature.Msg) bool {
+				return stik.TryHandleTo(m.Ctx, m.Client, m.Event.Info, m.Event.Message, m.Text)
+			},
+		},
+		&feature.Spec{
+			ID:      "vision",
+			Prio:    prioMedia,
+			Lines:   []string{"help.vision"},
+			MatchFn: func(m *feature.Msg) bool { return allowNonCommand(m) && vis.Matches(m.Event, m.Text) },
+			HandleFn: func(m *feature.Msg) bool {
+				return result(m)(vis.TryHandle(m.Ctx, m.Client, m.Event, m.Text, m.IsOwner))
+			},
+		},
+		&feature.Spec{
+			ID:      "anime",
+			Prio:    prioMedia,
+			MatchFn: allowNonCommand,
+			HandleFn: func(m *feature.Msg) bool {
+				return an.TryHandle(m.Client, m.Event, m.Text)
+			},
+		},
+		&feature.Spec{
+			ID:      "tts",
+			Prio:    prioMedia,
+			Lines:   []string{"help.tts"},
+			MatchFn: func(m *feature.Msg) bool { return allowNonCommand(m) && tt.Matches(m.Event, m.Text) },
+			HandleFn: func(m *feature.Msg) bool {
+				return result(m)(tt.TryHandle(m.Ctx, m.Client, m.Event, m.Text))
+			},
+		},
+
+		&feature.Spec{
+			ID:      "vn",
+			Prio:    prioVoice,
+			Lines:   []string{"help.vn"},
+			MatchFn: func(m *feature.Msg) bool { return m.Addressed },
+			HandleFn: func(m *feature.Msg) bool {
+				return vnote.TryHandle(m.Ctx, m.Client, m.Event, m.IsOwner)
+			},
+		},
+
+		&feature.Spec{
+			ID:       "chat",
+			Prio:     prioChat,
+			Core:     true,
+			MatchFn:  func(m *feature.Msg) bool { return m.Addressed },
+			HandleFn: r.handleChat,
+		},
+	)
+}
+
+func isCmd(name string) func(m *feature.Msg) bool {
+	return func(m *feature.Msg) bool { return m.IsCmd && m.Cmd == name }
+}
+
+// allowUtility: rvo/tagall di grup hanya jika ada trigger atau !tagall.
+func allowUtility(m *feature.Msg) bool {
+	if !m.Addressed {
+		return false
+	}
+	return !m.IsGroup || m.HasTrigger || (m.IsCmd && strings.EqualFold(m.Cmd, "tagall"))
+}
+
+// result meneruskan hasil handler (handled, ok) fitur berkuota; handler yang
+// menangani pesan tetapi gagal ditandai OutcomeFailed agar kuotanya
+// dikembalikan (lihat quotaGuard).
+func result(m *feature.Msg) func(handled, ok bool) bool {
+	return func(handled, ok bool) bool {
+		if handled && !ok {
+			m.Outcome = feature.OutcomeFailed
+		}
+		return handled
+	}
+}
+
+// allowNonCommand: di grup wajib menyebut trigger.
+func allowNonCommand(m *feature.Msg) bool {
+	return m.Addressed && (!m.IsGroup || m.HasTrigger)
+}
+
+// ---- handler perintah bawaan ----
+
+func (r *Router) handleHelp(m *feature.Msg) bool {
+	userName, _ := memory.GetUserName(m.SenderJID)
+	greeting := m.T("help.greeting")
+	if userName != "" {
+		greeting = m.T("help.greeting_name", userName)
+	}
+	lines := []string{m.T("help.intro", greeting), ""}
+	name := r.trig.Primary(m.Chat.String())
+	for _, key := range r.features.HelpLines() {
+		lines = append(lines, strings.ReplaceAll(m.T(key), "{trigger}", name))
+	}
+	lines = append(lines, "", m.T("help.tips"))
+	replyText(context.Background(), m.Client, m.Event, strings.Join(lines, "\n"))
+	return true
+}
+
+func (r *Router) handleWhoami(m *feature.Msg) bool {
+	userName, _ := memory.GetUserName(m.SenderJID)
+	whoamiText := "Sender: " + m.Sender.String() + "\nChat  : " + m.Chat.String()
+	if userName != "" {
+		whoamiText += m.T("whoami.name", userName)
+	}
+	replyText(context.Background(), m.Client, m.Event, whoamiText)
+	return true
+}
+
+func (r *Router) handlePersonaCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	parts := strings.Fields(m.Args)
+	if len(parts) >= 2 && strings.EqualFold(parts[0], "persona") {
+		p := strings.ToLower(parts[1])
+		if p == "1" {
+			p = "elaina1"
+		}
+		if p == "2" {
+			p = "elaina2"
+		}
+		if p != "elaina1" && p != "elaina2" {
+			replyText(context.Background(), client, ev, m.T("persona.invalid"))
+			return true
+		}
+		_ = r.store.SetPersona(m.Chat.String(), p)
+		replyText(context.Background(), client, ev, m.T("persona.set", p))
+		return true
+	}
+	if len(parts) >= 3 && strings.EqualFold(parts[0], "mode") && strings.EqualFold(parts[1], "pro") {
+		on := strings.EqualFold(parts[2], "on") || strings.EqualFold(parts[2], "enable")
+		_ = r.store.SetPro(m.Chat.String(), on)
+		if on {
+			replyText(context.Background(), client, ev, m.T("persona.pro_on"))
+		} else {
+			replyText(context.Background(), client, ev, m.T("persona.pro_off"))
+		}
+		return true
+	}
+	replyText(context.Background(), client, ev, m.T("persona.usage"))
+	return true
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/i18n"
+)
+
+// chatLang mengembalikan bahasa chat dari cache atau db (default Indonesia).
+// Juga dipasang sebagai resolver i18n agar paket fitur bisa memakai i18n.For.
+func (r *Router) chatLang(chat string) i18n.Lang {
+	if v, ok := r.langs.Load(chat); ok {
+		return v.(i18n.Lang)
+	}
+	lang := i18n.Default
+	if r.store != nil {
+		code, err := r.store.ChatLang(chat)
+		if err != nil {
+			log.Printf("[LANG] gagal memuat bahasa %s: %v", chat, err)
+			return lang
+		}
+		if l, ok := i18n.Parse(code); ok {
+			lang = l
+		}
+	}
+	r.langs.Store(chat, lang)
+	return lang
+}
+
+func (r *Router) handleLangCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	parts := strings.Fields(strings.ToLower(m.Args))
+
+	if len(parts) == 0 {
+		replyText(context.Background(), client, ev, m.T("lang.current", m.Lang.Name(), m.Lang))
+		return true
+	}
+	if len(parts) > 1 {
+		replyText(context.Background(), client, ev, m.T("lang.usage"))
+		return true
+	}
+	lang, ok := i18n.Parse(parts[0])
+	if !ok {
+		replyText(context.Background(), client, ev, m.T("lang.unknown", parts[0], strings.Join(i18n.Supported(), ", ")))
+		return true
+	}
+	if !r.canManageChat(m) {
+		deny(m, "lang.admin_only")
+		return true
+	}
+
+	chat := m.Chat.String()
+	if err := r.store.SetChatLang(chat, string(lang)); err != nil {
+		replyText(context.Background(), client, ev, m.T("lang.save_failed", err))
+		return true
+	}
+	r.langs.Store(chat, lang)
+	m.Lang = lang
+	replyText(context.Background(), client, ev, m.T("lang.set", lang.Name()))
+	return true
+}
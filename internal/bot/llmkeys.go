@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/llm"
+	"wa-elaina/internal/perm"
+)
+
+// handleLLMKeysCmd: !llmkeys — jumlah sukses/gagal dan status cooldown tiap
+// API key di pool LLM (key disamarkan).
+func (r *Router) handleLLMKeysCmd(m *feature.Msg) bool {
+	if !m.Can(perm.CoOwner) {
+		deny(m, "access.owner_only")
+		return true
+	}
+	pools := llm.Pools()
+	if len(pools) == 0 {
+		replyText(context.Background(), m.Client, m.Event, m.T("llmkeys.empty"))
+		return true
+	}
+	loc := r.remind.Location()
+	lines := []string{m.T("llmkeys.title")}
+	for _, p := range pools {
+		lines = append(lines, m.T("llmkeys.pool", p.Name(), p.Len()))
+		for i, st := range p.Stats() {
+			status := m.T("llmkeys.active")
+			switch {
+			case st.BenchedTo.IsZero():
+			case st.AuthBench:
+				status = m.T("llmkeys.rejected", st.BenchedTo.In(loc).Format("15:04:05"))
+			default:
+				status = m.T("llmkeys.cooldown", st.BenchedTo.In(loc).Format("15:04:05"))
+			}
+			lines = append(lines, m.T("llmkeys.line", i+1, st.Key, st.OK, st.Fail, st.Quota, st.AuthFail, status))
+			if st.LastError != "" && !st.BenchedTo.IsZero() {
+				lines = append(lines, m.T("llmkeys.last_error", shorten(st.LastError, 120)))
+			}
+		}
+	}
+	replyText(context.Background(), m.Client, m.Event, strings.Join(lines, "\n"))
+	return true
+}
+
+func shorten(s string, n int) string {
+	if rs := []rune(s); len(rs) > n {
+		return string(rs[:n]) + "…"
+	}
+	return s
+}
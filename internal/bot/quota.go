@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/quota"
+)
+
+// quotaGuard menerapkan cooldown & kuota harian per user untuk fitur mahal.
+// Owner bot selalu dikecualikan. Kuota dipotong sebelum handler jalan (agar
+// permintaan paralel tidak lolos bersamaan) lalu dikembalikan lewat
+// Rollback jika handler gagal atau tidak menangani pesan.
+func (r *Router) quotaGuard(m *feature.Msg, f feature.Feature) feature.Verdict {
+	if r.quota == nil || m.IsOwner || !r.quota.Limited(f.Name()) {
+		return feature.Allow
+	}
+	dec, err := r.quota.Take(m.Chat.String(), m.Sender.ToNonAD().String(), f.Name())
+	if err != nil {
+		log.Printf("[KUOTA] %s/%s: %v", f.Name(), m.Sender.String(), err)
+	}
+	if dec.OK {
+		m.Rollback = func() {
+			if err := r.quota.Refund(dec); err != nil {
+				log.Printf("[KUOTA] refund %s/%s: %v", f.Name(), m.Sender.String(), err)
+			}
+		}
+		return feature.Allow
+	}
+	m.Outcome = feature.OutcomeQuota
+	replyText(context.Background(), m.Client, m.Event, dec.Message(m.Lang, f.Name()))
+	return feature.Stop
+}
+
+func (r *Router) handleKuotaCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	parts := strings.Fields(strings.ToLower(m.Args))
+	usage := m.T("kuota.usage")
+
+	if r.quota == nil {
+		replyText(context.Background(), client, ev, m.T("kuota.inactive"))
+		return true
+	}
+	if len(parts) == 0 || parts[0] == "list" {
+		replyText(context.Background(), client, ev, r.kuotaList(m))
+		return true
+	}
+	if (parts[0] != "set" || len(parts) < 3) && (parts[0] != "reset" || len(parts) < 2) {
+		replyText(context.Background(), client, ev, usage)
+		return true
+	}
+	// Override kuota memengaruhi biaya API berbayar: moderator bot ke atas,
+	// bukan admin grup atau siapa pun di chat pribadi.
+	if !m.Can(perm.Moderator) {
+		deny(m, "kuota.admin_only")
+		return true
+	}
+	name := parts[1]
+	if !r.quota.Limited(name) {
+		replyText(context.Background(), client, ev, m.T("kuota.not_limited", name, strings.Join(r.quota.Features(), ", ")))
+		return true
+	}
+
+	chat := m.Chat.String()
+	if parts[0] == "reset" {
+		if err := r.store.DeleteChatQuota(chat, name); err != nil {
+			replyText(context.Background(), client, ev, m.T("kuota.reset_failed", err))
+			return true
+		}
+		replyText(context.Background(), client, ev, m.T("kuota.reset_done", name))
+		return true
+	}
+
+	daily := ""
+	if len(parts) >= 4 {
+		daily = parts[3]
+	}
+	lim, err := quota.ParseLimit(parts[2], daily)
+	if err != nil {
+		replyText(context.Background(), client, ev, err.Error()+"\n"+usage)
+		return true
+	}
+	if (lim.Cooldown <= 0 || lim.Daily <= 0) && !m.Can(perm.Owner) {
+		deny(m, "kuota.unlimited")
+		return true
+	}
+	if err := r.store.SetChatQuota(chat, name, lim); err != nil {
+		replyText(context.Background(), client, ev, m.T("kuota.save_failed", err))
+		return true
+	}
+	replyText(context.Background(), client, ev, m.T("kuota.saved", name, formatLimit(m.Lang, lim)))
+	return true
+}
+
+func (r *Router) kuotaList(m *feature.Msg) string {
+	limits, _ := r.quota.Limits(m.Chat.String())
+	used := r.quota.Usage(m.Sender.ToNonAD().String())
+	var sb strings.Builder
+	sb.WriteString(m.T("kuota.list_title") + "\n")
+	for _, name := range r.quota.Features() {
+		lim := limits[name]
+		line := fmt.Sprintf("- %s : %s", name, formatLimit(m.Lang, lim))
+		if lim.Daily > 0 {
+			line += m.T("kuota.list_usage", used[name], lim.Daily)
+		}
+		sb.WriteString(line + "\n")
+	}
+	if m.IsOwner {
+		sb.WriteString("\n" + m.T("kuota.owner_free"))
+	} else {
+		sb.WriteString("\n" + m.T("kuota.list_footer"))
+	}
+	return sb.String()
+}
+
+func formatLimit(l i18n.Lang, lim db.QuotaLimit) string {
+	cd := l.T("kuota.no_cooldown")
+	if lim.Cooldown > 0 {
+		cd = l.T("kuota.cooldown", lim.Cooldown.String())
+	}
+	daily := l.T("kuota.no_daily")
+	if lim.Daily > 0 {
+		daily = l.T("kuota.daily", lim.Daily)
+	}
+	return cd + ", " + daily
+}
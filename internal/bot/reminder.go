@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/reminder"
+)
+
+const reminderTimeFmt = "02/01/2006 15:04 MST"
+
+// Reminders mengekspos scheduler pengingat (dijalankan dari main).
+func (r *Router) Reminders() *reminder.Scheduler { return r.remind }
+
+// handleReminderNL: "elaina ingatkan aku besok jam 7 buat meeting".
+// Tanpa keterangan waktu → serahkan ke fitur berikutnya (obrolan biasa).
+func (r *Router) handleReminderNL(m *feature.Msg) bool {
+	text := r.trig.Strip(m.Chat.String(), m.Text)
+	if _, err := reminder.Parse(text, r.remind.Now()); errors.Is(err, reminder.ErrNoTime) {
+		return false
+	}
+	r.addReminder(m, text)
+	return true
+}
+
+// handleReminderCmd: !reminder <waktu> <pesan> | list | hapus <id>
+func (r *Router) handleReminderCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	sub, rest, _ := strings.Cut(strings.TrimSpace(m.Args), " ")
+	switch strings.ToLower(sub) {
+	case "", "list":
+		replyText(context.Background(), client, ev, r.reminderList(m))
+	case "hapus", "del", "delete", "remove":
+		id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(rest), "#"), 10, 64)
+		if err != nil {
+			replyText(context.Background(), client, ev, m.T("reminder.usage"))
+			return true
+		}
+		ok, err := r.remind.Delete(id, m.Sender)
+		switch {
+		case err != nil:
+			replyText(context.Background(), client, ev, m.T("reminder.save_failed", err))
+		case !ok:
+			replyText(context.Background(), client, ev, m.T("reminder.not_found", id))
+		default:
+			replyText(context.Background(), client, ev, m.T("reminder.deleted", id))
+		}
+	default:
+		r.addReminder(m, m.Args)
+	}
+	return true
+}
+
+func (r *Router) addReminder(m *feature.Msg, text string) {
+	client, ev := m.Client, m.Event
+	req, err := reminder.Parse(text, r.remind.Now())
+	switch {
+	case errors.Is(err, reminder.ErrNoTime):
+		replyText(context.Background(), client, ev, m.T("reminder.no_time"))
+		return
+	case errors.Is(err, reminder.ErrPast):
+		replyText(context.Background(), client, ev, m.T("reminder.past", req.At.Format(reminderTimeFmt)))
+		return
+	case errors.Is(err, reminder.ErrTooFar):
+		replyText(context.Background(), client, ev, m.T("reminder.too_far"))
+		return
+	}
+
+	id, err := r.remind.Add(m.Chat, m.Sender, req)
+	if errors.Is(err, reminder.ErrLimit) {
+		replyText(context.Background(), client, ev, m.T("reminder.limit", reminder.MaxPerUser))
+		return
+	}
+	if err != nil {
+		replyText(context.Background(), client, ev, m.T("reminder.save_failed", err))
+		return
+	}
+	what := req.Text
+	if what == "" {
+		what = m.T("reminder.no_text")
+	}
+	replyText(context.Background(), client, ev, m.T("reminder.saved", id, req.At.Format(reminderTimeFmt), what))
+}
+
+func (r *Router) reminderList(m *feature.Msg) string {
+	list, err := r.remind.Pending(m.Sender)
+	if err != nil {
+		return m.T("reminder.save_failed", err)
+	}
+	if len(list) == 0 {
+		return m.T("reminder.list_empty")
+	}
+	lines := []string{m.T("reminder.list_title")}
+	for _, rem := range list {
+		what := rem.Text
+		if what == "" {
+			what = m.T("reminder.no_text")
+		}
+		lines = append(lines, fmt.Sprintf("#%d • %s • %s", rem.ID, rem.Due.In(r.remind.Location()).Format(reminderTimeFmt), what))
+	}
+	lines = append(lines, "", m.T("reminder.list_footer"))
+	return strings.Join(lines, "\n")
+}
+
+// deliverReminder dipanggil scheduler saat pengingat jatuh tempo. JID rusak
+// dan chat yang tidak bisa lagi dikirimi dibungkus reminder.ErrPermanent.
+func (r *Router) deliverReminder(rem db.Reminder, late bool) error {
+	chat, err := types.ParseJID(rem.Chat)
+	if err != nil {
+		return fmt.Errorf("%w: chat %q: %v", reminder.ErrPermanent, rem.Chat, err)
+	}
+	lang := i18n.For(rem.Chat)
+	what := rem.Text
+	if what == "" {
+		what = lang.T("reminder.no_text")
+	}
+	var lateNote string
+	if late {
+		lateNote = lang.T("reminder.late", rem.Due.In(r.remind.Location()).Format(reminderTimeFmt))
+	}
+	if chat.Server != types.GroupServer {
+		return permanentSendErr(r.send.Text(chat, lang.T("reminder.fire", what)+lateNote))
+	}
+	user, err := types.ParseJID(rem.User)
+	if err != nil {
+		return fmt.Errorf("%w: user %q: %v", reminder.ErrPermanent, rem.User, err)
+	}
+	return permanentSendErr(r.send.TextMention(chat, lang.T("reminder.fire_group", user.User, what)+lateNote, []types.JID{user}))
+}
+
+// permanentSendErr membungkus error kirim yang tidak akan berhasil bila
+// diulang (bot sudah keluar/grup dihapus, tujuan tidak valid).
+func permanentSendErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, whatsmeow.ErrNotInGroup),
+		errors.Is(err, whatsmeow.ErrGroupNotFound),
+		errors.Is(err, whatsmeow.ErrUnknownServer),
+		errors.Is(err, whatsmeow.ErrRecipientADJID),
+		errors.Is(err, whatsmeow.ErrBroadcastListUnsupported):
+		return fmt.Errorf("%w: %w", reminder.ErrPermanent, err)
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+)
+
+// muteGuard: pesan Muted hanya diteruskan ke fitur Observer (moderasi tetap
+// berjalan untuk user banned), fitur lain dilewati tanpa balasan.
+func (r *Router) muteGuard(m *feature.Msg, f feature.Feature) feature.Verdict {
+	if !m.Muted {
+		return feature.Allow
+	}
+	if o, ok := f.(feature.Observer); ok && o.Observes() {
+		return feature.Allow
+	}
+	return feature.Skip
+}
+
+// UseAltJID memasang pemetaan LID ↔ nomor HP agar role yang diberikan ke
+// satu bentuk JID berlaku juga untuk bentuk lainnya.
+func (r *Router) UseAltJID(fn perm.AltFunc) { r.roles.UseAlt(fn) }
+
+// roleGuard menolak fitur yang butuh role lebih tinggi dari role pengirim.
+// FEATURE_ROLES (per nama fitur atau nama toggle) menimpa Spec.Need.
+func (r *Router) roleGuard(m *feature.Msg, f feature.Feature) feature.Verdict {
+	need := r.requiredRole(f)
+	if need <= perm.User || m.Can(need) {
+		return feature.Allow
+	}
+	deny(m, "role.need", need)
+	return feature.Stop
+}
+
+func (r *Router) requiredRole(f feature.Feature) perm.Role {
+	if need, ok := r.needs[f.Name()]; ok {
+		return need
+	}
+	if t, ok := f.(feature.Toggleable); ok && t.ToggleName() != "" {
+		if need, ok := r.needs[t.ToggleName()]; ok {
+			return need
+		}
+	}
+	if rf, ok := f.(feature.Restricted); ok {
+		return rf.Requires()
+	}
+	return perm.User
+}
+
+// handleRoleCmd: !role [list | grant <role> <target> | revoke <target>]
+func (r *Router) handleRoleCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	parts := strings.Fields(m.Args)
+	sub := ""
+	if len(parts) > 0 {
+		sub = strings.ToLower(parts[0])
+	}
+
+	switch sub {
+	case "", "me":
+		replyText(context.Background(), client, ev, m.T("role.me", m.Role))
+		return true
+	case "list":
+		if !m.Can(perm.Moderator) {
+			deny(m, "role.list_denied")
+			return true
+		}
+		replyText(context.Background(), client, ev, r.roleList(m))
+		return true
+	case "grant", "revoke":
+	default:
+		replyText(context.Background(), client, ev, m.T("role.usage", grantableNames()))
+		return true
+	}
+
+	next, rest := perm.User, parts[1:]
+	if sub == "grant" {
+		if len(parts) < 2 {
+			replyText(context.Background(), client, ev, m.T("role.usage", grantableNames()))
+			return true
+		}
+		role, ok := perm.Parse(parts[1])
+		if !ok || role == perm.User || role == perm.Owner {
+			replyText(context.Background(), client, ev, m.T("role.unknown", parts[1], grantableNames()))
+			return true
+		}
+		next, rest = role, parts[2:]
+	}
+
+	target, _, ok := targetJID(m, rest)
+	if !ok {
+		replyText(context.Background(), client, ev, m.T("role.no_target"))
+		return true
+	}
+	if perm.Key(target) == perm.Key(m.Sender) {
+		replyText(context.Background(), client, ev, m.T("role.self"))
+		return true
+	}
+	if !perm.CanGrant(m.Role, r.roles.Of(target), next) {
+		deny(m, "role.forbidden", m.Role)
+		return true
+	}
+	if err := r.roles.Grant(target, next, perm.Key(m.Sender)); err != nil {
+		replyText(context.Background(), client, ev, m.T("role.save_failed", err))
+		return true
+	}
+	log.Printf("[ROLE] %s → %s oleh %s", perm.Key(target), next, perm.Key(m.Sender))
+
+	reply := m.T("role.granted", target.User, next)
+	if next == perm.User {
+		reply = m.T("role.revoked", target.User)
+	}
+	replyTextMention(context.Background(), client, ev, reply, []types.JID{target.ToNonAD()})
+	return true
+}
+
+func (r *Router) roleList(m *feature.Msg) string {
+	entries, err := r.roles.List()
+	if err != nil {
+		return m.T("role.save_failed", err)
+	}
+	if len(entries) == 0 {
+		return m.T("role.list_empty")
+	}
+	lines := []string{m.T("role.list_title")}
+	for _, e := range entries {
+		user, _, _ := strings.Cut(e.User, "@")
+		lines = append(lines, "- "+user+" : "+e.Role.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func grantableNames() string {
+	var names []string
+	for _, role := range perm.Grantable() {
+		names = append(names, role.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// targetJID: user yang di-tag, pengirim pesan yang di-reply, atau nomor di
+// argumen pertama. rest = argumen sisanya (tanpa token @mention/nomor).
+func targetJID(m *feature.Msg, args []string) (target types.JID, rest []string, ok bool) {
+	if xt := m.Event.Message.GetExtendedTextMessage(); xt != nil && xt.GetContextInfo() != nil {
+		ci := xt.GetContextInfo()
+		if ms := ci.GetMentionedJid(); len(ms) > 0 {
+			if j, err := types.ParseJID(ms[0]); err == nil {
+				for _, a := range args {
+					if !strings.HasPrefix(a, "@") {
+						rest = append(rest, a)
+					}
+				}
+				return j, rest, true
+			}
+		}
+		if p := ci.GetParticipant(); p != "" {
+			if j, err := types.ParseJID(p); err == nil {
+				return j, args, true
+			}
+		}
+	}
+	if len(args) == 0 {
+		return types.JID{}, nil, false
+	}
+	digits := strings.Map(func(c rune) rune {
+		if c >= '0' && c <= '9' {
+			return c
+		}
+		return -1
+	}, args[0])
+	if len(digits) < 6 {
+		return types.JID{}, args, false
+	}
+	return types.NewJID(digits, types.DefaultUserServer), args[1:], true
+}
@@ -5,366 +5,286 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	pbf "google.golang.org/protobuf/proto"
 
 	dl "wa-elaina/downloader"
+	"wa-elaina/internal/access"
+	"wa-elaina/internal/broadcast"
 	"wa-elaina/internal/config"
 	"wa-elaina/internal/db"
-	"wa-elaina/internal/feature/anime"
-	"wa-elaina/internal/feature/baimg"
-	"wa-elaina/internal/feature/brat"
-	"wa-elaina/internal/feature/hijabin"
-	"wa-elaina/internal/feature/imggen" // Import image generation handler
+	"wa-elaina/internal/dedup"
+	"wa-elaina/internal/feature"
 	"wa-elaina/internal/feature/owner"
-	"wa-elaina/internal/feature/pap"
-	"wa-elaina/internal/feature/peraturan"
-	"wa-elaina/internal/feature/rvo"
-	"wa-elaina/internal/feature/sticker"
-	"wa-elaina/internal/feature/tagall"
-	"wa-elaina/internal/feature/tkwrap"
-	"wa-elaina/internal/feature/tts"
-	"wa-elaina/internal/feature/vision"
-	"wa-elaina/internal/feature/vn"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/llm"
 	"wa-elaina/internal/memory"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/quota"
+	"wa-elaina/internal/reminder"
+	"wa-elaina/internal/trigger"
 	"wa-elaina/internal/wa"
 )
 
 var reReplyCue = regexp.MustCompile(`(?i)\b(balas(in|lah)?|reply|jawab(in|lah)?)(\s+ini)?\b`)
 
 type Router struct {
-	cfg    config.Config
-	send   *wa.Sender
-	ready  *atomic.Bool
-	reTrig *regexp.Regexp
-	store  *db.Store
-
-	owner     *owner.Detector
-	ba        *baimg.Handler
-	hijab     *hijabin.Handler
-	vis       *vision.Handler
-	vnote     *vn.Handler
-	tts       *tts.Handler
-	tiktok    *tkwrap.Handler
-	rvo       *rvo.Handler
-	tall      *tagall.Handler
-	stik      *sticker.Handler
-	anime     *anime.Handler
-	brat      *brat.Handler
-	imggen    *imggen.Handler // Tambah image generation handler
-	peraturan *peraturan.Handler
-	pap       *pap.Handler
+	cfg   config.Config
+	send  *wa.Sender
+	ready *atomic.Bool
+	trig  *trigger.Matcher
+	store *db.Store
+	owner *owner.Detector
+	roles *perm.Checker
+	needs map[string]perm.Role // FEATURE_ROLES
+	acl   *access.List         // !ban / !allowgroup
+	quota *quota.Limiter
+	dedup *dedup.Filter
+	langs sync.Map // chat JID → i18n.Lang (cache !lang)
+	busy  busyNotes
+
+	recent *recent // status handled & cancel per pesan (edit/revoke)
+
+	remind *reminder.Scheduler
+	bcast  *broadcast.Scheduler
+
+	features *feature.Registry
 }
 
 func NewRouter(cfg config.Config, s *wa.Sender, ready *atomic.Bool, store *db.Store) *Router {
-	trig := cfg.Trigger
-	if trig == "" {
-		trig = "elaina"
+	if strings.TrimSpace(cfg.Trigger) == "" {
+		cfg.Trigger = trigger.Fallback
 	}
 
-	reTrig := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(trig) + `\b`)
-
+	roles := perm.New(store)
 	rt := &Router{
-		cfg:    cfg,
-		send:   s,
-		ready:  ready,
-		reTrig: reTrig,
-		store:  store,
-		owner:  owner.NewFromEnv(),
-		ba:     baimg.New(cfg),
-		hijab:  hijabin.New(cfg, s),
-		tiktok: tkwrap.New(cfg, s),
-		rvo:    rvo.New(),
-		tall:   tagall.New(trig),
-		stik:   sticker.New(),
-		imggen: imggen.New(cfg), // Initialize image generation handler
-		pap:    pap.New(cfg),
-	}
-
-	// Initialize handlers yang membutuhkan rt setelah struct dibuat
-	rt.brat = brat.New(rt.reTrig)
+		cfg:      cfg,
+		send:     s,
+		ready:    ready,
+		trig:     trigger.New(store, cfg.Trigger),
+		store:    store,
+		owner:    owner.NewFromEnv(),
+		roles:    roles,
+		acl:      access.New(store, roles, cfg.AllowlistOnly),
+		dedup:    dedup.New(store, cfg.DedupCache, cfg.DedupTTL, cfg.MsgMaxAge),
+		recent:   newRecent(),
+		busy:     busyNotes{last: make(map[string]time.Time)},
+		features: feature.NewRegistry(),
+	}
+
+	loc := reminder.LoadLocation(cfg.Timezone)
+	lim, err := quota.New(store, cfg.QuotaLimits, loc)
+	if err != nil {
+		log.Printf("[KUOTA] QUOTA_LIMITS: %v", err)
+	}
+	rt.quota = lim
+
+	needs, err := perm.ParseRequirements(cfg.FeatureRoles)
+	if err != nil {
+		log.Printf("[ROLE] FEATURE_ROLES: %v", err)
+	}
+	rt.needs = needs
+	rt.remind = reminder.NewScheduler(store, loc, ready, rt.deliverReminder)
+	rt.bcast = broadcast.NewScheduler(store, loc, ready, s, cfg.BroadcastMaxMedia)
 
 	llm.Init(cfg)
-	rt.vis = vision.New(cfg, s, rt.reTrig, rt.owner)
-	rt.vnote = vn.New(cfg, s, rt.reTrig, rt.owner)
-	rt.anime = anime.New(rt.reTrig, s)
-	rt.peraturan = peraturan.New(store)
-	rt.tts = tts.New(cfg, rt.reTrig)
+	wa.ConfigureProgress(cfg.ProgressReactions, cfg.ProgressPresence)
+	i18n.SetResolver(rt.chatLang)
+	rt.registerFeatures()
+	rt.features.Use(rt.muteGuard)
+	rt.features.Use(rt.roleGuard)
+	rt.features.Use(rt.featureGuard)
+	rt.features.Use(rt.quotaGuard)
 	return rt
 }
 
-func (r *Router) HandleMessage(client *whatsmeow.Client, m *events.Message) {
+// Features mengekspos registry (mis. untuk !help atau debug).
+func (r *Router) Features() *feature.Registry { return r.features }
+
+func (r *Router) HandleMessage(client wa.Client, m *events.Message) {
 	if m.Info.IsFromMe || !r.ready.Load() {
 		return
 	}
-
-	to := m.Info.Chat
-	txt := extractText(m)
-	origTxt := txt
-	senderJID := m.Info.Sender.String() // JID untuk tracking nama
-
-	isOwner := r.owner.IsOwner(m.Info)
-	r.owner.Debug(m.Info, isOwner)
-
-	var (
-		quotedImg  = false
-		quotedAud  = false
-		quotedText = ""
-	)
-	if xt := m.Message.GetExtendedTextMessage(); xt != nil && xt.ContextInfo != nil {
-		if qm := xt.GetContextInfo().GetQuotedMessage(); qm != nil {
-			quotedImg = qm.GetImageMessage() != nil
-			quotedAud = qm.GetAudioMessage() != nil
-			if t := qm.GetConversation(); t != "" {
-				quotedText = t
-			} else if et := qm.GetExtendedTextMessage(); et != nil {
-				quotedText = et.GetText()
-			}
-		}
-	}
-	if quotedImg || quotedAud || quotedText != "" {
-		log.Printf("[REPLY] chat=%s quoted{img:%t aud:%t textLen:%d}", m.Info.Chat.String(), quotedImg, quotedAud, len(quotedText))
+	if r.dedup.TooOld(m.Info.Timestamp) {
+		log.Printf("[DEDUP] lewati pesan lama chat=%s id=%s ts=%s", m.Info.Chat.String(), m.Info.ID, m.Info.Timestamp.Format(time.RFC3339))
+		return
 	}
-
-	// PRIORITAS TERTINGGI: IMAGE GENERATION - cek pertama kali
-	if r.imggen.TryHandle(client, m, origTxt, isOwner) {
+	if r.dedup.Seen(m.Info.Chat.String() + "/" + m.Info.ID) {
+		log.Printf("[DEDUP] lewati duplikat chat=%s id=%s", m.Info.Chat.String(), m.Info.ID)
 		return
 	}
-
-	if cmd, rest, ok := parseBang(txt); ok {
-		switch cmd {
-		case "help":
-			userName, _ := memory.GetUserName(senderJID)
-			greeting := "Hai!"
-			if userName != "" {
-				greeting = "Hai " + userName + "!"
-			}
-			lines := []string{
-				greeting + " Ini perintah yang bisa kamu gunakan:",
-				"",
-				"- !help : bantuan ringkas",
-				"- !whoami : lihat JID/LID kamu",
-				"- !tagall / elaina tagall : mention semua anggota grup",
-				"- !rvo : buka media sekali lihat (reply ke pesannya)",
-				"- ba / kirim gambar blue archive : gambar BA",
-				"- elaina hijabin : berhijabkan gambar (kirim/quote gambar)",
-				"- elaina vn <teks> : kirim voice note",
-				"- kirim gambar + sebut '" + r.cfg.Trigger + "' : analisis gambar",
-				"- vn sebut 'elaina' : transkrip & jawab",
-				"- kirim link TikTok : unduh via TikWM",
-				"- elaina brat <teks> : buat sticker brat",
-				"- elaina buatin gambar <prompt> / !gambar <prompt> : generate gambar AI",
-				"- !elaina persona elaina1|elaina2 : pilih persona AI (persist)",
-				"- !elaina mode pro on|off : aktifkan Mode Pro (persist)",
-				"",
-				"Tips: katakan \"panggil aku [nama]\" supaya aku ingat namamu!",
-			}
-			replyText(context.Background(), client, m, strings.Join(lines, "\n"))
-			return
-		case "whoami":
-			userName, _ := memory.GetUserName(senderJID)
-			whoamiText := "Sender: " + m.Info.Sender.String() + "\nChat  : " + m.Info.Chat.String()
-			if userName != "" {
-				whoamiText += "\nNama tersimpan: " + userName
-			}
-			replyText(context.Background(), client, m, whoamiText)
-			return
-		case "anime":
-			if r.anime != nil && r.anime.TryHandle(client, m, origTxt) {
-				return
-			}
-		case "peraturan":
-			if r.peraturan != nil && r.peraturan.TryCommand(client, m, rest, isOwner) {
-				return
-			}
-		case "elaina":
-			after := strings.TrimSpace(strings.TrimPrefix(txt, "!elaina"))
-			parts := strings.Fields(after)
-			if len(parts) >= 2 && strings.EqualFold(parts[0], "persona") {
-				p := strings.ToLower(parts[1])
-				if p == "1" {
-					p = "elaina1"
-				}
-				if p == "2" {
-					p = "elaina2"
-				}
-				if p != "elaina1" && p != "elaina2" {
-					replyText(context.Background(), client, m, "Persona tidak valid. Gunakan: elaina1 atau elaina2.")
-					return
-				}
-				_ = r.store.SetPersona(m.Info.Chat.String(), p)
-				replyText(context.Background(), client, m, "Persona disetel ke "+p+" untuk chat ini.")
-				return
-			}
-			if len(parts) >= 3 && strings.EqualFold(parts[0], "mode") && strings.EqualFold(parts[1], "pro") {
-				on := strings.EqualFold(parts[2], "on") || strings.EqualFold(parts[2], "enable")
-				_ = r.store.SetPro(m.Info.Chat.String(), on)
-				if on {
-					replyText(context.Background(), client, m, "Mode Pro diaktifkan (persist).")
-				} else {
-					replyText(context.Background(), client, m, "Mode Pro dimatikan (persist).")
-				}
-				return
-			}
-			replyText(context.Background(), client, m, "Gunakan: !elaina persona elaina1|elaina2  atau  !elaina mode pro on|off")
+	if _, ok := RevokedID(m); ok {
+		return // ditangani langsung di event handler (CancelMessage)
+	}
+	edited := false
+	if ed, ok := unwrapEdit(m); ok {
+		if !r.reevaluate(m.Info.Chat.String() + "/" + ed.Info.ID) {
 			return
 		}
+		log.Printf("[EDIT] evaluasi ulang chat=%s id=%s", m.Info.Chat.String(), ed.Info.ID)
+		m, edited = ed, true
 	}
+	key := m.Info.Chat.String() + "/" + m.Info.ID
 
-	if r.peraturan != nil {
-		r.peraturan.HandleMessage(client, m, origTxt)
+	msg := r.buildMsg(client, m)
+	msg.Edited = edited
+	r.owner.Debug(m.Info, msg.IsOwner)
+	// User banned & chat di luar ACL tidak dibalas, tetapi moderasi grup
+	// (fitur Observer) tetap berjalan.
+	msg.Muted = !r.admitted(msg)
+
+	if msg.HasQuoted() {
+		log.Printf("[REPLY] chat=%s quoted{img:%t aud:%t textLen:%d}", m.Info.Chat.String(), msg.QuotedImg, msg.QuotedAud, len(msg.QuotedText))
 	}
 
-	cmd, _, isCmd := parseBang(origTxt)
-	isGroup := to.Server == types.GroupServer
-	hasTrig := r.reTrig.MatchString(origTxt)
-	isTagAllCmd := isCmd && strings.EqualFold(cmd, "tagall")
+	ctx, done := r.recent.start(key)
+	defer done()
+	msg.Ctx = ctx
 
-	hasQuoted := quotedImg || quotedAud || quotedText != ""
-	if hasQuoted && !hasTrig && !isCmd {
-		return
-	}
+	_, handled := r.features.Dispatch(msg)
+	r.recent.mark(key, handled)
+	r.audit(msg, handled)
+}
 
-	hasImage := m.Message.ImageMessage != nil
-	hasVideo := m.Message.VideoMessage != nil
-	if isGroup && (hasImage || hasVideo) && !hasTrig && !isCmd {
-		return
+// buildMsg menghitung semua sinyal gating sekali per pesan.
+func (r *Router) buildMsg(client wa.Client, m *events.Message) *feature.Msg {
+	txt := extractText(m)
+	chat := m.Info.Chat.String()
+	msg := &feature.Msg{
+		Client:    client,
+		Event:     m,
+		Chat:      m.Info.Chat,
+		Sender:    m.Info.Sender,
+		Text:      txt,
+		SenderJID: m.Info.Sender.String(),
+		IsOwner:   r.owner.IsOwner(m.Info),
+		IsGroup:   m.Info.Chat.Server == types.GroupServer,
+		Lang:      r.chatLang(chat),
 	}
+	msg.Role = r.roles.RoleOf(m.Info.Sender, msg.IsOwner)
+	msg.Cmd, msg.Args, msg.IsCmd = parseBang(txt)
+	msg.HasTrigger = r.trig.Match(chat, txt)
 
-	tiktokText := origTxt
-	hasTrigTikTok := hasTrig
-	if ext := m.Message.GetExtendedTextMessage(); ext != nil {
-		if s := ext.GetMatchedText(); s != "" {
-			tiktokText += " " + s
-			if r.reTrig.MatchString(s) {
-				hasTrigTikTok = true
+	if xt := m.Message.GetExtendedTextMessage(); xt != nil && xt.ContextInfo != nil {
+		if qm := xt.GetContextInfo().GetQuotedMessage(); qm != nil {
+			msg.QuotedImg = qm.GetImageMessage() != nil
+			msg.QuotedAud = qm.GetAudioMessage() != nil
+			if t := qm.GetConversation(); t != "" {
+				msg.QuotedText = t
+			} else if et := qm.GetExtendedTextMessage(); et != nil {
+				msg.QuotedText = et.GetText()
 			}
 		}
-		if s := ext.GetText(); s != "" && r.reTrig.MatchString(s) {
-			hasTrigTikTok = true
-		}
 	}
 
-	allowRvoTagall := !isGroup || hasTrig || isTagAllCmd
-	if allowRvoTagall {
-		if r.rvo.TryHandle(client, m, txt) {
-			return
+	msg.TikTokText = txt
+	msg.HasTrigTikTok = msg.HasTrigger
+	if ext := m.Message.GetExtendedTextMessage(); ext != nil {
+		if s := ext.GetMatchedText(); s != "" {
+			msg.TikTokText += " " + s
+			if r.trig.Match(chat, s) {
+				msg.HasTrigTikTok = true
+			}
 		}
-		if r.tall.TryHandle(client, m, txt) {
-			return
+		if s := ext.GetText(); s != "" && r.trig.Match(chat, s) {
+			msg.HasTrigTikTok = true
 		}
 	}
+	msg.HasTikTokLink = len(dl.DetectTikTokURLs(msg.TikTokText)) > 0
 
-	hasTikTokLink := len(dl.DetectTikTokURLs(tiktokText)) > 0
-
-	allowTikTok := !isGroup || hasTrigTikTok || hasTikTokLink
-	if allowTikTok && r.tiktok.TryHandle(tiktokText, to) {
-		return
+	// Reply atau media di grup tanpa trigger/perintah → bukan untuk bot.
+	msg.Addressed = true
+	if msg.HasQuoted() && !msg.HasTrigger && !msg.IsCmd {
+		msg.Addressed = false
 	}
-
-	allowNonCommand := !isGroup || hasTrig
-	if allowNonCommand {
-		if r.pap != nil && r.pap.TryHandle(client, m, origTxt) {
-			return
-		}
-		if r.ba.TryHandleText(context.Background(), client, m, txt, isOwner) {
-			return
-		}
-		if r.hijab.TryHandle(client, m, txt, isOwner, r.reTrig) {
-			return
-		}
-
-		// PRIORITAS BRAT STICKER - cek sebelum sticker biasa
-		if r.brat.TryHandle(client, m, txt, isOwner) {
-			return
-		}
-
-		// PRIORITASKAN STICKER SEBELUM VISION
-		if r.stik.TryHandleTo(client, m.Info.Chat, m.Message, txt) {
-			return
-		}
-
-		// Baru cek vision setelah sticker tidak match
-		if r.vis.TryHandle(client, m, txt, isOwner) {
-			return
-		}
-
-		if r.anime != nil && r.anime.TryHandle(client, m, txt) {
-			return
-		}
-
-		if r.tts.TryHandle(client, m, txt) {
-			return
-		}
+	hasMedia := m.Message.ImageMessage != nil || m.Message.VideoMessage != nil
+	if msg.IsGroup && hasMedia && !msg.HasTrigger && !msg.IsCmd {
+		msg.Addressed = false
 	}
+	return msg
+}
 
-	if r.vnote.TryHandle(client, m, isOwner) {
-		return
-	}
+// handleChat adalah fallback terakhir: obrolan AI dengan persona.
+func (r *Router) handleChat(fm *feature.Msg) bool {
+	client, m := fm.Client, fm.Event
+	origTxt := fm.Text
+	txt := origTxt
+	senderJID := fm.SenderJID
+	isTagAllCmd := fm.IsCmd && strings.EqualFold(fm.Cmd, "tagall")
 
-	if quotedText != "" && r.reTrig.MatchString(origTxt) {
-		after := strings.TrimSpace(r.reTrig.ReplaceAllString(origTxt, ""))
+	if fm.QuotedText != "" && fm.HasTrigger {
+		after := r.trig.Strip(m.Info.Chat.String(), origTxt)
 		if after == "" || reReplyCue.MatchString(after) {
-			txt = quotedText
+			txt = fm.QuotedText
 		} else {
-			txt = after + "\n\nKonteks (pesan yang di-reply): " + quotedText
+			txt = fm.T("chat.reply_context", after, fm.QuotedText)
 		}
 	}
 
-	if isGroup && strings.EqualFold(r.cfg.Mode, "MANUAL") {
-		if !r.reTrig.MatchString(origTxt) && !isTagAllCmd {
-			return
+	if fm.IsGroup && strings.EqualFold(r.cfg.Mode, "MANUAL") {
+		if !fm.HasTrigger && !isTagAllCmd {
+			return false
 		}
-		clean := strings.TrimSpace(r.reTrig.ReplaceAllString(strings.ToLower(origTxt), ""))
-		if clean == "" && quotedText != "" {
-			txt = quotedText
+		clean := r.trig.Strip(m.Info.Chat.String(), strings.ToLower(origTxt))
+		if clean == "" && fm.QuotedText != "" {
+			txt = fm.QuotedText
 		} else if clean != "" && txt == origTxt {
 			txt = clean
 		}
 	}
 
 	if strings.TrimSpace(txt) == "" {
-		return
+		return false
 	}
 
 	// Prioritas: Cek apakah ini permintaan perubahan nama SEBELUM masuk ke LLM
 	if name, isNameRequest := memory.DetectNameRequest(txt); isNameRequest {
 		if err := memory.SetUserName(senderJID, name); err == nil {
-			reply := "*Oke! Mulai sekarang aku akan memanggilmu " + name + "* ✨\n\n_Senang berkenalan denganmu, " + name + "!_ Aku Elaina, penyihir cantik dan berbakat~ 🌟"
-			txtOut, mentions := r.owner.Decorate(isOwner, reply)
+			reply := fm.T("chat.name_saved", name)
+			txtOut, mentions := r.owner.Decorate(fm.IsOwner, reply)
 			replyTextMention(context.Background(), client, m, txtOut, mentions)
 
 			// Simpan interaksi ini ke memory
-			_ = memory.SaveTurn(m.Info.Chat.String(), "user", txt)
-			_ = memory.SaveTurn(m.Info.Chat.String(), "assistant", reply)
-			return
+			rememberTurns(m.Info.Chat.String(), senderJID, txt, reply)
+			return true
 		}
 	}
 
 	state, _ := r.store.Get(m.Info.Chat.String())
 
+	// Riwayat per chat dikirim sebagai giliran user/model; senderJID untuk nama
 	hist, _ := memory.Load(m.Info.Chat.String())
-	// Gunakan Chat JID untuk memory, Sender JID untuk nama
-	ctxTxt := memory.BuildContext(hist, txt, senderJID)
-
-	// Pass senderJID ke AskAsPersona untuk nama
-	reply := llm.AskAsPersona(r.cfg, state.Persona, state.Pro, ctxTxt, senderJID, time.Now())
-
-	_ = memory.SaveTurn(m.Info.Chat.String(), "user", txt)
-	_ = memory.SaveTurn(m.Info.Chat.String(), "assistant", reply)
+	reply, err := llm.AskAsPersona(r.cfg, state.Persona, state.Pro, fm.Lang, hist, txt, senderJID, time.Now())
+	if err != nil {
+		// Detail sudah di log llm; pesan gagal tidak disimpan ke memory
+		reply = llm.Friendly(fm.Lang, err)
+	} else {
+		rememberTurns(m.Info.Chat.String(), senderJID, txt, reply)
+	}
 
-	txtOut, mentions := r.owner.Decorate(isOwner, reply)
+	txtOut, mentions := r.owner.Decorate(fm.IsOwner, reply)
 	replyTextMention(context.Background(), client, m, txtOut, mentions)
+	return true
+}
+
+// rememberTurns menyimpan pesan user & balasan bot ke memory (state DB).
+func rememberTurns(chat, sender, userText, reply string) {
+	err := memory.SaveTurn(chat, sender, "user", userText)
+	if err == nil {
+		err = memory.SaveTurn(chat, "", "assistant", reply)
+	}
+	if err != nil {
+		log.Printf("[MEMORY] simpan giliran chat=%s: %v", chat, err)
+	}
 }
 
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -379,7 +299,7 @@ func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message,
 	})
 }
 
-func replyTextMention(ctx context.Context, client *whatsmeow.Client, m *events.Message, text string, mentions []types.JID) {
+func replyTextMention(ctx context.Context, client wa.Client, m *events.Message, text string, mentions []types.JID) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
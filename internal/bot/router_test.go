@@ -0,0 +1,334 @@
+package bot
+
+import (
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"wa-elaina/internal/config"
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/fakeapi"
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/wa"
+	"wa-elaina/internal/wa/watest"
+)
+
+var (
+	ownerJID = watest.UserJID("6281111111111")
+	userJID  = watest.UserJID("6282222222222")
+	adminJID = watest.UserJID("6283333333333")
+	groupJID = watest.GroupJID("120363000000000001")
+)
+
+// harness: router lengkap di atas state DB sementara, FakeClient, dan server
+// Gemini palsu (obrolan AI).
+type harness struct {
+	rt     *Router
+	client *watest.FakeClient
+	store  *db.Store
+	gemini *fakeapi.GeminiServer
+}
+
+func newHarness(t *testing.T, tweak func(*config.Config)) *harness {
+	t.Helper()
+	t.Setenv("OWNER_JID", ownerJID.String())
+	for _, k := range []string{"OWNER_NUMBER", "OWNER_LID", "OWNER_IDS", "OWNER_MATCH"} {
+		t.Setenv(k, "")
+	}
+
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	gem := fakeapi.NewGemini()
+	t.Cleanup(gem.Close)
+
+	cfg := config.Config{
+		BotName:       "Elaina",
+		Mode:          "MANUAL",
+		Trigger:       "elaina",
+		Timezone:      "Asia/Jakarta",
+		EditMode:      EditUnhandled,
+		GeminiKeys:    []string{"test-key"},
+		GeminiBaseURL: gem.URL,
+		LLMText:       "gemini",
+		LLMVision:     "gemini",
+		LLMTranscribe: "gemini",
+		LLMJSON:       "gemini",
+	}
+	if tweak != nil {
+		tweak(&cfg)
+	}
+
+	client := watest.NewFakeClient()
+	client.Groups[groupJID] = watest.Group(groupJID, "Tes", []types.JID{adminJID}, userJID, ownerJID)
+	var ready atomic.Bool
+	ready.Store(true)
+	rt := NewRouter(cfg, wa.NewSender(client), &ready, store)
+	return &harness{rt: rt, client: client, store: store, gemini: gem}
+}
+
+// send memproses satu pesan dan mengembalikan teks balasan yang terkirim.
+func (h *harness) send(ev *events.Message) []string {
+	h.client.Reset()
+	h.rt.HandleMessage(h.client, ev)
+	return h.client.Texts()
+}
+
+func TestRouter(t *testing.T) {
+	cases := []struct {
+		name  string
+		cfg   func(*config.Config)
+		msg   *events.Message
+		reply string // "" = tidak boleh ada balasan
+		check func(t *testing.T, h *harness)
+	}{
+		{
+			name: "grup tanpa trigger di mode MANUAL tidak dibalas",
+			msg:  watest.Text(groupJID, userJID, "halo semuanya, apa kabar?"),
+		},
+		{
+			name:  "grup dengan trigger dibalas obrolan AI",
+			msg:   watest.Text(groupJID, userJID, "elaina halo"),
+			reply: "Halo, aku Elaina (palsu).",
+			check: func(t *testing.T, h *harness) {
+				if n := len(h.gemini.Requests()); n != 1 {
+					t.Fatalf("request Gemini = %d, mau 1", n)
+				}
+			},
+		},
+		{
+			name:  "chat pribadi tanpa trigger tetap dibalas",
+			msg:   watest.Text(userJID, userJID, "halo"),
+			reply: "Halo, aku Elaina (palsu).",
+		},
+		{
+			name:  "TRIGGER dari config menggantikan bawaan",
+			cfg:   func(c *config.Config) { c.Trigger = "ela" },
+			msg:   watest.Text(groupJID, userJID, "ela halo"),
+			reply: "Halo, aku Elaina (palsu).",
+		},
+		{
+			name:  "!elaina persona 2 menyimpan elaina2",
+			msg:   watest.Text(groupJID, userJID, "!elaina persona 2"),
+			reply: "elaina2",
+			check: func(t *testing.T, h *harness) {
+				st, err := h.store.Get(groupJID.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if st.Persona != "elaina2" {
+					t.Fatalf("persona = %q, mau elaina2", st.Persona)
+				}
+			},
+		},
+		{
+			name:  "persona tidak dikenal ditolak",
+			msg:   watest.Text(groupJID, userJID, "!elaina persona 3"),
+			reply: "Persona tidak valid",
+			check: func(t *testing.T, h *harness) {
+				st, _ := h.store.Get(groupJID.String())
+				if st.Persona == "elaina3" {
+					t.Fatal("persona tidak valid tersimpan")
+				}
+			},
+		},
+		{
+			name:  "!whoami menampilkan JID pengirim",
+			msg:   watest.Text(userJID, userJID, "!whoami"),
+			reply: userJID.String(),
+		},
+		{
+			name:  "!help memakai trigger chat",
+			msg:   watest.Text(userJID, userJID, "!help"),
+			reply: "elaina buatin gambar",
+		},
+		{
+			name: "pesan dari bot sendiri diabaikan",
+			msg: func() *events.Message {
+				ev := watest.Text(userJID, userJID, "!whoami")
+				ev.Info.IsFromMe = true
+				return ev
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHarness(t, tc.cfg)
+			got := h.send(tc.msg)
+			switch {
+			case tc.reply == "" && len(got) > 0:
+				t.Fatalf("tidak boleh membalas, terkirim: %q", got)
+			case tc.reply != "" && (len(got) == 0 || !strings.Contains(strings.Join(got, "\n"), tc.reply)):
+				t.Fatalf("balasan %q tidak memuat %q", got, tc.reply)
+			}
+			if tc.check != nil {
+				tc.check(t, h)
+			}
+		})
+	}
+}
+
+func TestRouterDuplicateDelivery(t *testing.T) {
+	h := newHarness(t, nil)
+	ev := watest.Text(userJID, userJID, "!whoami")
+	if got := h.send(ev); len(got) != 1 {
+		t.Fatalf("pengiriman pertama: %q", got)
+	}
+	if got := h.send(ev); len(got) != 0 {
+		t.Fatalf("pesan duplikat dibalas lagi: %q", got)
+	}
+}
+
+func TestKuotaOverridePermissions(t *testing.T) {
+	cases := []struct {
+		name   string
+		sender types.JID
+		chat   types.JID
+		role   perm.Role
+		cmd    string
+		saved  bool
+	}{
+		{"user biasa lewat chat pribadi ditolak", userJID, userJID, perm.User, "!kuota set imggen 10s 3", false},
+		{"admin grup bukan moderator ditolak", adminJID, groupJID, perm.User, "!kuota set imggen 10s 3", false},
+		{"moderator boleh mengubah batas", userJID, groupJID, perm.Moderator, "!kuota set imggen 10s 3", true},
+		{"moderator tidak boleh tanpa batas", userJID, groupJID, perm.Moderator, "!kuota set imggen 0", false},
+		{"moderator tidak boleh harian tanpa batas", userJID, groupJID, perm.Moderator, "!kuota set imggen 10s", false},
+		{"owner boleh tanpa batas", ownerJID, groupJID, perm.User, "!kuota set imggen 0", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHarness(t, nil)
+			if tc.role != perm.User {
+				if err := h.rt.roles.Grant(tc.sender, tc.role, "test"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			h.send(watest.Text(tc.chat, tc.sender, tc.cmd))
+			over, err := h.store.ChatQuotas(tc.chat.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, saved := over["imggen"]; saved != tc.saved {
+				t.Fatalf("override tersimpan = %t, mau %t (balasan %q)", saved, tc.saved, h.client.Texts())
+			}
+		})
+	}
+}
+
+func TestBannedUserOnlyObserved(t *testing.T) {
+	h := newHarness(t, nil)
+	var observed int
+	h.rt.Features().Register(&feature.Spec{
+		ID:      "spy",
+		Prio:    prioObserver,
+		Core:    true,
+		Observe: true,
+		HandleFn: func(*feature.Msg) bool {
+			observed++
+			return false
+		},
+	})
+	if err := h.rt.roles.Grant(userJID, perm.Banned, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := h.send(watest.Text(groupJID, userJID, "elaina halo")); len(got) != 0 {
+		t.Fatalf("user banned dibalas: %q", got)
+	}
+	if got := h.send(watest.Text(userJID, userJID, "!whoami")); len(got) != 0 {
+		t.Fatalf("perintah user banned dibalas: %q", got)
+	}
+	if observed != 2 {
+		t.Fatalf("observer dipanggil %d kali, mau 2", observed)
+	}
+	if n := len(h.gemini.Requests()); n != 0 {
+		t.Fatalf("request Gemini untuk user banned = %d", n)
+	}
+}
+
+func TestBanUsesBannedRole(t *testing.T) {
+	h := newHarness(t, nil)
+	h.send(watest.Text(groupJID, ownerJID, "!ban 6282222222222 spam"))
+	if got := h.rt.roles.Of(userJID); got != perm.Banned {
+		t.Fatalf("role setelah !ban = %s, mau banned", got)
+	}
+	if got := h.send(watest.Text(groupJID, userJID, "elaina halo")); len(got) != 0 {
+		t.Fatalf("user yang di-ban dibalas: %q", got)
+	}
+	if got := h.send(watest.Text(userJID, ownerJID, "!ban list")); len(got) != 1 || !strings.Contains(got[0], "6282222222222") {
+		t.Fatalf("!ban list = %q", got)
+	}
+	h.send(watest.Text(groupJID, ownerJID, "!unban 6282222222222"))
+	if got := h.rt.roles.Of(userJID); got != perm.User {
+		t.Fatalf("role setelah !unban = %s, mau user", got)
+	}
+}
+
+func TestAllowlistOnlyStillObserved(t *testing.T) {
+	h := newHarness(t, func(c *config.Config) { c.AllowlistOnly = true })
+	var observed int
+	h.rt.Features().Register(&feature.Spec{
+		ID:      "spy",
+		Prio:    prioObserver,
+		Core:    true,
+		Observe: true,
+		HandleFn: func(*feature.Msg) bool {
+			observed++
+			return false
+		},
+	})
+	if got := h.send(watest.Text(groupJID, userJID, "elaina halo")); len(got) != 0 {
+		t.Fatalf("grup di luar allowlist dibalas: %q", got)
+	}
+	if observed != 1 {
+		t.Fatalf("observer dipanggil %d kali, mau 1", observed)
+	}
+	if got := h.send(watest.Text(groupJID, ownerJID, "!allowgroup add")); len(got) != 1 {
+		t.Fatalf("!allowgroup add oleh owner: %q", got)
+	}
+	if got := h.send(watest.Text(groupJID, userJID, "elaina halo")); len(got) != 1 {
+		t.Fatalf("grup terdaftar tidak dibalas: %q", got)
+	}
+}
+
+func TestEditedMessageModerated(t *testing.T) {
+	h := newHarness(t, nil)
+	if err := h.store.SetPeraturanState(groupJID.String(), true, "1. Dilarang spam"); err != nil {
+		t.Fatal(err)
+	}
+	h.gemini.SetReply(`{"violation":false,"reason":"","redeem":false}`)
+	orig := watest.Text(groupJID, userJID, "selamat pagi semua")
+	h.send(orig)
+	if n, _ := h.store.ListWarns(groupJID.String()); len(n) != 0 {
+		t.Fatalf("pesan aman diberi warn: %+v", n)
+	}
+
+	h.gemini.SetReply(`{"violation":true,"reason":"spam link","redeem":false}`)
+	h.send(watest.Edit(orig, "promo murah klik link ini"))
+
+	warns, err := h.store.ListWarns(groupJID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warns) != 1 || warns[0].Count != 1 {
+		t.Fatalf("warn setelah edit = %+v, mau 1 warn", warns)
+	}
+	revoked := false
+	for _, s := range h.client.Sent() {
+		if pm := s.Message.GetProtocolMessage(); pm.GetType() == waProto.ProtocolMessage_REVOKE && pm.GetKey().GetID() == orig.Info.ID {
+			revoked = true
+		}
+	}
+	if !revoked {
+		t.Fatal("pesan yang diedit menjadi pelanggaran tidak dihapus")
+	}
+}
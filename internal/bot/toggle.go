@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/perm"
+)
+
+// featureGuard melewati fitur yang dimatikan di chat ini via !fitur off.
+func (r *Router) featureGuard(m *feature.Msg, f feature.Feature) feature.Verdict {
+	t, ok := f.(feature.Toggleable)
+	if !ok || t.ToggleName() == "" {
+		return feature.Allow
+	}
+	if m.Disabled == nil {
+		off, err := r.store.DisabledFeatures(m.Chat.String())
+		if err != nil {
+			log.Printf("[FITUR] gagal memuat toggle %s: %v", m.Chat.String(), err)
+			off = map[string]bool{}
+		}
+		m.Disabled = off
+	}
+	if m.Disabled[t.ToggleName()] {
+		return feature.Skip
+	}
+	return feature.Allow
+}
+
+// canManageChat: moderator bot ke atas, admin grup, atau siapa pun di chat pribadi.
+func (r *Router) canManageChat(m *feature.Msg) bool {
+	if m.Can(perm.Moderator) || m.Chat.Server != types.GroupServer {
+		return true
+	}
+	return perm.IsGroupAdmin(m.Client, m.Chat, m.Sender)
+}
+
+func (r *Router) handleFiturCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	parts := strings.Fields(strings.ToLower(m.Args))
+
+	if len(parts) == 0 || parts[0] == "list" {
+		replyText(context.Background(), client, ev, r.fiturList(m))
+		return true
+	}
+	if len(parts) < 2 || (parts[0] != "on" && parts[0] != "off") {
+		replyText(context.Background(), client, ev, m.T("fitur.usage"))
+		return true
+	}
+	if !r.canManageChat(m) {
+		deny(m, "fitur.admin_only")
+		return true
+	}
+
+	name := parts[1]
+	known := false
+	for _, t := range r.features.Toggles() {
+		if t == name {
+			known = true
+			break
+		}
+	}
+	if !known {
+		replyText(context.Background(), client, ev, m.T("fitur.unknown", name))
+		return true
+	}
+
+	on := parts[0] == "on"
+	if err := r.store.SetFeatureEnabled(m.Chat.String(), name, on); err != nil {
+		replyText(context.Background(), client, ev, m.T("fitur.save_failed", err))
+		return true
+	}
+	if on {
+		replyText(context.Background(), client, ev, m.T("fitur.enabled", name))
+	} else {
+		replyText(context.Background(), client, ev, m.T("fitur.disabled", name))
+	}
+	return true
+}
+
+func (r *Router) fiturList(m *feature.Msg) string {
+	off, _ := r.store.DisabledFeatures(m.Chat.String())
+	names := r.features.Toggles()
+	sort.Strings(names)
+	var sb strings.Builder
+	sb.WriteString(m.T("fitur.list_title") + "\n")
+	for _, n := range names {
+		status := "on"
+		if off[n] {
+			status = "off"
+		}
+		sb.WriteString("- " + n + " : " + status + "\n")
+	}
+	sb.WriteString("\n" + m.T("fitur.list_footer"))
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"wa-elaina/internal/feature"
+	"wa-elaina/internal/trigger"
+)
+
+// handleTriggerCmd: !trigger [set <a, b> | add <alias> | del <alias> | reset]
+// mengatur nama panggilan bot per chat (dipakai router & semua fitur).
+func (r *Router) handleTriggerCmd(m *feature.Msg) bool {
+	client, ev := m.Client, m.Event
+	chat := m.Chat.String()
+	sub, rest, _ := strings.Cut(strings.TrimSpace(m.Args), " ")
+	sub = strings.ToLower(sub)
+	rest = strings.TrimSpace(rest)
+
+	if sub == "" || sub == "list" {
+		replyText(context.Background(), client, ev, r.triggerList(m))
+		return true
+	}
+	if sub != "reset" && (rest == "" || (sub != "set" && sub != "add" && sub != "del")) {
+		replyText(context.Background(), client, ev, m.T("trigger.usage"))
+		return true
+	}
+	if !r.canManageChat(m) {
+		deny(m, "trigger.admin_only")
+		return true
+	}
+
+	if sub == "reset" {
+		if err := r.trig.Reset(chat); err != nil {
+			replyText(context.Background(), client, ev, m.T("trigger.save_failed", err))
+			return true
+		}
+		replyText(context.Background(), client, ev, m.T("trigger.reset", strings.Join(r.trig.Words(chat), ", ")))
+		return true
+	}
+
+	words, err := trigger.ParseList(rest)
+	if err != nil {
+		replyText(context.Background(), client, ev, m.T("trigger.invalid", err))
+		return true
+	}
+	if len(words) == 0 {
+		replyText(context.Background(), client, ev, m.T("trigger.usage"))
+		return true
+	}
+	switch sub {
+	case "add":
+		words = append(r.trig.Words(chat), words...)
+	case "del":
+		drop := map[string]bool{}
+		for _, w := range words {
+			if !r.trig.Has(chat, w) {
+				replyText(context.Background(), client, ev, m.T("trigger.not_found", w))
+				return true
+			}
+			drop[w] = true
+		}
+		var keep []string
+		for _, w := range r.trig.Words(chat) {
+			if !drop[w] {
+				keep = append(keep, w)
+			}
+		}
+		if len(keep) == 0 {
+			replyText(context.Background(), client, ev, m.T("trigger.last"))
+			return true
+		}
+		words = keep
+	}
+	// Validasi ulang gabungan (duplikat & batas jumlah alias)
+	if words, err = trigger.ParseList(strings.Join(words, ",")); err != nil {
+		replyText(context.Background(), client, ev, m.T("trigger.invalid", err))
+		return true
+	}
+	if err := r.trig.Set(chat, words); err != nil {
+		replyText(context.Background(), client, ev, m.T("trigger.save_failed", err))
+		return true
+	}
+	replyText(context.Background(), client, ev, m.T("trigger.saved", strings.Join(words, ", ")))
+	return true
+}
+
+func (r *Router) triggerList(m *feature.Msg) string {
+	chat := m.Chat.String()
+	mark := ""
+	if !r.trig.Custom(chat) {
+		mark = m.T("trigger.default_mark")
+	}
+	return m.T("trigger.list", strings.Join(r.trig.Words(chat), ", "), mark)
+}
@@ -0,0 +1,378 @@
+// Package broadcast menyediakan siaran berulang ("tiap Senin 08:00 kirim
+// agenda ke tiga grup"): jadwal cron / every-N, target grup atau user,
+// payload teks atau media, disimpan di state DB dan dijalankan scheduler
+// dengan kebijakan catch-up untuk jadwal yang terlewat saat bot mati.
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/util"
+	"wa-elaina/internal/wa"
+)
+
+// Kebijakan catch-up saat scheduler menemukan jadwal yang terlewat.
+const (
+	CatchUpSkip = "skip" // lewati yang terlewat, lanjut ke jadwal berikutnya
+	CatchUpOnce = "once" // kirim sekali untuk semua yang terlewat
+	CatchUpAll  = "all"  // kirim untuk tiap jadwal terlewat (maks maxCatchUp)
+)
+
+const (
+	MaxTargets   = 50
+	maxCatchUp   = 24
+	graceLate    = 5 * time.Minute  // telat di bawah ini tetap dianggap tepat waktu
+	pollInterval = 30 * time.Second // cek ulang saat WA belum siap / kirim gagal
+	targetGap    = time.Second      // jeda antar target (hindari flood)
+)
+
+var (
+	ErrNoTargets = errors.New("target kosong")
+	ErrNoPayload = errors.New("teks atau media wajib diisi")
+	ErrCatchUp   = errors.New("catchup harus skip, once, atau all")
+	ErrNotFound  = errors.New("broadcast tidak ditemukan")
+)
+
+var reDigits = regexp.MustCompile(`^\+?\d{6,20}$`)
+
+// ParseTarget menerima JID lengkap (grup "...@g.us" / user) atau nomor telepon.
+func ParseTarget(s string) (types.JID, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "@") {
+		j, err := types.ParseJID(s)
+		if err != nil || j.User == "" {
+			return types.EmptyJID, fmt.Errorf("target tidak valid %q", s)
+		}
+		return wa.DestJID(j), nil
+	}
+	if reDigits.MatchString(s) {
+		return types.NewJID(strings.TrimPrefix(s, "+"), types.DefaultUserServer), nil
+	}
+	return types.EmptyJID, fmt.Errorf("target tidak valid %q (pakai nomor atau JID)", s)
+}
+
+// ValidCatchUp: "" dianggap skip.
+func ValidCatchUp(p string) (string, bool) {
+	switch p = strings.ToLower(strings.TrimSpace(p)); p {
+	case "":
+		return CatchUpSkip, true
+	case CatchUpSkip, CatchUpOnce, CatchUpAll:
+		return p, true
+	}
+	return p, false
+}
+
+// Spec: data job baru (dari perintah atau HTTP API).
+type Spec struct {
+	Schedule  string   `json:"schedule"`
+	Targets   []string `json:"targets"`
+	Text      string   `json:"text"`
+	MediaURL  string   `json:"media_url"`
+	CatchUp   string   `json:"catchup"`
+	CreatedBy string   `json:"-"`
+}
+
+type Scheduler struct {
+	store    *db.Store
+	loc      *time.Location
+	ready    *atomic.Bool
+	send     *wa.Sender
+	httpc    *http.Client
+	maxMedia int64
+	wake     chan struct{}
+}
+
+func NewScheduler(store *db.Store, loc *time.Location, ready *atomic.Bool, send *wa.Sender, maxMedia int64) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		loc:      loc,
+		ready:    ready,
+		send:     send,
+		httpc:    &http.Client{Timeout: 60 * time.Second},
+		maxMedia: maxMedia,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+func (s *Scheduler) Location() *time.Location { return s.loc }
+
+// Add memvalidasi lalu menyimpan job baru; jadwal pertama dihitung dari sekarang.
+func (s *Scheduler) Add(spec Spec) (db.Broadcast, error) {
+	sched, err := ParseSchedule(spec.Schedule)
+	if err != nil {
+		return db.Broadcast{}, err
+	}
+	policy, ok := ValidCatchUp(spec.CatchUp)
+	if !ok {
+		return db.Broadcast{}, ErrCatchUp
+	}
+	var targets []string
+	seen := map[string]bool{}
+	for _, t := range spec.Targets {
+		if strings.TrimSpace(t) == "" {
+			continue
+		}
+		j, err := ParseTarget(t)
+		if err != nil {
+			return db.Broadcast{}, err
+		}
+		if k := j.String(); !seen[k] {
+			seen[k] = true
+			targets = append(targets, k)
+		}
+	}
+	if len(targets) == 0 {
+		return db.Broadcast{}, ErrNoTargets
+	}
+	if len(targets) > MaxTargets {
+		return db.Broadcast{}, fmt.Errorf("target maksimal %d", MaxTargets)
+	}
+	text, media := strings.TrimSpace(spec.Text), strings.TrimSpace(spec.MediaURL)
+	if text == "" && media == "" {
+		return db.Broadcast{}, ErrNoPayload
+	}
+	if media != "" && !strings.HasPrefix(media, "http://") && !strings.HasPrefix(media, "https://") {
+		return db.Broadcast{}, fmt.Errorf("media harus URL http(s)")
+	}
+
+	now := time.Now()
+	b := db.Broadcast{
+		Schedule:  strings.Join(strings.Fields(spec.Schedule), " "),
+		Targets:   targets,
+		Text:      text,
+		MediaURL:  media,
+		CatchUp:   policy,
+		NextRun:   sched.Next(now.In(s.loc)),
+		CreatedBy: spec.CreatedBy,
+		Created:   now,
+	}
+	if b.NextRun.IsZero() {
+		return db.Broadcast{}, errors.New("jadwal tidak pernah jalan")
+	}
+	if b.ID, err = s.store.AddBroadcast(b); err != nil {
+		return db.Broadcast{}, err
+	}
+	s.Wake()
+	return b, nil
+}
+
+func (s *Scheduler) List() ([]db.Broadcast, error) { return s.store.ListBroadcasts() }
+
+func (s *Scheduler) Delete(id int64) (bool, error) {
+	ok, err := s.store.DeleteBroadcast(id)
+	if ok {
+		s.Wake()
+	}
+	return ok, err
+}
+
+// SetPaused menjeda / melanjutkan job; saat dilanjutkan jadwal dihitung dari
+// sekarang sehingga jeda tidak memicu catch-up.
+func (s *Scheduler) SetPaused(id int64, paused bool) (db.Broadcast, error) {
+	b, ok, err := s.store.GetBroadcast(id)
+	if err != nil {
+		return b, err
+	}
+	if !ok {
+		return b, ErrNotFound
+	}
+	next := b.NextRun
+	if !paused {
+		sched, err := ParseSchedule(b.Schedule)
+		if err != nil {
+			return b, err
+		}
+		next = sched.Next(time.Now().In(s.loc))
+	}
+	if _, err := s.store.SetBroadcastPaused(id, paused, next); err != nil {
+		return b, err
+	}
+	b.Paused, b.NextRun = paused, next
+	s.Wake()
+	return b, nil
+}
+
+func (s *Scheduler) SetCatchUp(id int64, policy string) (bool, error) {
+	p, ok := ValidCatchUp(policy)
+	if !ok {
+		return false, ErrCatchUp
+	}
+	return s.store.SetBroadcastCatchUp(id, p)
+}
+
+// RunNow mengirim job sekarang juga tanpa menggeser jadwal berikutnya.
+func (s *Scheduler) RunNow(id int64) (int, error) {
+	b, ok, err := s.store.GetBroadcast(id)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrNotFound
+	}
+	sent, err := s.deliver(b)
+	if sent > 0 {
+		if uerr := s.store.UpdateBroadcastRun(b.ID, time.Now(), b.NextRun); uerr != nil {
+			log.Printf("[BROADCAST] simpan #%d: %v", b.ID, uerr)
+		}
+	}
+	return sent, err
+}
+
+func (s *Scheduler) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run menjalankan loop sampai ctx selesai.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		s.fire()
+
+		wait := pollInterval
+		if next, ok, err := s.store.NextBroadcastDue(); err != nil {
+			log.Printf("[BROADCAST] jadwal berikutnya: %v", err)
+		} else if ok {
+			if d := time.Until(next); d > 0 && d < wait {
+				wait = d
+			}
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-s.wake:
+			t.Stop()
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Scheduler) fire() {
+	if s.ready != nil && !s.ready.Load() {
+		return
+	}
+	now := time.Now()
+	due, err := s.store.DueBroadcasts(now)
+	if err != nil {
+		log.Printf("[BROADCAST] baca jadwal: %v", err)
+		return
+	}
+	for _, b := range due {
+		s.runDue(b, now)
+	}
+}
+
+// runDue menjalankan satu job jatuh tempo sesuai kebijakan catch-up lalu
+// menggeser next_run ke jadwal pertama setelah now.
+func (s *Scheduler) runDue(b db.Broadcast, now time.Time) {
+	sched, err := ParseSchedule(b.Schedule)
+	if err != nil {
+		log.Printf("[BROADCAST] #%d jadwal rusak, dijeda: %v", b.ID, err)
+		_, _ = s.store.SetBroadcastPaused(b.ID, true, time.Time{})
+		return
+	}
+
+	missed := 0
+	next := b.NextRun
+	for !next.After(now) && missed <= maxCatchUp {
+		missed++
+		next = sched.Next(next.In(s.loc))
+		if next.IsZero() {
+			break
+		}
+	}
+	if next.IsZero() || !next.After(now) {
+		next = sched.Next(now.In(s.loc))
+	}
+
+	runs := 1
+	if now.Sub(b.NextRun) > graceLate {
+		switch b.CatchUp {
+		case CatchUpAll:
+			runs = min(missed, maxCatchUp)
+		case CatchUpOnce:
+			runs = 1
+		default:
+			runs = 0
+		}
+		log.Printf("[BROADCAST] #%d terlewat %d jadwal (catchup=%s → kirim %d)", b.ID, missed, b.CatchUp, runs)
+	}
+
+	var last time.Time
+	for i := 0; i < runs; i++ {
+		sent, err := s.deliver(b)
+		if err != nil {
+			log.Printf("[BROADCAST] #%d: %v", b.ID, err)
+		}
+		if sent == 0 {
+			if i == 0 && now.Sub(b.NextRun) <= graceLate {
+				return // gagal total & masih dalam toleransi → coba lagi di putaran berikutnya
+			}
+			break
+		}
+		last = time.Now()
+	}
+	if err := s.store.UpdateBroadcastRun(b.ID, last, next); err != nil {
+		log.Printf("[BROADCAST] simpan #%d: %v", b.ID, err)
+	}
+}
+
+// deliver mengirim payload ke semua target; mengembalikan jumlah target
+// yang berhasil dan error terakhir.
+func (s *Scheduler) deliver(b db.Broadcast) (int, error) {
+	sendTo := func(to types.JID) error { return s.send.Text(to, b.Text) }
+	if b.MediaURL != "" {
+		data, ct, err := util.DownloadBytes(s.httpc, b.MediaURL, s.maxMedia)
+		if err != nil {
+			return 0, fmt.Errorf("unduh media: %w", err)
+		}
+		mime, _, _ := strings.Cut(ct, ";")
+		if mime == "" {
+			mime = http.DetectContentType(data)
+		}
+		switch {
+		case strings.HasPrefix(mime, "image/"):
+			sendTo = func(to types.JID) error { return s.send.Image(to, data, mime, b.Text) }
+		case strings.HasPrefix(mime, "video/"):
+			sendTo = func(to types.JID) error { return s.send.Video(to, data, mime, b.Text) }
+		default:
+			name := path.Base(strings.SplitN(b.MediaURL, "?", 2)[0])
+			sendTo = func(to types.JID) error { return s.send.Document(to, data, mime, name, b.Text) }
+		}
+	}
+
+	sent := 0
+	var lastErr error
+	for i, t := range b.Targets {
+		if i > 0 {
+			time.Sleep(targetGap)
+		}
+		to, err := ParseTarget(t)
+		if err == nil {
+			err = sendTo(to)
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("kirim ke %s: %w", t, err)
+			log.Printf("[BROADCAST] #%d %v", b.ID, lastErr)
+			continue
+		}
+		sent++
+	}
+	return sent, lastErr
+}
@@ -0,0 +1,109 @@
+package broadcast
+
+import (
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/wa"
+	"wa-elaina/internal/wa/watest"
+)
+
+func newTestScheduler(t *testing.T) (*Scheduler, *db.Store, *watest.FakeClient) {
+	t.Helper()
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	client := watest.NewFakeClient()
+	var ready atomic.Bool
+	ready.Store(true)
+	return NewScheduler(store, time.UTC, &ready, wa.NewSender(client), 0), store, client
+}
+
+func TestRunDueCatchUp(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	target := watest.UserJID("6281111111111")
+
+	cases := []struct {
+		name    string
+		late    time.Duration // now - next_run
+		policy  string
+		sends   int
+		nextRun time.Time
+	}{
+		// every 1h, telat 3j1m → terlewat 4 jadwal (06:59, 07:59, 08:59, 09:59)
+		{"skip melewati semua", 3*time.Hour + time.Minute, CatchUpSkip, 0, now.Add(59 * time.Minute)},
+		{"once kirim sekali", 3*time.Hour + time.Minute, CatchUpOnce, 1, now.Add(59 * time.Minute)},
+		{"all kirim tiap jadwal", 3*time.Hour + time.Minute, CatchUpAll, 4, now.Add(59 * time.Minute)},
+		{"all dibatasi maxCatchUp", 100 * time.Hour, CatchUpAll, maxCatchUp, now.Add(time.Hour)},
+		{"telat dalam toleransi tetap dikirim", 2 * time.Minute, CatchUpSkip, 1, now.Add(58 * time.Minute)},
+		{"tepat waktu", 0, CatchUpSkip, 1, now.Add(time.Hour)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, store, client := newTestScheduler(t)
+			id, err := store.AddBroadcast(db.Broadcast{
+				Schedule: "every 1h",
+				Targets:  []string{target.String()},
+				Text:     "pengumuman",
+				CatchUp:  tc.policy,
+				NextRun:  now.Add(-tc.late),
+				Created:  now.Add(-200 * time.Hour),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _, err := store.GetBroadcast(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s.runDue(b, now)
+
+			if got := len(client.Texts()); got != tc.sends {
+				t.Fatalf("terkirim %d, mau %d", got, tc.sends)
+			}
+			b, _, err = store.GetBroadcast(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !b.NextRun.Equal(tc.nextRun) {
+				t.Fatalf("next_run = %s, mau %s", b.NextRun, tc.nextRun)
+			}
+			if tc.sends > 0 && b.LastRun.IsZero() {
+				t.Fatal("last_run tidak diisi setelah terkirim")
+			}
+		})
+	}
+}
+
+func TestRunDueFailedRetriesWithinGrace(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	s, store, client := newTestScheduler(t)
+	due := now.Add(-time.Minute)
+	id, err := store.AddBroadcast(db.Broadcast{
+		Schedule: "every 1h",
+		Targets:  []string{"bukan-jid"},
+		Text:     "pengumuman",
+		CatchUp:  CatchUpSkip,
+		NextRun:  due,
+		Created:  now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, _ := store.GetBroadcast(id)
+	s.runDue(b, now)
+
+	if n := len(client.Texts()); n != 0 {
+		t.Fatalf("terkirim %d ke target rusak", n)
+	}
+	b, _, _ = store.GetBroadcast(id)
+	if !b.NextRun.Equal(due) {
+		t.Fatalf("next_run bergeser ke %s, mau tetap %s agar dicoba lagi", b.NextRun, due)
+	}
+}
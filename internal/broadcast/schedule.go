@@ -0,0 +1,194 @@
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule menghitung waktu jalan berikutnya setelah t (zona waktu t dipakai).
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// MinInterval: jarak minimum jadwal every-N (cegah spam).
+const MinInterval = 5 * time.Minute
+
+var reEvery = regexp.MustCompile(`(?i)^(?:@every|every|tiap|setiap)\s+(\d+)\s*(m|mnt|menit|min|mins|minutes?|h|j|jam|hours?|d|hari|days?|w|minggu|weeks?)$`)
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseSchedule menerima ekspresi cron 5 kolom ("0 8 * * 1" = tiap Senin
+// 08:00), deskriptor (@daily, @weekly, ...) atau every-N ("every 30m",
+// "tiap 2 jam", "every 1d").
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.Join(strings.Fields(spec), " ")
+	if c, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = c
+	}
+	if m := reEvery.FindStringSubmatch(spec); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		var unit time.Duration
+		switch u := strings.ToLower(m[2]); {
+		case u == "h" || u == "j" || u == "jam" || strings.HasPrefix(u, "hour"):
+			unit = time.Hour
+		case u == "d" || u == "hari" || strings.HasPrefix(u, "day"):
+			unit = 24 * time.Hour
+		case u == "w" || u == "minggu" || strings.HasPrefix(u, "week"):
+			unit = 7 * 24 * time.Hour
+		default:
+			unit = time.Minute
+		}
+		every := time.Duration(n) * unit
+		if every < MinInterval {
+			return nil, fmt.Errorf("interval minimal %s", MinInterval)
+		}
+		return Every(every), nil
+	}
+	return parseCron(spec)
+}
+
+// Every: jalan tiap d sejak jadwal sebelumnya. Tidak dibulatkan ke menit agar
+// jarak antar jalan selalu tepat d (tidak bergeser); ParseSchedule menjamin
+// d >= MinInterval.
+type Every time.Duration
+
+func (e Every) Next(t time.Time) time.Time { return t.Add(time.Duration(e)) }
+
+// cron: bitset per kolom (menit, jam, tanggal, bulan, hari).
+type cron struct {
+	min, hour, dom, month, dow uint64
+	domAny, dowAny             bool
+}
+
+var cronNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+func parseCron(spec string) (*cron, error) {
+	f := strings.Fields(strings.ToLower(spec))
+	if len(f) != 5 {
+		return nil, errors.New("jadwal harus cron 5 kolom (menit jam tanggal bulan hari) atau \"every 30m\"")
+	}
+	c := &cron{domAny: f[2] == "*", dowAny: f[4] == "*"}
+	var err error
+	if c.min, err = cronField(f[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("menit: %w", err)
+	}
+	if c.hour, err = cronField(f[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("jam: %w", err)
+	}
+	if c.dom, err = cronField(f[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("tanggal: %w", err)
+	}
+	if c.month, err = cronField(f[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("bulan: %w", err)
+	}
+	if c.dow, err = cronField(f[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("hari: %w", err)
+	}
+	if c.dow&(1<<7) != 0 { // 7 = Minggu
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// cronField mem-parse "*", "*/n", "a", "a-b", "a-b/n" dan daftar dipisah koma.
+func cronField(s string, lo, hi int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("langkah tidak valid %q", part)
+			}
+			step = n
+		}
+		a, b := lo, hi
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if a, err = cronValue(from, lo, hi); err != nil {
+				return 0, err
+			}
+			b = a
+			if isRange {
+				if b, err = cronValue(to, lo, hi); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				b = hi
+			}
+			if b < a {
+				return 0, fmt.Errorf("rentang terbalik %q", part)
+			}
+		}
+		for v := a; v <= b; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, lo, hi int) (int, error) {
+	if v, ok := cronNames[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < lo || v > hi {
+		return 0, fmt.Errorf("nilai %q di luar %d-%d", s, lo, hi)
+	}
+	return v, nil
+}
+
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow // aturan cron standar: salah satu cocok
+}
+
+// Next mencari menit berikutnya yang cocok (maks ~5 tahun ke depan).
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.min&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,105 @@
+package broadcast
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := []struct {
+		spec  string
+		every time.Duration // >0: harus Every dengan interval ini
+		ok    bool
+	}{
+		{spec: "0 8 * * 1", ok: true},
+		{spec: "*/15 9-17 * * mon-fri", ok: true},
+		{spec: "0 9,12,18 1,15 * *", ok: true},
+		{spec: "0 0 * * 7", ok: true},
+		{spec: "@daily", ok: true},
+		{spec: "@weekly", ok: true},
+		{spec: "every 30m", every: 30 * time.Minute, ok: true},
+		{spec: "tiap 2 jam", every: 2 * time.Hour, ok: true},
+		{spec: "setiap 1 hari", every: 24 * time.Hour, ok: true},
+		{spec: "@every 1w", every: 7 * 24 * time.Hour, ok: true},
+		{spec: "every 1m"},   // di bawah MinInterval
+		{spec: "every 0h"},   // di bawah MinInterval
+		{spec: "60 * * * *"}, // menit 0-59
+		{spec: "* 24 * * *"}, // jam 0-23
+		{spec: "* * 0 * *"},  // tanggal 1-31
+		{spec: "* * 32 * *"},
+		{spec: "* * * 13 *"}, // bulan 1-12
+		{spec: "* * * * 8"},  // hari 0-7
+		{spec: "*/0 * * * *"},
+		{spec: "30-10 * * * *"},
+		{spec: "0 8 * *"},
+		{spec: "besok jam 8"},
+	}
+	for _, tc := range cases {
+		sched, err := ParseSchedule(tc.spec)
+		if (err == nil) != tc.ok {
+			t.Errorf("ParseSchedule(%q) err = %v, mau ok=%t", tc.spec, err, tc.ok)
+			continue
+		}
+		if tc.every > 0 {
+			if e, isEvery := sched.(Every); !isEvery || time.Duration(e) != tc.every {
+				t.Errorf("ParseSchedule(%q) = %#v, mau Every(%s)", tc.spec, sched, tc.every)
+			}
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	jkt, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Kamis, 15 Oktober 2026 10:07:30 WIB
+	from := time.Date(2026, 10, 15, 10, 7, 30, 0, jkt)
+	at := func(y int, mo time.Month, d, h, m int) time.Time { return time.Date(y, mo, d, h, m, 0, 0, jkt) }
+
+	cases := []struct {
+		spec string
+		want []time.Time // jalan berturut-turut
+	}{
+		{"0 8 * * 1", []time.Time{at(2026, 10, 19, 8, 0), at(2026, 10, 26, 8, 0)}},        // tiap Senin 08:00
+		{"*/15 * * * *", []time.Time{at(2026, 10, 15, 10, 15), at(2026, 10, 15, 10, 30)}}, // langkah
+		{"0 9,17 * * *", []time.Time{at(2026, 10, 15, 17, 0), at(2026, 10, 16, 9, 0)}},    // daftar
+		{"30 8-9/1 * * *", []time.Time{at(2026, 10, 16, 8, 30), at(2026, 10, 16, 9, 30)}}, // rentang + langkah
+		{"0 12 * * 7", []time.Time{at(2026, 10, 18, 12, 0)}},                              // 7 = Minggu
+		{"0 0 1 * *", []time.Time{at(2026, 11, 1, 0, 0), at(2026, 12, 1, 0, 0)}},          // awal bulan
+		{"0 0 29 2 *", []time.Time{at(2028, 2, 29, 0, 0)}},                                // kabisat
+		{"0 0 13 * fri", []time.Time{at(2026, 10, 16, 0, 0), at(2026, 10, 23, 0, 0)}},     // dom ATAU dow
+		{"0 0 20 * *", []time.Time{at(2026, 10, 20, 0, 0), at(2026, 11, 20, 0, 0)}},       // dow * → dom saja
+		{"0 10 * 1 *", []time.Time{at(2027, 1, 1, 10, 0), at(2027, 1, 2, 10, 0)}},         // bulan tertentu
+		{"7 10 15 10 *", []time.Time{at(2027, 10, 15, 10, 7)}},                            // menit ini sudah lewat
+	}
+	for _, tc := range cases {
+		sched, err := ParseSchedule(tc.spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q): %v", tc.spec, err)
+		}
+		cur := from
+		for i, want := range tc.want {
+			cur = sched.Next(cur)
+			if !cur.Equal(want) {
+				t.Errorf("%q jalan ke-%d = %s, mau %s", tc.spec, i+1, cur.Format(time.RFC3339), want.Format(time.RFC3339))
+				break
+			}
+		}
+	}
+}
+
+func TestEveryNextNoDrift(t *testing.T) {
+	sched, err := ParseSchedule("every 90m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 15, 10, 7, 30, 0, time.UTC)
+	cur := start
+	for i := 1; i <= 100; i++ {
+		cur = sched.Next(cur)
+		if want := start.Add(time.Duration(i) * 90 * time.Minute); !cur.Equal(want) {
+			t.Fatalf("jalan ke-%d = %s, mau %s", i, cur, want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,16 +17,79 @@ type Config struct {
 	Mode      string // MANUAL / PROD / etc.
 	Trigger   string
 	Port      string
+	Timezone  string // zona waktu pengingat/jadwal, default Asia/Jakarta
+
+	// Login: PAIR_PHONE diisi = login pakai kode pairing, bukan QR
+	PairPhone   string
+	LoginAPIKey string // auth /login/*; default SEND_API_KEY
+	AuditAPIKey string // auth /audit; default LOGIN_API_KEY
+
+	// Broadcast terjadwal: auth /broadcasts (default LOGIN_API_KEY) & batas media (bytes)
+	BroadcastAPIKey   string
+	BroadcastMaxMedia int64
 
 	// State DB (persist persona & pro per JID)
 	StateDB string
 
+	// Dispatcher pesan (worker pool)
+	DispatchWorkers int // job paralel maksimum
+	DispatchQueue   int // total antrean maksimum sebelum pesan di-drop
+
+	// Reconnect (backoff eksponensial) setelah StreamReplaced/ConnectFailure
+	ReconnectMin time.Duration
+	ReconnectMax time.Duration
+
+	// Batas waktu graceful shutdown (drain antrean, HTTP, WA, DB)
+	ShutdownTimeout time.Duration
+
+	// De-duplikasi pesan masuk (redelivery setelah reconnect/history sync)
+	DedupCache int           // kapasitas LRU ID pesan di memori
+	DedupTTL   time.Duration // umur catatan ID di SQLite
+	MsgMaxAge  time.Duration // pesan lebih tua dari ini dilewati (0 = nonaktif)
+
+	// Cooldown & kuota harian fitur mahal, mis. "imggen=60s/5,tts=30s/20"
+	QuotaLimits string
+
+	// Role minimal per fitur, mis. "imggen=premium,tts=premium" ("none" = tanpa
+	// batasan). Kosong (bawaan) = semua fitur terbuka untuk semua user; opt-in.
+	FeatureRoles string
+
+	// AllowlistOnly: bot hanya menjawab di grup yang ada di daftar !allowgroup
+	AllowlistOnly bool
+
+	// Umpan balik fitur lambat: reaksi ⏳→✅/❌ dan presence "mengetik…"
+	ProgressReactions bool
+	ProgressPresence  bool
+
+	// EditMode: pesan yang diedit dievaluasi ulang: "unhandled" (hanya jika
+	// pesan asli tidak ditangani), "all", atau "off"
+	EditMode string
+
 	// Auth & rate limit
 	SendAPIKey     string
 	SendRatePerMin int
 
 	// Gemini
-	GeminiKeys []string
+	GeminiKeys      []string
+	GeminiImageKeys []string // GEMINI_IMG_KEYS: key khusus imggen/hijabin (opsional)
+	GeminiModel     string
+
+	// Backend LLM per kemampuan (gemini | openai | ollama)
+	LLMText       string
+	LLMVision     string
+	LLMTranscribe string
+	LLMJSON       string
+
+	// OpenAI-compatible (/chat/completions)
+	OpenAIBaseURL  string
+	OpenAIKey      string
+	OpenAIModel    string
+	OpenAISTTModel string
+
+	// Ollama
+	OllamaBaseURL     string
+	OllamaModel       string
+	OllamaVisionModel string
 
 	// ElevenLabs
 	ElevenAPIKey string
@@ -46,6 +110,12 @@ type Config struct {
 	TTMaxDoc    int64
 	TTMaxSlides int
 
+	// Base URL API eksternal (bisa diarahkan ke server palsu untuk tes offline)
+	GeminiBaseURL    string
+	TikWMBaseURL     string
+	AnimeKitaBaseURL string
+	ElevenBaseURL    string
+
 	GeminiAPIKey string `env:"GEMINI_API_KEY" envDefault:""`
 }
 
@@ -54,41 +124,86 @@ func Load() Config {
 	_ = godotenv.Load()
 
 	cfg := Config{
-		SessionDB:      getenv("SESSION_PATH", "session.db"),
-		StateDB:        getenv("STATE_DB", "elaina_state.db"),
-		BotName:        getenv("BOT_NAME", "Elaina"),
-		Mode:           strings.ToUpper(getenv("MODE", "MANUAL")),
-		Trigger:        strings.ToLower(getenv("TRIGGER", "elaina")),
-		Port:           getenv("PORT", "7860"),
-		SendAPIKey:     os.Getenv("SEND_API_KEY"),
-		SendRatePerMin: mustAtoi(getenv("SEND_RATE_PER_MIN", "10")),
-		ElevenAPIKey:   os.Getenv("ELEVENLABS_API_KEY"),
-		ElevenVoice:    getenv("ELEVENLABS_VOICE_ID", "iWydkXKoiVtvdn4vLKp9"),
-		ElevenMime:     getenv("ELEVENLABS_FORMAT", "audio/ogg;codecs=opus"),
-		VNMaxWords:     mustAtoi(getenv("VN_MAX_WORDS", "80")),
-		BALinksURL:     getenv("BA_LINKS_URL", ""),
-		BALinksLocal:   getenv("BA_LINKS_LOCAL", "anime/bluearchive_links.json"),
-		PapLinksPath:   getenv("PAP_LINKS_PATH", "anime/pap_links.json"),
-		TTMaxVideo:     int64(mustAtoi(getenv("TIKTOK_MAX_VIDEO_MB", "50"))) << 20,
-		TTMaxImage:     int64(mustAtoi(getenv("TIKTOK_MAX_IMAGE_MB", "5"))) << 20,
-		TTMaxDoc:       int64(mustAtoi(getenv("TIKTOK_MAX_DOC_MB", "80"))) << 20,
-		TTMaxSlides:    mustAtoi(getenv("TIKTOK_MAX_SLIDES", "10")),
+		SessionDB:       getenv("SESSION_PATH", "session.db"),
+		StateDB:         getenv("STATE_DB", "elaina_state.db"),
+		BotName:         getenv("BOT_NAME", "Elaina"),
+		Mode:            strings.ToUpper(getenv("MODE", "MANUAL")),
+		Trigger:         strings.ToLower(getenv("TRIGGER", "elaina")),
+		Port:            getenv("PORT", "7860"),
+		Timezone:        getenv("TIMEZONE", "Asia/Jakarta"),
+		DispatchWorkers: mustAtoi(getenv("DISPATCH_WORKERS", "8")),
+		DispatchQueue:   mustAtoi(getenv("DISPATCH_QUEUE", "256")),
+		ShutdownTimeout: durationEnv("SHUTDOWN_TIMEOUT", 9*time.Second),
+		ReconnectMin:    durationEnv("RECONNECT_MIN", 2*time.Second),
+		ReconnectMax:    durationEnv("RECONNECT_MAX", 5*time.Minute),
+		DedupCache:      mustAtoi(getenv("DEDUP_CACHE", "4096")),
+		DedupTTL:        durationEnv("DEDUP_TTL", 48*time.Hour),
+		MsgMaxAge:       durationEnv("MSG_MAX_AGE", 10*time.Minute),
+		QuotaLimits:     os.Getenv("QUOTA_LIMITS"),
+		FeatureRoles:    getenv("FEATURE_ROLES", ""),
+		AllowlistOnly:   boolEnv("ALLOWLIST_ONLY", false),
+		EditMode:        strings.ToLower(getenv("EDIT_MODE", "unhandled")),
+		PairPhone:       strings.TrimSpace(os.Getenv("PAIR_PHONE")),
+		SendAPIKey:      os.Getenv("SEND_API_KEY"),
+		SendRatePerMin:  mustAtoi(getenv("SEND_RATE_PER_MIN", "10")),
+		ElevenAPIKey:    os.Getenv("ELEVENLABS_API_KEY"),
+		ElevenVoice:     getenv("ELEVENLABS_VOICE_ID", "iWydkXKoiVtvdn4vLKp9"),
+		ElevenMime:      getenv("ELEVENLABS_FORMAT", "audio/ogg;codecs=opus"),
+		VNMaxWords:      mustAtoi(getenv("VN_MAX_WORDS", "80")),
+		BALinksURL:      getenv("BA_LINKS_URL", ""),
+		BALinksLocal:    getenv("BA_LINKS_LOCAL", "anime/bluearchive_links.json"),
+		PapLinksPath:    getenv("PAP_LINKS_PATH", "anime/pap_links.json"),
+		TTMaxVideo:      int64(mustAtoi(getenv("TIKTOK_MAX_VIDEO_MB", "50"))) << 20,
+		TTMaxImage:      int64(mustAtoi(getenv("TIKTOK_MAX_IMAGE_MB", "5"))) << 20,
+		TTMaxDoc:        int64(mustAtoi(getenv("TIKTOK_MAX_DOC_MB", "80"))) << 20,
+		TTMaxSlides:     mustAtoi(getenv("TIKTOK_MAX_SLIDES", "10")),
+
+		GeminiBaseURL:    baseURL("GEMINI_BASE_URL", "https://generativelanguage.googleapis.com/v1beta"),
+		TikWMBaseURL:     baseURL("TIKWM_BASE_URL", "https://www.tikwm.com"),
+		AnimeKitaBaseURL: baseURL("ANIMEKITA_BASE_URL", "https://apps.animekita.org/api/v1.1.9"),
+		ElevenBaseURL:    baseURL("ELEVENLABS_BASE_URL", "https://api.elevenlabs.io"),
 	}
 
-	// Gemini keys: GEMINI_API_KEYS (comma) atau GEMINI_API_KEY (single)
+	// Gemini keys: GEMINI_API_KEYS (comma), GEMINI_API_KEY atau GEMINI_KEYS.
+	// Semua pemanggil Gemini berbagi satu pool key (llm.GeminiKeys).
 	keysEnv := os.Getenv("GEMINI_API_KEYS")
 	if keysEnv == "" {
 		keysEnv = os.Getenv("GEMINI_API_KEY")
 	}
-	for _, part := range strings.Split(keysEnv, ",") {
-		if k := strings.TrimSpace(part); k != "" {
-			cfg.GeminiKeys = append(cfg.GeminiKeys, k)
-		}
+	if keysEnv == "" {
+		keysEnv = os.Getenv("GEMINI_KEYS")
+	}
+	cfg.GeminiKeys = splitList(keysEnv)
+	cfg.GeminiImageKeys = splitList(os.Getenv("GEMINI_IMG_KEYS"))
+	cfg.GeminiModel = getenv("GEMINI_MODEL", "gemini-2.5-flash-lite")
+
+	cfg.LLMText = llmBackend("LLM_TEXT")
+	cfg.LLMVision = llmBackend("LLM_VISION")
+	cfg.LLMTranscribe = llmBackend("LLM_TRANSCRIBE")
+	cfg.LLMJSON = llmBackend("LLM_JSON")
+	cfg.OpenAIBaseURL = baseURL("OPENAI_BASE_URL", "https://api.openai.com/v1")
+	cfg.OpenAIKey = os.Getenv("OPENAI_API_KEY")
+	cfg.OpenAIModel = getenv("OPENAI_MODEL", "gpt-4o-mini")
+	cfg.OpenAISTTModel = getenv("OPENAI_TRANSCRIBE_MODEL", "whisper-1")
+	cfg.OllamaBaseURL = baseURL("OLLAMA_BASE_URL", "http://localhost:11434")
+	cfg.OllamaModel = getenv("OLLAMA_MODEL", "llama3.1")
+	cfg.OllamaVisionModel = getenv("OLLAMA_VISION_MODEL", cfg.OllamaModel)
+
+	usesGemini := false
+	for _, b := range []string{cfg.LLMText, cfg.LLMVision, cfg.LLMTranscribe, cfg.LLMJSON} {
+		usesGemini = usesGemini || b == "gemini"
 	}
-	if len(cfg.GeminiKeys) == 0 {
+	if len(cfg.GeminiKeys) == 0 && usesGemini {
 		log.Fatal("Tidak ada GEMINI_API_KEYS/GEMINI_API_KEY di .env (boleh beberapa key dipisah koma).")
 	}
 
+	cfg.LoginAPIKey = getenv("LOGIN_API_KEY", cfg.SendAPIKey)
+	cfg.AuditAPIKey = getenv("AUDIT_API_KEY", cfg.LoginAPIKey)
+	cfg.ProgressReactions = boolEnv("PROGRESS_REACTIONS", true)
+	cfg.ProgressPresence = boolEnv("PROGRESS_PRESENCE", true)
+	cfg.BroadcastAPIKey = getenv("BROADCAST_API_KEY", cfg.LoginAPIKey)
+	cfg.BroadcastMaxMedia = int64(mustAtoi(getenv("BROADCAST_MAX_MEDIA_MB", "16"))) << 20
+
 	// Opsional enforce di PROD
 	if cfg.Mode == "PROD" && cfg.SendAPIKey == "" {
 		log.Println("[WARN] MODE=PROD tapi SEND_API_KEY kosong. /send akan terbuka tanpa auth!")
@@ -104,6 +219,61 @@ func getenv(k, def string) string {
 	return def
 }
 
+// boolEnv menerima 1/true/yes (tanpa membedakan huruf besar).
+func boolEnv(k string, def bool) bool {
+	v := strings.TrimSpace(os.Getenv(k))
+	if v == "" {
+		return def
+	}
+	return v == "1" || strings.EqualFold(v, "true") || strings.EqualFold(v, "yes")
+}
+
+// durationEnv membaca durasi Go ("10m", "48h"); "0" menonaktifkan.
+func durationEnv(k string, def time.Duration) time.Duration {
+	v := strings.TrimSpace(os.Getenv(k))
+	if v == "" {
+		return def
+	}
+	if v == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Printf("[WARN] %s tidak valid (%q), pakai default %s", k, v, def)
+		return def
+	}
+	return d
+}
+
+// baseURL: seperti getenv, tanpa garis miring di akhir.
+func baseURL(k, def string) string {
+	return strings.TrimRight(strings.TrimSpace(getenv(k, def)), "/")
+}
+
+// splitList memecah daftar dipisah koma, membuang entri kosong.
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if k := strings.TrimSpace(part); k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// llmBackend membaca pilihan backend LLM; kosong/tidak dikenal → gemini.
+func llmBackend(k string) string {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv(k)))
+	switch v {
+	case "", "gemini":
+		return "gemini"
+	case "openai", "ollama":
+		return v
+	}
+	log.Printf("[WARN] %s tidak dikenal (%q), pakai gemini", k, v)
+	return "gemini"
+}
+
 func mustAtoi(s string) int {
 	n, err := strconv.Atoi(strings.TrimSpace(s))
 	if err != nil || n <= 0 {
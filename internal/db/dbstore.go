@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -22,6 +23,84 @@ type PeraturanState struct {
 	Updated time.Time
 }
 
+// QuotaLimit: batas pemakaian satu fitur. Nilai 0 berarti tanpa batas.
+type QuotaLimit struct {
+	Cooldown time.Duration
+	Daily    int
+}
+
+// QuotaUsage: pemakaian fitur oleh satu user pada satu hari.
+type QuotaUsage struct {
+	Uses     int
+	LastUsed time.Time // terakhir dipakai (lintas hari), nol jika belum pernah
+}
+
+// AccessEntry: satu baris ban/allow list (kind: ban_user, ban_chat, allow_chat).
+type AccessEntry struct {
+	Kind    string
+	JID     string
+	Reason  string
+	AddedBy string
+	Created time.Time
+}
+
+// AuditEntry: satu perintah yang tercatat di audit_log.
+type AuditEntry struct {
+	ID      int64     `json:"id"`
+	At      time.Time `json:"at"`
+	Chat    string    `json:"chat"`
+	Sender  string    `json:"sender"`
+	Command string    `json:"command"`
+	Args    string    `json:"args"`
+	Outcome string    `json:"outcome"`
+}
+
+// Reminder: satu pengingat; SentAt dan FailedAt nol = masih menunggu.
+type Reminder struct {
+	ID       int64
+	Chat     string
+	User     string
+	Text     string
+	Due      time.Time
+	Created  time.Time
+	SentAt   time.Time
+	FailedAt time.Time // menyerah: error permanen / percobaan habis
+	Attempts int       // kirim gagal sejauh ini
+}
+
+// Broadcast: job siaran berulang; NextRun nol = tidak ada jadwal berikutnya.
+type Broadcast struct {
+	ID        int64     `json:"id"`
+	Schedule  string    `json:"schedule"`
+	Targets   []string  `json:"targets"`
+	Text      string    `json:"text,omitempty"`
+	MediaURL  string    `json:"media_url,omitempty"`
+	CatchUp   string    `json:"catchup"`
+	Paused    bool      `json:"paused"`
+	NextRun   time.Time `json:"next_run"`
+	LastRun   time.Time `json:"last_run"`
+	CreatedBy string    `json:"created_by"`
+	Created   time.Time `json:"created_at"`
+}
+
+// MemoryTurn: satu giliran obrolan AI (role "user" atau "assistant").
+// Sender kosong untuk balasan bot.
+type MemoryTurn struct {
+	ID     int64
+	Chat   string
+	Sender string
+	Role   string
+	Text   string
+	At     time.Time
+}
+
+type RoleRecord struct {
+	User    string
+	Role    string
+	GrantBy string
+	Updated time.Time
+}
+
 type WarnRecord struct {
 	Group      string
 	User       string
@@ -30,8 +109,14 @@ type WarnRecord struct {
 	Updated    time.Time
 }
 
+// Open membuka state DB. Dispatcher, scheduler reminder/broadcast, dan
+// fitur menulis secara bersamaan: WAL membiarkan pembaca jalan saat ada
+// penulis, busy_timeout membuat penulis menunggu giliran alih-alih langsung
+// gagal SQLITE_BUSY, dan _txlock=immediate mengambil kunci tulis di awal
+// transaksi agar tidak buntu saat upgrade kunci.
 func Open(path string) (*Store, error) {
-	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +150,128 @@ func migrate(db *sql.DB) error {
 			PRIMARY KEY (group_jid, user_jid)
 		);
 		CREATE INDEX IF NOT EXISTS idx_peraturan_warn_group ON peraturan_warn(group_jid);
+		CREATE TABLE IF NOT EXISTS chat_features (
+			chat_jid TEXT NOT NULL,
+			feature TEXT NOT NULL,
+			enabled INTEGER NOT NULL DEFAULT 1,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (chat_jid, feature)
+		);
+		CREATE TABLE IF NOT EXISTS processed_messages (
+			msg_key TEXT PRIMARY KEY,
+			seen_at INTEGER NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_processed_messages_seen ON processed_messages(seen_at);
+		CREATE TABLE IF NOT EXISTS quota_usage (
+			user_jid TEXT NOT NULL,
+			feature TEXT NOT NULL,
+			day TEXT NOT NULL,
+			uses INTEGER NOT NULL DEFAULT 0,
+			last_used INTEGER NOT NULL,
+			PRIMARY KEY (user_jid, feature, day)
+		);
+		CREATE TABLE IF NOT EXISTS quota_limits (
+			chat_jid TEXT NOT NULL,
+			feature TEXT NOT NULL,
+			cooldown_sec INTEGER NOT NULL DEFAULT 0,
+			daily INTEGER NOT NULL DEFAULT 0,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (chat_jid, feature)
+		);
+		CREATE TABLE IF NOT EXISTS chat_triggers (
+			chat_jid TEXT PRIMARY KEY,
+			words TEXT NOT NULL,
+			updated_at INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS chat_lang (
+			chat_jid TEXT PRIMARY KEY,
+			lang TEXT NOT NULL,
+			updated_at INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS access_list (
+			kind TEXT NOT NULL,
+			jid TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			added_by TEXT NOT NULL DEFAULT '',
+			created_at INTEGER NOT NULL,
+			PRIMARY KEY (kind, jid)
+		);
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			at INTEGER NOT NULL,
+			chat_jid TEXT NOT NULL,
+			sender_jid TEXT NOT NULL,
+			command TEXT NOT NULL,
+			args TEXT NOT NULL DEFAULT '',
+			outcome TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_audit_log_chat ON audit_log(chat_jid, at);
+		CREATE TABLE IF NOT EXISTS reminders (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_jid TEXT NOT NULL,
+			user_jid TEXT NOT NULL,
+			text TEXT NOT NULL DEFAULT '',
+			due_at INTEGER NOT NULL,
+			created_at INTEGER NOT NULL,
+			sent_at INTEGER NOT NULL DEFAULT 0,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			failed_at INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE INDEX IF NOT EXISTS idx_reminders_due ON reminders(sent_at, due_at);
+		CREATE TABLE IF NOT EXISTS broadcasts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			schedule TEXT NOT NULL,
+			targets TEXT NOT NULL,
+			text TEXT NOT NULL DEFAULT '',
+			media_url TEXT NOT NULL DEFAULT '',
+			catchup TEXT NOT NULL DEFAULT 'skip',
+			paused INTEGER NOT NULL DEFAULT 0,
+			next_run INTEGER NOT NULL DEFAULT 0,
+			last_run INTEGER NOT NULL DEFAULT 0,
+			created_by TEXT NOT NULL DEFAULT '',
+			created_at INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS user_roles (
+			user_jid TEXT PRIMARY KEY,
+			role TEXT NOT NULL,
+			granted_by TEXT NOT NULL DEFAULT '',
+			updated_at INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS memory_turns (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_jid TEXT NOT NULL,
+			sender_jid TEXT NOT NULL DEFAULT '',
+			role TEXT NOT NULL,
+			text TEXT NOT NULL,
+			at INTEGER NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_memory_turns_chat ON memory_turns(chat_jid, id);
+		CREATE TABLE IF NOT EXISTS user_nicknames (
+			user_jid TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			updated_at INTEGER NOT NULL
+		);
 	`)
+	if err != nil {
+		return err
+	}
+	// Kolom yang ditambahkan setelah tabelnya dibuat (DB versi lama).
+	if err := addColumn(db, "reminders", "attempts", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	return addColumn(db, "reminders", "failed_at", "INTEGER NOT NULL DEFAULT 0")
+}
+
+// addColumn menambah kolom ke tabel yang sudah ada; no-op jika sudah ada.
+func addColumn(db *sql.DB, table, column, def string) error {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err := db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + def)
 	return err
 }
 
@@ -262,3 +468,673 @@ func (s *Store) ListWarns(group string) ([]WarnRecord, error) {
 	}
 	return out, rows.Err()
 }
+
+func (s *Store) SetFeatureEnabled(chat, feature string, enabled bool) error {
+	flag := 0
+	if enabled {
+		flag = 1
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO chat_features(chat_jid, feature, enabled, updated_at)
+		VALUES(?, ?, ?, ?)
+		ON CONFLICT(chat_jid, feature) DO UPDATE SET
+			enabled = excluded.enabled,
+			updated_at = excluded.updated_at
+	`, chat, feature, flag, time.Now().Unix())
+	return err
+}
+
+// DisabledFeatures mengembalikan set fitur yang dimatikan di chat (default: semua aktif).
+func (s *Store) DisabledFeatures(chat string) (map[string]bool, error) {
+	rows, err := s.db.Query(`SELECT feature FROM chat_features WHERE chat_jid = ? AND enabled = 0`, chat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		out[name] = true
+	}
+	return out, rows.Err()
+}
+
+// GetQuotaUsage mengembalikan jumlah pemakaian user pada day (YYYY-MM-DD)
+// dan waktu pemakaian terakhirnya untuk cooldown.
+func (s *Store) GetQuotaUsage(user, feature, day string) (QuotaUsage, error) {
+	var u QuotaUsage
+	var uses sql.NullInt64
+	var last sql.NullInt64
+	err := s.db.QueryRow(`
+		SELECT
+			(SELECT uses FROM quota_usage WHERE user_jid = ? AND feature = ? AND day = ?),
+			(SELECT MAX(last_used) FROM quota_usage WHERE user_jid = ? AND feature = ?)
+	`, user, feature, day, user, feature).Scan(&uses, &last)
+	if err != nil {
+		return u, err
+	}
+	u.Uses = int(uses.Int64)
+	if last.Valid {
+		u.LastUsed = time.Unix(last.Int64, 0)
+	}
+	return u, nil
+}
+
+// AddQuotaUsage mencatat satu pemakaian dan mengembalikan total hari itu.
+func (s *Store) AddQuotaUsage(user, feature, day string, at time.Time) (int, error) {
+	var uses int
+	err := s.db.QueryRow(`
+		INSERT INTO quota_usage(user_jid, feature, day, uses, last_used)
+		VALUES(?, ?, ?, 1, ?)
+		ON CONFLICT(user_jid, feature, day) DO UPDATE SET
+			uses = quota_usage.uses + 1,
+			last_used = excluded.last_used
+		RETURNING uses
+	`, user, feature, day, at.Unix()).Scan(&uses)
+	return uses, err
+}
+
+// RefundQuotaUsage membatalkan satu pemakaian pada day yang dicatat pada at:
+// uses dikurangi satu dan last_used dikembalikan ke prev (jika belum ditimpa
+// pemakaian lain). Baris yang habis dihapus.
+func (s *Store) RefundQuotaUsage(user, feature, day string, at, prev time.Time) error {
+	var prevUnix int64
+	if !prev.IsZero() {
+		prevUnix = prev.Unix()
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`
+		UPDATE quota_usage SET
+			uses = uses - 1,
+			last_used = CASE WHEN last_used = ? THEN ? ELSE last_used END
+		WHERE user_jid = ? AND feature = ? AND day = ? AND uses > 0
+	`, at.Unix(), prevUnix, user, feature, day); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM quota_usage WHERE user_jid = ? AND feature = ? AND day = ? AND uses <= 0
+	`, user, feature, day); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PruneQuotaUsage menghapus catatan sebelum day (YYYY-MM-DD).
+func (s *Store) PruneQuotaUsage(day string) error {
+	_, err := s.db.Exec(`DELETE FROM quota_usage WHERE day < ?`, day)
+	return err
+}
+
+func (s *Store) SetChatQuota(chat, feature string, lim QuotaLimit) error {
+	_, err := s.db.Exec(`
+		INSERT INTO quota_limits(chat_jid, feature, cooldown_sec, daily, updated_at)
+		VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, feature) DO UPDATE SET
+			cooldown_sec = excluded.cooldown_sec,
+			daily = excluded.daily,
+			updated_at = excluded.updated_at
+	`, chat, feature, int64(lim.Cooldown/time.Second), lim.Daily, time.Now().Unix())
+	return err
+}
+
+func (s *Store) DeleteChatQuota(chat, feature string) error {
+	_, err := s.db.Exec(`DELETE FROM quota_limits WHERE chat_jid = ? AND feature = ?`, chat, feature)
+	return err
+}
+
+// ChatQuotas mengembalikan override batas fitur untuk satu chat.
+func (s *Store) ChatQuotas(chat string) (map[string]QuotaLimit, error) {
+	rows, err := s.db.Query(`SELECT feature, cooldown_sec, daily FROM quota_limits WHERE chat_jid = ?`, chat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]QuotaLimit{}
+	for rows.Next() {
+		var name string
+		var cd int64
+		var daily int
+		if err := rows.Scan(&name, &cd, &daily); err != nil {
+			return nil, err
+		}
+		out[name] = QuotaLimit{Cooldown: time.Duration(cd) * time.Second, Daily: daily}
+	}
+	return out, rows.Err()
+}
+
+// MarkProcessed menandai pesan sebagai sudah diproses. fresh=false jika key
+// sudah tercatat setelah notBefore (catatan lebih lama dianggap kedaluwarsa).
+func (s *Store) MarkProcessed(key string, at, notBefore time.Time) (fresh bool, err error) {
+	res, err := s.db.Exec(`
+		INSERT INTO processed_messages(msg_key, seen_at) VALUES(?, ?)
+		ON CONFLICT(msg_key) DO UPDATE SET seen_at = excluded.seen_at
+		WHERE processed_messages.seen_at < ?
+	`, key, at.Unix(), notBefore.Unix())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) PruneProcessed(before time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM processed_messages WHERE seen_at < ?`, before.Unix())
+	return err
+}
+
+// ChatLang mengembalikan kode bahasa chat; kosong jika belum pernah disetel.
+func (s *Store) ChatLang(chat string) (string, error) {
+	var lang string
+	err := s.db.QueryRow(`SELECT lang FROM chat_lang WHERE chat_jid = ?`, chat).Scan(&lang)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return lang, err
+}
+
+func (s *Store) SetChatLang(chat, lang string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO chat_lang(chat_jid, lang, updated_at)
+		VALUES(?, ?, ?)
+		ON CONFLICT(chat_jid) DO UPDATE SET
+			lang = excluded.lang,
+			updated_at = excluded.updated_at
+	`, chat, lang, time.Now().Unix())
+	return err
+}
+
+// ChatTriggers mengembalikan alias trigger chat (kosong = pakai bawaan).
+func (s *Store) ChatTriggers(chat string) ([]string, error) {
+	var words string
+	err := s.db.QueryRow(`SELECT words FROM chat_triggers WHERE chat_jid = ?`, chat).Scan(&words)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, w := range strings.Split(words, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) SetChatTriggers(chat string, words []string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO chat_triggers(chat_jid, words, updated_at)
+		VALUES(?, ?, ?)
+		ON CONFLICT(chat_jid) DO UPDATE SET
+			words = excluded.words,
+			updated_at = excluded.updated_at
+	`, chat, strings.Join(words, ","), time.Now().Unix())
+	return err
+}
+
+func (s *Store) DeleteChatTriggers(chat string) error {
+	_, err := s.db.Exec(`DELETE FROM chat_triggers WHERE chat_jid = ?`, chat)
+	return err
+}
+
+// GetRole mengembalikan nama role user; kosong jika tidak punya role.
+func (s *Store) GetRole(user string) (string, error) {
+	var role string
+	err := s.db.QueryRow(`SELECT role FROM user_roles WHERE user_jid = ?`, user).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
+
+func (s *Store) SetRole(user, role, by string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO user_roles(user_jid, role, granted_by, updated_at)
+		VALUES(?, ?, ?, ?)
+		ON CONFLICT(user_jid) DO UPDATE SET
+			role = excluded.role,
+			granted_by = excluded.granted_by,
+			updated_at = excluded.updated_at
+	`, user, role, by, time.Now().Unix())
+	return err
+}
+
+func (s *Store) DeleteRole(user string) error {
+	_, err := s.db.Exec(`DELETE FROM user_roles WHERE user_jid = ?`, user)
+	return err
+}
+
+func (s *Store) ListRoles() ([]RoleRecord, error) {
+	rows, err := s.db.Query(`SELECT user_jid, role, granted_by, updated_at FROM user_roles ORDER BY updated_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []RoleRecord
+	for rows.Next() {
+		var rec RoleRecord
+		var ts int64
+		if err := rows.Scan(&rec.User, &rec.Role, &rec.GrantBy, &ts); err != nil {
+			return nil, err
+		}
+		rec.Updated = time.Unix(ts, 0)
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) AddAccess(e AccessEntry) error {
+	_, err := s.db.Exec(`
+		INSERT INTO access_list(kind, jid, reason, added_by, created_at)
+		VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT(kind, jid) DO UPDATE SET
+			reason = excluded.reason,
+			added_by = excluded.added_by
+	`, e.Kind, e.JID, e.Reason, e.AddedBy, e.Created.Unix())
+	return err
+}
+
+// RemoveAccess menghapus entri; removed=false jika entri tidak ada.
+func (s *Store) RemoveAccess(kind, jid string) (removed bool, err error) {
+	res, err := s.db.Exec(`DELETE FROM access_list WHERE kind = ? AND jid = ?`, kind, jid)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) ListAccess() ([]AccessEntry, error) {
+	rows, err := s.db.Query(`SELECT kind, jid, reason, added_by, created_at FROM access_list ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []AccessEntry
+	for rows.Next() {
+		var e AccessEntry
+		var ts int64
+		if err := rows.Scan(&e.Kind, &e.JID, &e.Reason, &e.AddedBy, &ts); err != nil {
+			return nil, err
+		}
+		e.Created = time.Unix(ts, 0)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) AddAudit(e AuditEntry) error {
+	_, err := s.db.Exec(`
+		INSERT INTO audit_log(at, chat_jid, sender_jid, command, args, outcome)
+		VALUES(?, ?, ?, ?, ?, ?)
+	`, e.At.Unix(), e.Chat, e.Sender, e.Command, e.Args, e.Outcome)
+	return err
+}
+
+// RecentAudit mengembalikan n entri terbaru (terbaru dulu); chat kosong = semua chat.
+func (s *Store) RecentAudit(n int, chat string) ([]AuditEntry, error) {
+	q := `SELECT id, at, chat_jid, sender_jid, command, args, outcome FROM audit_log`
+	args := []any{}
+	if chat != "" {
+		q += ` WHERE chat_jid = ?`
+		args = append(args, chat)
+	}
+	q += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, n)
+
+	rows, err := s.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		var ts int64
+		if err := rows.Scan(&e.ID, &ts, &e.Chat, &e.Sender, &e.Command, &e.Args, &e.Outcome); err != nil {
+			return nil, err
+		}
+		e.At = time.Unix(ts, 0)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) AddReminder(r Reminder) (int64, error) {
+	res, err := s.db.Exec(`
+		INSERT INTO reminders(chat_jid, user_jid, text, due_at, created_at)
+		VALUES(?, ?, ?, ?, ?)
+	`, r.Chat, r.User, r.Text, r.Due.Unix(), r.Created.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+const reminderCols = `id, chat_jid, user_jid, text, due_at, created_at, sent_at, failed_at, attempts`
+
+func scanReminders(rows *sql.Rows) ([]Reminder, error) {
+	defer rows.Close()
+	var out []Reminder
+	for rows.Next() {
+		var r Reminder
+		var due, created, sent, failed int64
+		if err := rows.Scan(&r.ID, &r.Chat, &r.User, &r.Text, &due, &created, &sent, &failed, &r.Attempts); err != nil {
+			return nil, err
+		}
+		r.Due, r.Created = time.Unix(due, 0), time.Unix(created, 0)
+		if sent > 0 {
+			r.SentAt = time.Unix(sent, 0)
+		}
+		if failed > 0 {
+			r.FailedAt = time.Unix(failed, 0)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// PendingReminders: pengingat user yang belum terkirim, urut waktu.
+func (s *Store) PendingReminders(user string) ([]Reminder, error) {
+	rows, err := s.db.Query(`SELECT `+reminderCols+` FROM reminders WHERE user_jid = ? AND sent_at = 0 AND failed_at = 0 ORDER BY due_at`, user)
+	if err != nil {
+		return nil, err
+	}
+	return scanReminders(rows)
+}
+
+// DueReminders: pengingat belum terkirim dengan due_at <= now.
+func (s *Store) DueReminders(now time.Time) ([]Reminder, error) {
+	rows, err := s.db.Query(`SELECT `+reminderCols+` FROM reminders WHERE sent_at = 0 AND failed_at = 0 AND due_at <= ? ORDER BY due_at`, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+	return scanReminders(rows)
+}
+
+// NextReminderDue: waktu pengingat berikutnya yang belum terkirim.
+func (s *Store) NextReminderDue() (time.Time, bool, error) {
+	var due sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MIN(due_at) FROM reminders WHERE sent_at = 0 AND failed_at = 0`).Scan(&due); err != nil {
+		return time.Time{}, false, err
+	}
+	if !due.Valid {
+		return time.Time{}, false, nil
+	}
+	return time.Unix(due.Int64, 0), true, nil
+}
+
+func (s *Store) MarkReminderSent(id int64, at time.Time) error {
+	_, err := s.db.Exec(`UPDATE reminders SET sent_at = ? WHERE id = ?`, at.Unix(), id)
+	return err
+}
+
+// RecordReminderAttempt menambah hitungan kirim gagal; mengembalikan total.
+func (s *Store) RecordReminderAttempt(id int64) (int, error) {
+	var n int
+	err := s.db.QueryRow(`UPDATE reminders SET attempts = attempts + 1 WHERE id = ? RETURNING attempts`, id).Scan(&n)
+	return n, err
+}
+
+// MarkReminderFailed menandai pengingat gagal permanen (tidak dicoba lagi).
+func (s *Store) MarkReminderFailed(id int64, at time.Time) error {
+	_, err := s.db.Exec(`UPDATE reminders SET failed_at = ? WHERE id = ?`, at.Unix(), id)
+	return err
+}
+
+// DeleteReminder menghapus pengingat milik user yang belum terkirim.
+func (s *Store) DeleteReminder(id int64, user string) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM reminders WHERE id = ? AND user_jid = ? AND sent_at = 0 AND failed_at = 0`, id, user)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// PruneReminders menghapus pengingat terkirim/gagal sebelum waktu tertentu.
+func (s *Store) PruneReminders(before time.Time) error {
+	_, err := s.db.Exec(`
+		DELETE FROM reminders
+		WHERE (sent_at > 0 AND sent_at < ?) OR (failed_at > 0 AND failed_at < ?)
+	`, before.Unix(), before.Unix())
+	return err
+}
+
+func (s *Store) AddBroadcast(b Broadcast) (int64, error) {
+	res, err := s.db.Exec(`
+		INSERT INTO broadcasts(schedule, targets, text, media_url, catchup, paused, next_run, created_by, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, b.Schedule, strings.Join(b.Targets, ","), b.Text, b.MediaURL, b.CatchUp, boolToInt(b.Paused), unixOrZero(b.NextRun), b.CreatedBy, b.Created.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+const broadcastCols = `id, schedule, targets, text, media_url, catchup, paused, next_run, last_run, created_by, created_at`
+
+func scanBroadcasts(rows *sql.Rows) ([]Broadcast, error) {
+	defer rows.Close()
+	var out []Broadcast
+	for rows.Next() {
+		var b Broadcast
+		var targets string
+		var paused int
+		var next, last, created int64
+		if err := rows.Scan(&b.ID, &b.Schedule, &targets, &b.Text, &b.MediaURL, &b.CatchUp, &paused, &next, &last, &b.CreatedBy, &created); err != nil {
+			return nil, err
+		}
+		b.Targets = strings.Split(targets, ",")
+		b.Paused = paused != 0
+		b.Created = time.Unix(created, 0)
+		if next > 0 {
+			b.NextRun = time.Unix(next, 0)
+		}
+		if last > 0 {
+			b.LastRun = time.Unix(last, 0)
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) ListBroadcasts() ([]Broadcast, error) {
+	rows, err := s.db.Query(`SELECT ` + broadcastCols + ` FROM broadcasts ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return scanBroadcasts(rows)
+}
+
+func (s *Store) GetBroadcast(id int64) (Broadcast, bool, error) {
+	rows, err := s.db.Query(`SELECT `+broadcastCols+` FROM broadcasts WHERE id = ?`, id)
+	if err != nil {
+		return Broadcast{}, false, err
+	}
+	list, err := scanBroadcasts(rows)
+	if err != nil || len(list) == 0 {
+		return Broadcast{}, false, err
+	}
+	return list[0], true, nil
+}
+
+// DueBroadcasts: job aktif dengan next_run <= now.
+func (s *Store) DueBroadcasts(now time.Time) ([]Broadcast, error) {
+	rows, err := s.db.Query(`SELECT `+broadcastCols+` FROM broadcasts WHERE paused = 0 AND next_run > 0 AND next_run <= ? ORDER BY next_run`, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+	return scanBroadcasts(rows)
+}
+
+// NextBroadcastDue: next_run terdekat dari job aktif.
+func (s *Store) NextBroadcastDue() (time.Time, bool, error) {
+	var next sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MIN(next_run) FROM broadcasts WHERE paused = 0 AND next_run > 0`).Scan(&next); err != nil {
+		return time.Time{}, false, err
+	}
+	if !next.Valid {
+		return time.Time{}, false, nil
+	}
+	return time.Unix(next.Int64, 0), true, nil
+}
+
+// UpdateBroadcastRun mencatat jalan terakhir (nol = tidak diubah) dan jadwal berikutnya.
+func (s *Store) UpdateBroadcastRun(id int64, last, next time.Time) error {
+	if last.IsZero() {
+		_, err := s.db.Exec(`UPDATE broadcasts SET next_run = ? WHERE id = ?`, unixOrZero(next), id)
+		return err
+	}
+	_, err := s.db.Exec(`UPDATE broadcasts SET last_run = ?, next_run = ? WHERE id = ?`, last.Unix(), unixOrZero(next), id)
+	return err
+}
+
+// SetBroadcastPaused menjeda/melanjutkan job; next dipakai saat dilanjutkan.
+func (s *Store) SetBroadcastPaused(id int64, paused bool, next time.Time) (bool, error) {
+	res, err := s.db.Exec(`UPDATE broadcasts SET paused = ?, next_run = ? WHERE id = ?`, boolToInt(paused), unixOrZero(next), id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) SetBroadcastCatchUp(id int64, policy string) (bool, error) {
+	res, err := s.db.Exec(`UPDATE broadcasts SET catchup = ? WHERE id = ?`, policy, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) DeleteBroadcast(id int64) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM broadcasts WHERE id = ?`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) AddMemoryTurn(t MemoryTurn) error {
+	_, err := s.db.Exec(`
+		INSERT INTO memory_turns(chat_jid, sender_jid, role, text, at)
+		VALUES(?, ?, ?, ?, ?)
+	`, t.Chat, t.Sender, t.Role, t.Text, t.At.Unix())
+	return err
+}
+
+// RecentMemoryTurns mengembalikan n giliran terakhir chat, urut lama → baru.
+func (s *Store) RecentMemoryTurns(chat string, n int) ([]MemoryTurn, error) {
+	rows, err := s.db.Query(`
+		SELECT id, chat_jid, sender_jid, role, text, at FROM (
+			SELECT * FROM memory_turns WHERE chat_jid = ? ORDER BY id DESC LIMIT ?
+		) ORDER BY id
+	`, chat, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []MemoryTurn
+	for rows.Next() {
+		var t MemoryTurn
+		var ts int64
+		if err := rows.Scan(&t.ID, &t.Chat, &t.Sender, &t.Role, &t.Text, &ts); err != nil {
+			return nil, err
+		}
+		t.At = time.Unix(ts, 0)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// PruneMemoryTurns menyisakan keep giliran terbaru untuk chat.
+func (s *Store) PruneMemoryTurns(chat string, keep int) error {
+	_, err := s.db.Exec(`
+		DELETE FROM memory_turns WHERE chat_jid = ? AND id NOT IN (
+			SELECT id FROM memory_turns WHERE chat_jid = ? ORDER BY id DESC LIMIT ?
+		)
+	`, chat, chat, keep)
+	return err
+}
+
+func (s *Store) SetNickname(user, name string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO user_nicknames(user_jid, name, updated_at)
+		VALUES(?, ?, ?)
+		ON CONFLICT(user_jid) DO UPDATE SET
+			name = excluded.name,
+			updated_at = excluded.updated_at
+	`, user, name, time.Now().Unix())
+	return err
+}
+
+// Nicknames mengembalikan semua nama panggilan (user JID → nama).
+func (s *Store) Nicknames() (map[string]string, error) {
+	rows, err := s.db.Query(`SELECT user_jid, name FROM user_nicknames`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]string{}
+	for rows.Next() {
+		var user, name string
+		if err := rows.Scan(&user, &name); err != nil {
+			return nil, err
+		}
+		out[user] = name
+	}
+	return out, rows.Err()
+}
+
+// ImportMemory memasukkan riwayat & nama panggilan lama dalam satu
+// transaksi. Nama yang sudah ada di DB tidak ditimpa.
+func (s *Store) ImportMemory(turns []MemoryTurn, nicks map[string]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, t := range turns {
+		if _, err := tx.Exec(`
+			INSERT INTO memory_turns(chat_jid, sender_jid, role, text, at)
+			VALUES(?, ?, ?, ?, ?)
+		`, t.Chat, t.Sender, t.Role, t.Text, t.At.Unix()); err != nil {
+			return err
+		}
+	}
+	now := time.Now().Unix()
+	for user, name := range nicks {
+		if _, err := tx.Exec(`
+			INSERT INTO user_nicknames(user_jid, name, updated_at) VALUES(?, ?, ?)
+			ON CONFLICT(user_jid) DO NOTHING
+		`, user, name, now); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
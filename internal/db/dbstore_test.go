@@ -0,0 +1,80 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentWrites(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	const workers, perWorker = 8, 200
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	now := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if _, err := store.MarkProcessed(fmt.Sprintf("chat/%d-%d", w, i), now, time.Time{}); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		t.Fatalf("%d dari %d penulisan gagal, contoh: %v", len(errs), workers*perWorker, errs[0])
+	}
+}
+
+// DB dari versi sebelum attempts/failed_at tetap bisa dibuka dan dipakai.
+func TestMigrateOldReminders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	old, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Exec(`
+		CREATE TABLE reminders (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_jid TEXT NOT NULL,
+			user_jid TEXT NOT NULL,
+			text TEXT NOT NULL DEFAULT '',
+			due_at INTEGER NOT NULL,
+			created_at INTEGER NOT NULL,
+			sent_at INTEGER NOT NULL DEFAULT 0
+		);
+		INSERT INTO reminders(chat_jid, user_jid, text, due_at, created_at) VALUES('c', 'u', 'lama', 1, 1);
+	`); err != nil {
+		t.Fatal(err)
+	}
+	old.Close()
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if n, err := store.RecordReminderAttempt(1); err != nil || n != 1 {
+		t.Fatalf("RecordReminderAttempt = %d, %v", n, err)
+	}
+	due, err := store.DueReminders(time.Now())
+	if err != nil || len(due) != 1 || due[0].Attempts != 1 {
+		t.Fatalf("DueReminders = %+v, %v", due, err)
+	}
+}
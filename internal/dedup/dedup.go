@@ -0,0 +1,115 @@
+// Package dedup mencegah pesan yang sama diproses dua kali, mis. saat
+// whatsmeow mengirim ulang events.Message setelah reconnect/history sync.
+//
+// ID yang sudah diproses disimpan di LRU memori (cepat) dan di SQLite
+// (bertahan setelah restart) dengan TTL.
+package dedup
+
+import (
+	"container/list"
+	"log"
+	"sync"
+	"time"
+
+	"wa-elaina/internal/db"
+)
+
+type Filter struct {
+	store  *db.Store // boleh nil: hanya memori
+	size   int
+	ttl    time.Duration
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	order     *list.List // depan = terbaru
+	items     map[string]*list.Element
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type entry struct {
+	key string
+	at  time.Time
+}
+
+// New membuat filter. size = kapasitas LRU, ttl = umur catatan di SQLite,
+// maxAge = pesan lebih tua dari ini dilewati (0 = nonaktif).
+// ttl 0 berarti tanpa persistensi (hanya LRU memori).
+func New(store *db.Store, size int, ttl, maxAge time.Duration) *Filter {
+	if size <= 0 {
+		size = 4096
+	}
+	if ttl <= 0 {
+		store = nil
+	}
+	return &Filter{
+		store:  store,
+		size:   size,
+		ttl:    ttl,
+		maxAge: maxAge,
+		order:  list.New(),
+		items:  make(map[string]*list.Element, size),
+		now:    time.Now,
+	}
+}
+
+// TooOld: timestamp pesan melewati batas umur (replay lama).
+func (f *Filter) TooOld(ts time.Time) bool {
+	if f == nil || f.maxAge <= 0 || ts.IsZero() {
+		return false
+	}
+	return f.now().Sub(ts) > f.maxAge
+}
+
+// Seen mengembalikan true jika key sudah pernah diproses; jika belum, key
+// langsung ditandai sehingga panggilan berikutnya mengembalikan true.
+func (f *Filter) Seen(key string) bool {
+	if f == nil || key == "" {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if el, ok := f.items[key]; ok {
+		if f.ttl <= 0 || now.Sub(el.Value.(*entry).at) < f.ttl {
+			f.order.MoveToFront(el)
+			return true
+		}
+		f.order.Remove(el)
+		delete(f.items, key)
+	}
+
+	if f.store != nil {
+		fresh, err := f.store.MarkProcessed(key, now, now.Add(-f.ttl))
+		if err != nil {
+			log.Printf("[DEDUP] simpan %s: %v", key, err)
+		} else if !fresh {
+			f.remember(key, now)
+			return true
+		}
+		f.prune(now)
+	}
+	f.remember(key, now)
+	return false
+}
+
+func (f *Filter) remember(key string, at time.Time) {
+	f.items[key] = f.order.PushFront(&entry{key: key, at: at})
+	for f.order.Len() > f.size {
+		old := f.order.Back()
+		f.order.Remove(old)
+		delete(f.items, old.Value.(*entry).key)
+	}
+}
+
+// prune membersihkan catatan SQLite yang kedaluwarsa, paling sering sejam sekali.
+func (f *Filter) prune(now time.Time) {
+	if f.ttl <= 0 || now.Sub(f.lastPrune) < time.Hour {
+		return
+	}
+	f.lastPrune = now
+	if err := f.store.PruneProcessed(now.Add(-f.ttl)); err != nil {
+		log.Printf("[DEDUP] prune: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package dispatch
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// Dispatcher menjalankan job di luar event loop whatsmeow dengan:
+//   - batas konkurensi global (jumlah job yang berjalan bersamaan),
+//   - urutan per chat (job dari chat yang sama diproses berurutan),
+//   - antrean terbatas; job yang tidak muat dibuang dan dilaporkan.
+type Dispatcher struct {
+	sem      chan struct{}
+	maxQueue int
+
+	mu      sync.Mutex
+	chats   map[string]*chatQueue
+	queued  int
+	closed  bool
+	stopped bool           // batas waktu shutdown lewat: job yang belum mulai tidak dijalankan
+	wg      sync.WaitGroup // job yang diterima & belum selesai
+
+	running atomic.Int64
+	dropped atomic.Uint64
+
+	// OnDrop opsional; dipanggil (di goroutine pemanggil Submit) saat job
+	// dibuang karena antrean penuh. Jangan memblokir di sini.
+	OnDrop func(chat, id string)
+}
+
+type job struct {
+	id  string
+	run func()
+}
+
+type chatQueue struct {
+	jobs   []job
+	active bool
+}
+
+// Stats adalah potret kondisi dispatcher.
+type Stats struct {
+	Queued  int
+	Running int64
+	Dropped uint64
+}
+
+// New membuat dispatcher; workers = batas job paralel, queue = batas total antrean.
+func New(workers, queue int) *Dispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queue <= 0 {
+		queue = 1
+	}
+	return &Dispatcher{
+		sem:      make(chan struct{}, workers),
+		maxQueue: queue,
+		chats:    make(map[string]*chatQueue),
+	}
+}
+
+// Submit memasukkan job ke antrean chat. Mengembalikan false jika antrean
+// penuh atau dispatcher sedang dimatikan.
+func (d *Dispatcher) Submit(chat, id string, run func()) bool {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		log.Printf("[DISPATCH] sedang shutdown, tolak chat=%s id=%s", chat, id)
+		return false
+	}
+	if d.queued >= d.maxQueue {
+		d.mu.Unlock()
+		n := d.dropped.Add(1)
+		log.Printf("[DISPATCH] antrean penuh (%d), drop chat=%s id=%s (total drop=%d)", d.maxQueue, chat, id, n)
+		if d.OnDrop != nil {
+			d.OnDrop(chat, id)
+		}
+		return false
+	}
+	q := d.chats[chat]
+	if q == nil {
+		q = &chatQueue{}
+		d.chats[chat] = q
+	}
+	q.jobs = append(q.jobs, job{id: id, run: run})
+	d.queued++
+	d.wg.Add(1)
+	start := !q.active
+	q.active = true
+	d.mu.Unlock()
+
+	if start {
+		go d.drain(chat, q)
+	}
+	return true
+}
+
+// Cancel membuang job chat/id yang masih antre (belum mulai). Mengembalikan
+// false jika job tidak ditemukan, mis. sudah berjalan atau selesai.
+func (d *Dispatcher) Cancel(chat, id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q := d.chats[chat]
+	if q == nil {
+		return false
+	}
+	for i, j := range q.jobs {
+		if j.id != id {
+			continue
+		}
+		q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+		d.queued--
+		d.wg.Done()
+		return true
+	}
+	return false
+}
+
+// drain memproses antrean satu chat sampai kosong, satu job per slot global.
+func (d *Dispatcher) drain(chat string, q *chatQueue) {
+	for {
+		d.mu.Lock()
+		if len(q.jobs) == 0 {
+			q.active = false
+			delete(d.chats, chat)
+			d.mu.Unlock()
+			return
+		}
+		j := q.jobs[0]
+		q.jobs = q.jobs[1:]
+		d.queued--
+		d.mu.Unlock()
+
+		d.sem <- struct{}{}
+		d.mu.Lock()
+		stopped := d.stopped
+		d.mu.Unlock()
+		if !stopped {
+			d.exec(chat, j)
+		}
+		<-d.sem
+		d.wg.Done()
+	}
+}
+
+// Shutdown menolak job baru lalu menunggu antrean habis. Jika ctx berakhir
+// lebih dulu, job yang belum mulai dibuang; job yang sedang berjalan tidak
+// bisa dibatalkan dan dibiarkan selesai sendiri (cek Stats().Running sebelum
+// menutup resource yang masih mereka pakai).
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	d.stopped = true
+	abandoned := 0
+	for _, q := range d.chats {
+		abandoned += len(q.jobs)
+		for range q.jobs {
+			d.wg.Done()
+		}
+		q.jobs = nil
+	}
+	d.queued -= abandoned
+	d.mu.Unlock()
+	log.Printf("[DISPATCH] batas waktu shutdown: %d job dibuang, %d masih berjalan", abandoned, d.running.Load())
+	return ctx.Err()
+}
+
+func (d *Dispatcher) exec(chat string, j job) {
+	d.running.Add(1)
+	defer d.running.Add(-1)
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Printf("[DISPATCH] panic chat=%s id=%s: %v", chat, j.id, rec)
+		}
+	}()
+	j.run()
+}
+
+func (d *Dispatcher) Stats() Stats {
+	d.mu.Lock()
+	queued := d.queued
+	d.mu.Unlock()
+	return Stats{Queued: queued, Running: d.running.Load(), Dropped: d.dropped.Load()}
+}
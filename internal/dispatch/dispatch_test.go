@@ -0,0 +1,182 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gate: job yang menunggu sampai release dipanggil.
+type gate struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGate() *gate {
+	return &gate{started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (g *gate) run() {
+	g.started <- struct{}{}
+	<-g.release
+}
+
+func waitStarted(t *testing.T, g *gate) {
+	t.Helper()
+	select {
+	case <-g.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job tidak mulai")
+	}
+}
+
+func TestOrderWithinChat(t *testing.T) {
+	d := New(4, 100)
+	var mu sync.Mutex
+	var got []int
+	for i := 0; i < 50; i++ {
+		i := i
+		if !d.Submit("chat", strconv.Itoa(i), func() {
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+		}) {
+			t.Fatalf("submit %d ditolak", i)
+		}
+	}
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 50 {
+		t.Fatalf("jalan %d job, mau 50", len(got))
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("urutan salah di %d: %v", i, got)
+		}
+	}
+}
+
+func TestGlobalLimit(t *testing.T) {
+	const workers = 2
+	d := New(workers, 100)
+	var cur, peak atomic.Int64
+	release := make(chan struct{})
+	for i := 0; i < 6; i++ {
+		d.Submit("chat"+strconv.Itoa(i), "m", func() {
+			n := cur.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			<-release
+			cur.Add(-1)
+		})
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for d.Stats().Running < workers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // beri kesempatan job lain melanggar batas
+	if r := d.Stats().Running; r != workers {
+		t.Fatalf("running = %d, mau %d", r, workers)
+	}
+	close(release)
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p != workers {
+		t.Fatalf("puncak paralel = %d, mau %d", p, workers)
+	}
+}
+
+func TestOverflowDrops(t *testing.T) {
+	d := New(1, 2)
+	var dropped []string
+	d.OnDrop = func(chat, id string) { dropped = append(dropped, chat+"/"+id) }
+
+	g := newGate()
+	d.Submit("a", "1", g.run)
+	waitStarted(t, g) // job 1 berjalan, tidak lagi dihitung antrean
+	if !d.Submit("a", "2", func() {}) || !d.Submit("b", "3", func() {}) {
+		t.Fatal("antrean belum penuh tetapi ditolak")
+	}
+	if d.Submit("c", "4", func() {}) {
+		t.Fatal("antrean penuh tetapi diterima")
+	}
+	if len(dropped) != 1 || dropped[0] != "c/4" {
+		t.Fatalf("OnDrop = %v", dropped)
+	}
+	if s := d.Stats(); s.Dropped != 1 || s.Queued != 2 {
+		t.Fatalf("stats = %+v", s)
+	}
+	close(g.release)
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCancelQueued(t *testing.T) {
+	d := New(1, 10)
+	g := newGate()
+	d.Submit("a", "1", g.run)
+	waitStarted(t, g)
+
+	var ran atomic.Bool
+	d.Submit("a", "2", func() { ran.Store(true) })
+	if !d.Cancel("a", "2") {
+		t.Fatal("Cancel job antre = false")
+	}
+	if d.Cancel("a", "1") {
+		t.Fatal("Cancel job yang sedang berjalan = true")
+	}
+	if d.Cancel("a", "x") {
+		t.Fatal("Cancel job tak dikenal = true")
+	}
+	close(g.release)
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ran.Load() {
+		t.Fatal("job yang dibatalkan tetap jalan")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	d := New(1, 10)
+	g := newGate()
+	d.Submit("a", "1", g.run)
+	waitStarted(t, g)
+
+	var ran atomic.Int64
+	d.Submit("a", "2", func() { ran.Add(1) }) // antre di chat yang sama
+	d.Submit("b", "3", func() { ran.Add(1) }) // menunggu slot global
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := d.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, mau DeadlineExceeded", err)
+	}
+	if r := d.Stats().Running; r != 1 {
+		t.Fatalf("running setelah batas waktu = %d, mau 1", r)
+	}
+	if d.Submit("c", "4", func() { ran.Add(1) }) {
+		t.Fatal("Submit diterima setelah Shutdown")
+	}
+
+	close(g.release)
+	deadline := time.Now().Add(2 * time.Second)
+	for d.Stats().Running > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := ran.Load(); n != 0 {
+		t.Fatalf("%d job yang belum mulai tetap jalan setelah batas waktu", n)
+	}
+}
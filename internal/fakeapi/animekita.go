@@ -0,0 +1,67 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// AnimeKitaServer meniru endpoint *.php AnimeKita dengan data kecil tetap.
+type AnimeKitaServer struct {
+	*Server
+}
+
+func NewAnimeKita() *AnimeKitaServer {
+	a := &AnimeKitaServer{}
+	entry := map[string]any{
+		"id": 1, "url": "frieren-sousou-no-frieren", "judul": "Frieren",
+		"cover": "", "lastch": "Episode 28", "lastup": "1 jam lalu",
+	}
+	mux := http.NewServeMux()
+	// baruupload membungkus list dalam "value", movie berupa array polos —
+	// dua bentuk yang sama-sama muncul di API aslinya.
+	mux.HandleFunc("/baruupload.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"value": []any{entry}})
+	})
+	mux.HandleFunc("/movie.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, []any{entry})
+	})
+	mux.HandleFunc("/genreseries.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, []any{entry})
+	})
+	mux.HandleFunc("/jadwal.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": []any{map[string]any{
+			"day": "Jumat",
+			"animeList": []any{map[string]any{
+				"id": "1", "anime_name": "Frieren", "link": "frieren-sousou-no-frieren", "cover": "",
+			}},
+		}}})
+	})
+	mux.HandleFunc("/anime-list.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"F": []any{map[string]any{
+			"id": "1", "judul": "Frieren", "url": "frieren-sousou-no-frieren", "cover": "",
+		}}})
+	})
+	mux.HandleFunc("/search.php", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": []any{map[string]any{
+			"jumlah": 1, "result": []any{entry},
+		}}})
+	})
+	mux.HandleFunc("/series.php", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": []any{map[string]any{
+			"id": 1, "series_id": "1", "judul": "Frieren", "type": "TV", "status": "Completed",
+			"rating": "9.1", "published": "2023", "author": "Madhouse",
+			"genre": []string{"adventure", "fantasy"}, "sinopsis": "Penyihir elf melanjutkan perjalanan.",
+			"chapter": []any{map[string]any{
+				"id": 28, "ch": "Episode 28", "url": "frieren-episode-28", "date": "2024-03-22",
+			}},
+		}}})
+	})
+	mux.HandleFunc("/chapter.php", func(w http.ResponseWriter, r *http.Request) {
+		reso := r.URL.Query().Get("reso")
+		writeJSON(w, http.StatusOK, map[string]any{"data": []any{map[string]any{
+			"episode_id": 28, "reso": []string{"480p", "720p"},
+			"stream": []any{map[string]any{"reso": reso, "link": a.URL + "/stream/" + reso + ".mp4", "provide": 1}},
+		}}})
+	})
+	a.Server = newServer(mux)
+	return a
+}
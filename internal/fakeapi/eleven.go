@@ -0,0 +1,30 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ElevenServer meniru POST /v1/text-to-speech/{voice} dan GET /v1/voices/{voice}.
+type ElevenServer struct {
+	*Server
+}
+
+func NewElevenLabs() *ElevenServer {
+	e := &ElevenServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/text-to-speech/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("xi-api-key") == "" {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"detail": map[string]any{"status": "invalid_api_key"}})
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(Audio)
+	})
+	mux.HandleFunc("/v1/voices/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/voices/")
+		writeJSON(w, http.StatusOK, map[string]any{"voice_id": id, "name": "Elaina (palsu)"})
+	})
+	e.Server = newServer(mux)
+	return e
+}
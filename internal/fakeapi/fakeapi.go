@@ -0,0 +1,128 @@
+// Package fakeapi berisi server httptest yang meniru bentuk respons API
+// eksternal (Gemini, TikWM, AnimeKita, ElevenLabs) supaya bot bisa dijalankan
+// end-to-end tanpa internet. Arahkan *_BASE_URL di config ke URL server ini.
+package fakeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Fault adalah respons paksa yang dikembalikan sebelum handler normal.
+type Fault struct {
+	Status int
+	Body   string
+}
+
+// Request mencatat satu request yang diterima server palsu.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// Server membungkus httptest.Server dengan antrean fault & log request.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	faults []Fault
+	reqs   []Request
+}
+
+func newServer(h http.Handler) *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := readBody(r)
+		s.mu.Lock()
+		s.reqs = append(s.reqs, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+		var f *Fault
+		if len(s.faults) > 0 {
+			f = &s.faults[0]
+			s.faults = s.faults[1:]
+		}
+		s.mu.Unlock()
+
+		if f != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.Status)
+			_, _ = w.Write([]byte(f.Body))
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// Fail mengantrekan n respons dengan status & body tertentu.
+func (s *Server) Fail(n, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.faults = append(s.faults, Fault{Status: status, Body: body})
+	}
+}
+
+// RateLimit mengantrekan n respons 429 (mis. kuota key habis).
+func (s *Server) RateLimit(n int) {
+	s.Fail(n, http.StatusTooManyRequests, `{"error":{"code":429,"message":"Resource has been exhausted (e.g. check quota).","status":"RESOURCE_EXHAUSTED"}}`)
+}
+
+// Malformed mengantrekan n respons 200 dengan JSON rusak.
+func (s *Server) Malformed(n int) {
+	s.Fail(n, http.StatusOK, `{"candidates":[{"content":{"parts":[{"text":`)
+}
+
+// Requests mengembalikan salinan semua request yang diterima.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.reqs...)
+}
+
+// Hits menghitung request yang path-nya sama persis.
+func (s *Server) Hits(path string) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+// Suite menjalankan semua server palsu sekaligus.
+type Suite struct {
+	Gemini     *GeminiServer
+	TikWM      *TikWMServer
+	AnimeKita  *AnimeKitaServer
+	ElevenLabs *ElevenServer
+}
+
+func NewSuite() *Suite {
+	return &Suite{
+		Gemini:     NewGemini(),
+		TikWM:      NewTikWM(),
+		AnimeKita:  NewAnimeKita(),
+		ElevenLabs: NewElevenLabs(),
+	}
+}
+
+// Env mengembalikan variabel lingkungan yang mengarahkan bot ke server palsu.
+func (s *Suite) Env() map[string]string {
+	return map[string]string{
+		"GEMINI_BASE_URL":     s.Gemini.URL,
+		"TIKWM_BASE_URL":      s.TikWM.URL,
+		"ANIMEKITA_BASE_URL":  s.AnimeKita.URL,
+		"ELEVENLABS_BASE_URL": s.ElevenLabs.URL,
+	}
+}
+
+func (s *Suite) Close() {
+	s.Gemini.Close()
+	s.TikWM.Close()
+	s.AnimeKita.Close()
+	s.ElevenLabs.Close()
+}
@@ -0,0 +1,67 @@
+package fakeapi
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// GeminiServer meniru POST /models/{model}:generateContent.
+// Model yang namanya mengandung "image" membalas gambar inline; lainnya teks.
+type GeminiServer struct {
+	*Server
+
+	mu    sync.Mutex
+	reply string
+}
+
+func NewGemini() *GeminiServer {
+	g := &GeminiServer{reply: "Halo, aku Elaina (palsu)."}
+	g.Server = newServer(http.HandlerFunc(g.serve))
+	return g
+}
+
+// SetReply mengganti teks balasan model teks.
+func (g *GeminiServer) SetReply(text string) {
+	g.mu.Lock()
+	g.reply = text
+	g.mu.Unlock()
+}
+
+func (g *GeminiServer) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/models/")
+	model, ok := strings.CutSuffix(path, ":generateContent")
+	if r.Method != http.MethodPost || !ok || model == "" {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"error": map[string]any{"code": 404, "message": "model not found", "status": "NOT_FOUND"},
+		})
+		return
+	}
+	if r.URL.Query().Get("key") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"error": map[string]any{"code": 400, "message": "API key not valid", "status": "INVALID_ARGUMENT"},
+		})
+		return
+	}
+
+	var part map[string]any
+	if strings.Contains(model, "image") {
+		inline := map[string]any{"mimeType": "image/png", "data": base64.StdEncoding.EncodeToString(PNG)}
+		// imggen membaca camelCase, hijabin membaca snake_case.
+		part = map[string]any{
+			"inlineData":  inline,
+			"inline_data": map[string]any{"mime_type": "image/png", "data": inline["data"]},
+		}
+	} else {
+		g.mu.Lock()
+		part = map[string]any{"text": g.reply}
+		g.mu.Unlock()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"candidates": []any{map[string]any{
+			"content":      map[string]any{"role": "model", "parts": []any{part}},
+			"finishReason": "STOP",
+		}},
+	})
+}
@@ -0,0 +1,73 @@
+package fakeapi
+
+import (
+	"net/http"
+	"sync"
+)
+
+// TikWMServer meniru GET /api/?url=... dan menyajikan media di /media/.
+type TikWMServer struct {
+	*Server
+
+	mu     sync.Mutex
+	slides int
+}
+
+// Video & Audio adalah isi media palsu yang disajikan server.
+var (
+	Video = []byte("\x00\x00\x00\x18ftypmp42fake-video")
+	Audio = []byte("ID3fake-audio")
+)
+
+func NewTikWM() *TikWMServer {
+	t := &TikWMServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/", t.serveAPI)
+	mux.HandleFunc("/media/video.mp4", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "video/mp4")
+		_, _ = w.Write(Video)
+	})
+	mux.HandleFunc("/media/audio.mp3", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(Audio)
+	})
+	mux.HandleFunc("/media/slide.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(PNG)
+	})
+	t.Server = newServer(mux)
+	return t
+}
+
+// SetSlides: n>0 membuat respons berikutnya berupa TikTok slide (n gambar).
+func (t *TikWMServer) SetSlides(n int) {
+	t.mu.Lock()
+	t.slides = n
+	t.mu.Unlock()
+}
+
+func (t *TikWMServer) serveAPI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("url") == "" {
+		writeJSON(w, http.StatusOK, map[string]any{"code": -1, "msg": "Url parsing is failed! Please check url."})
+		return
+	}
+	t.mu.Lock()
+	n := t.slides
+	t.mu.Unlock()
+
+	data := map[string]any{
+		"id":    "7300000000000000000",
+		"title": "video palsu",
+		"music": t.URL + "/media/audio.mp3",
+	}
+	if n > 0 {
+		imgs := make([]string, n)
+		for i := range imgs {
+			imgs[i] = t.URL + "/media/slide.png"
+		}
+		data["images"] = imgs
+	} else {
+		data["play"] = t.URL + "/media/video.mp4"
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"code": 0, "msg": "success", "data": data})
+}
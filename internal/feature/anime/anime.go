@@ -9,18 +9,19 @@ import (
 	"net/url"
 	"os"
 	"path"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
-	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/animekita"
+	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/trigger"
 	"wa-elaina/internal/wa"
 )
 
@@ -34,14 +35,14 @@ const (
 
 type Handler struct {
 	api         *animekita.Client
-	reTrigger   *regexp.Regexp
+	trig        *trigger.Matcher
 	genreAllow  map[string]struct{}
 	genreString string
 	sender      *wa.Sender
 	httpc       *http.Client
 }
 
-func New(reTrigger *regexp.Regexp, sender *wa.Sender) *Handler {
+func New(cfg config.Config, trig *trigger.Matcher, sender *wa.Sender) *Handler {
 	genres := []string{
 		"action", "adventure", "comedy", "demons", "drama", "ecchi", "fantasy", "game",
 		"harem", "historical", "horror", "josei", "magic", "martial-arts", "mecha", "military",
@@ -55,8 +56,8 @@ func New(reTrigger *regexp.Regexp, sender *wa.Sender) *Handler {
 	}
 
 	return &Handler{
-		api:         animekita.New(nil),
-		reTrigger:   reTrigger,
+		api:         animekita.NewWithBaseURL(nil, cfg.AnimeKitaBaseURL),
+		trig:        trig,
 		genreAllow:  set,
 		genreString: strings.Join(genres, ", "),
 		sender:      sender,
@@ -65,14 +66,15 @@ func New(reTrigger *regexp.Regexp, sender *wa.Sender) *Handler {
 }
 
 // TryHandle inspects incoming text for anime command variations.
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string) bool {
-	args, ok := h.extractCommand(text)
+func (h *Handler) TryHandle(client wa.Client, m *events.Message, text string) bool {
+	args, ok := h.extractCommand(m.Info.Chat.String(), text)
 	if !ok {
 		return false
 	}
+	lang := i18n.For(m.Info.Chat.String())
 
 	if len(args) == 0 {
-		h.replyText(context.Background(), client, m, h.helpMessage())
+		h.replyText(context.Background(), client, m, h.helpMessage(lang))
 		return true
 	}
 
@@ -81,7 +83,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 
 	switch sub {
 	case "help", "menu":
-		h.replyText(context.Background(), client, m, h.helpMessage())
+		h.replyText(context.Background(), client, m, h.helpMessage(lang))
 		return true
 
 	case "new", "baru", "latest":
@@ -90,10 +92,10 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		data, err := h.api.NewUploads(ctx, page)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil data rilis terbaru: %v", err))
+			h.replyText(ctx, client, m, lang.T("anime.new_failed", err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatSimpleList(fmt.Sprintf("Rilisan terbaru (page %d)", page), data))
+		h.replyText(ctx, client, m, formatSimpleList(lang, lang.T("anime.new_title", page), data))
 		return true
 
 	case "movie", "movies":
@@ -101,10 +103,10 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		data, err := h.api.Movies(ctx)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil daftar movie: %v", err))
+			h.replyText(ctx, client, m, lang.T("anime.movie_failed", err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatSimpleList("Daftar movie", data))
+		h.replyText(ctx, client, m, formatSimpleList(lang, lang.T("anime.movie_title"), data))
 		return true
 
 	case "schedule", "jadwal":
@@ -116,15 +118,15 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		data, err := h.api.Schedule(ctx)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil jadwal: %v", err))
+			h.replyText(ctx, client, m, lang.T("anime.schedule_failed", err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatSchedule(day, data))
+		h.replyText(ctx, client, m, formatSchedule(lang, day, data))
 		return true
 
 	case "list":
 		if len(rest) == 0 {
-			h.replyText(context.Background(), client, m, "Format: anime list <huruf>. Contoh: anime list a")
+			h.replyText(context.Background(), client, m, lang.T("anime.list_usage"))
 			return true
 		}
 		letter := strings.ToUpper(rest[0])
@@ -132,20 +134,20 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		data, err := h.api.AnimeList(ctx)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil daftar lengkap: %v", err))
+			h.replyText(ctx, client, m, lang.T("anime.list_failed", err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatAlphabetList(letter, data))
+		h.replyText(ctx, client, m, formatAlphabetList(lang, letter, data))
 		return true
 
 	case "genre":
 		if len(rest) == 0 {
-			h.replyText(context.Background(), client, m, "Format: anime genre <nama> [page].\nGenre tersedia: "+h.genreString)
+			h.replyText(context.Background(), client, m, lang.T("anime.genre_usage", h.genreString))
 			return true
 		}
 		genre := strings.ToLower(rest[0])
 		if _, ok := h.genreAllow[genre]; !ok {
-			h.replyText(context.Background(), client, m, "Genre tidak dikenal. Pilih salah satu dari:\n"+h.genreString)
+			h.replyText(context.Background(), client, m, lang.T("anime.genre_unknown", h.genreString))
 			return true
 		}
 		page := 1
@@ -156,16 +158,16 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		data, err := h.api.Genre(ctx, genre, page)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil daftar genre %s: %v", genre, err))
+			h.replyText(ctx, client, m, lang.T("anime.genre_failed", genre, err))
 			return true
 		}
-		title := fmt.Sprintf("Genre %s (page %d)", genre, page)
-		h.replyText(ctx, client, m, formatSimpleList(title, data))
+		title := lang.T("anime.genre_title", genre, page)
+		h.replyText(ctx, client, m, formatSimpleList(lang, title, data))
 		return true
 
 	case "search", "cari", "find":
 		if len(rest) == 0 {
-			h.replyText(context.Background(), client, m, "Format: anime search <kata kunci>")
+			h.replyText(context.Background(), client, m, lang.T("anime.search_usage"))
 			return true
 		}
 		query := strings.Join(rest, " ")
@@ -173,15 +175,15 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		results, err := h.api.Search(ctx, query)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal mencari \"%s\": %v", query, err))
+			h.replyText(ctx, client, m, lang.T("anime.search_failed", query, err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatSearchResults(query, results))
+		h.replyText(ctx, client, m, formatSearchResults(lang, query, results))
 		return true
 
 	case "detail":
 		if len(rest) == 0 {
-			h.replyText(context.Background(), client, m, "Format: anime detail <slug>")
+			h.replyText(context.Background(), client, m, lang.T("anime.detail_usage"))
 			return true
 		}
 		slug := rest[0]
@@ -189,15 +191,15 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		defer cancel()
 		detail, err := h.api.Detail(ctx, slug)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil detail untuk %s: %v", slug, err))
+			h.replyText(ctx, client, m, lang.T("anime.detail_failed", slug, err))
 			return true
 		}
-		h.replyText(ctx, client, m, formatDetail(detail))
+		h.replyText(ctx, client, m, formatDetail(lang, detail))
 		return true
 
 	case "episode", "stream":
 		if len(rest) == 0 {
-			h.replyText(context.Background(), client, m, "Format: anime episode <slug> [reso]. Gunakan slug dari daftar chapter detail.")
+			h.replyText(context.Background(), client, m, lang.T("anime.episode_usage"))
 			return true
 		}
 		slug := rest[0]
@@ -205,30 +207,32 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		if len(rest) > 1 {
 			reso = rest[1]
 		}
+		// Placeholder diedit jadi hasil/error, bukan pesan terpisah
+		ph, _ := wa.NewSender(client).PlaceholderReply(m, lang.T("anime.episode_loading", slug, reso))
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
 		episode, err := h.api.Episode(ctx, slug, reso)
 		if err != nil {
-			h.replyText(ctx, client, m, fmt.Sprintf("Gagal ambil stream untuk %s (%s): %v", slug, reso, err))
+			_ = ph.Edit(lang.T("anime.episode_failed", slug, reso, err))
 			return true
 		}
 		note := ""
 		if h.sender != nil && h.hasPixeldrain(episode.Streams) {
-			note = fmt.Sprintf("\n%s akan mencoba unduh tautan Pixeldrain otomatis.", botName())
+			note = lang.T("anime.pixeldrain_note", botName())
 		}
-		h.replyText(ctx, client, m, formatEpisode(slug, episode, note))
+		_ = ph.Edit(formatEpisode(lang, slug, episode, note))
 
 		if h.sender != nil && h.httpc != nil {
-			go h.deliverPixeldrain(m.Info.Chat, episode.Streams)
+			h.deliverPixeldrain(m.Info.Chat, episode.Streams)
 		}
 		return true
 	}
 
-	h.replyText(context.Background(), client, m, h.helpMessage())
+	h.replyText(context.Background(), client, m, h.helpMessage(lang))
 	return true
 }
 
-func (h *Handler) extractCommand(text string) ([]string, bool) {
+func (h *Handler) extractCommand(chat, text string) ([]string, bool) {
 	t := strings.TrimSpace(text)
 	if t == "" {
 		return nil, false
@@ -245,10 +249,10 @@ func (h *Handler) extractCommand(text string) ([]string, bool) {
 		return nil, false
 	}
 
-	if !h.reTrigger.MatchString(t) {
+	if !h.trig.Match(chat, t) {
 		return nil, false
 	}
-	clean := strings.TrimSpace(h.reTrigger.ReplaceAllString(t, ""))
+	clean := h.trig.Strip(chat, t)
 	if clean == "" {
 		return nil, false
 	}
@@ -273,7 +277,7 @@ func (h *Handler) parsePage(args []string) int {
 	return p
 }
 
-func (h *Handler) replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func (h *Handler) replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -288,25 +292,25 @@ func (h *Handler) replyText(ctx context.Context, client *whatsmeow.Client, m *ev
 	})
 }
 
-func (h *Handler) helpMessage() string {
+func (h *Handler) helpMessage(l i18n.Lang) string {
 	return strings.Join([]string{
-		"*Menu AnimeKita*",
-		"anime new [page]       - rilisan baru",
-		"anime movie            - daftar movie",
-		"anime schedule [hari]  - jadwal rilis (contoh: anime schedule selasa)",
-		"anime list <huruf>     - daftar anime per awal huruf",
-		"anime genre <nama> [page] - daftar berdasarkan genre",
-		"anime search <keyword> - cari anime",
-		"anime detail <slug>    - info detail + daftar chapter",
-		"anime episode <slug> [reso] - link streaming per episode",
+		l.T("anime.menu_title"),
+		l.T("anime.menu_new"),
+		l.T("anime.menu_movie"),
+		l.T("anime.menu_schedule"),
+		l.T("anime.menu_list"),
+		l.T("anime.menu_genre"),
+		l.T("anime.menu_search"),
+		l.T("anime.menu_detail"),
+		l.T("anime.menu_episode"),
 		"",
-		"Gunakan slug/link dari hasil detail untuk mengambil episode.",
+		l.T("anime.menu_footer"),
 	}, "\n")
 }
 
-func formatSimpleList(title string, entries []animekita.SimpleEntry) string {
+func formatSimpleList(l i18n.Lang, title string, entries []animekita.SimpleEntry) string {
 	if len(entries) == 0 {
-		return fmt.Sprintf("%s kosong.", title)
+		return l.T("anime.empty", title)
 	}
 	max := len(entries)
 	if max > maxSimpleItems {
@@ -318,7 +322,7 @@ func formatSimpleList(title string, entries []animekita.SimpleEntry) string {
 	sb.WriteString("*\n")
 	for i := 0; i < max; i++ {
 		e := entries[i]
-		name := fallback(e.Title, e.AnimeKey, "Tanpa judul")
+		name := fallback(e.Title, e.AnimeKey, l.T("anime.untitled"))
 		slug := fallback(e.URL, e.Link)
 		update := fallback(e.LastUp, e.AirDate, e.Episode)
 		fmt.Fprintf(&sb, "%d. %s\n", i+1, name)
@@ -339,19 +343,19 @@ func formatSimpleList(title string, entries []animekita.SimpleEntry) string {
 		}
 	}
 	if len(entries) > max {
-		fmt.Fprintf(&sb, "... %d entri lainnya.\n", len(entries)-max)
+		sb.WriteString(l.T("anime.more_entries", len(entries)-max) + "\n")
 	}
-	sb.WriteString("\nDetail: anime detail <slug>")
+	sb.WriteString("\n" + l.T("anime.hint_detail"))
 	return sb.String()
 }
 
-func formatSchedule(day string, days []animekita.ScheduleDay) string {
+func formatSchedule(l i18n.Lang, day string, days []animekita.ScheduleDay) string {
 	if len(days) == 0 {
-		return "Jadwal kosong."
+		return l.T("anime.schedule_empty")
 	}
 	wantDay := strings.TrimSpace(strings.ToLower(day))
 	var sb strings.Builder
-	sb.WriteString("*Jadwal Rilis*\n")
+	sb.WriteString(l.T("anime.schedule_title") + "\n")
 	var found bool
 	for _, d := range days {
 		if wantDay != "" && !strings.EqualFold(wantDay, d.Day) {
@@ -366,67 +370,67 @@ func formatSchedule(day string, days []animekita.ScheduleDay) string {
 		}
 		for i := 0; i < limit; i++ {
 			item := d.List[i]
-			name := fallback(item.Name, "Tanpa judul")
+			name := fallback(item.Name, l.T("anime.untitled"))
 			fmt.Fprintf(&sb, "- %s (slug: %s)\n", name, item.Link)
 		}
 		if len(d.List) > limit {
-			fmt.Fprintf(&sb, "  ... %d lainnya\n", len(d.List)-limit)
+			sb.WriteString(l.T("anime.more_short", len(d.List)-limit) + "\n")
 		}
 		sb.WriteString("\n")
 	}
 	if !found {
-		return fmt.Sprintf("Tidak ada jadwal untuk \"%s\".", day)
+		return l.T("anime.schedule_none", day)
 	}
-	sb.WriteString("Ambil info lengkap: anime detail <slug>")
+	sb.WriteString(l.T("anime.hint_info"))
 	return strings.TrimSpace(sb.String())
 }
 
-func formatAlphabetList(letter string, data animekita.AlphabeticalList) string {
+func formatAlphabetList(l i18n.Lang, letter string, data animekita.AlphabeticalList) string {
 	entries := data[letter]
 	if len(entries) == 0 {
 		if letter == "#" {
-			return "Tidak ada daftar untuk simbol #."
+			return l.T("anime.letter_symbol")
 		}
-		return fmt.Sprintf("Tidak ada anime yang diawali huruf %s.", letter)
+		return l.T("anime.letter_none", letter)
 	}
 	max := len(entries)
 	if max > maxSimpleItems {
 		max = maxSimpleItems
 	}
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "*Daftar huruf %s*\n", letter)
+	sb.WriteString(l.T("anime.letter_title", letter) + "\n")
 	for i := 0; i < max; i++ {
 		e := entries[i]
-		name := fallback(e.Title, "Tanpa judul")
+		name := fallback(e.Title, l.T("anime.untitled"))
 		fmt.Fprintf(&sb, "%d. %s\n", i+1, name)
 		sb.WriteString("   slug: ")
 		sb.WriteString(e.URL)
 		sb.WriteString("\n")
 	}
 	if len(entries) > max {
-		fmt.Fprintf(&sb, "... %d entri lainnya.\n", len(entries)-max)
+		sb.WriteString(l.T("anime.more_entries", len(entries)-max) + "\n")
 	}
-	sb.WriteString("Gunakan anime detail <slug> untuk info.")
+	sb.WriteString(l.T("anime.hint_letter"))
 	return sb.String()
 }
 
-func formatSearchResults(query string, results []animekita.SearchResult) string {
+func formatSearchResults(l i18n.Lang, query string, results []animekita.SearchResult) string {
 	var all []animekita.SearchEntry
 	for _, block := range results {
 		all = append(all, block.Items...)
 	}
 	if len(all) == 0 {
-		return fmt.Sprintf("Tidak ada hasil untuk \"%s\".", query)
+		return l.T("anime.search_none", query)
 	}
 	max := len(all)
 	if max > maxSimpleItems {
 		max = maxSimpleItems
 	}
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "*Hasil pencarian \"%s\"*\n", query)
+	sb.WriteString(l.T("anime.search_title", query) + "\n")
 	for i := 0; i < max; i++ {
 		e := all[i]
-		name := fallback(e.Title, "Tanpa judul")
+		name := fallback(e.Title, l.T("anime.untitled"))
 		fmt.Fprintf(&sb, "%d. %s\n", i+1, name)
 		if e.URL != "" {
 			sb.WriteString("   slug: ")
@@ -435,18 +439,18 @@ func formatSearchResults(query string, results []animekita.SearchResult) string
 		}
 	}
 	if len(all) > max {
-		fmt.Fprintf(&sb, "... %d hasil lainnya.\n", len(all)-max)
+		sb.WriteString(l.T("anime.more_results", len(all)-max) + "\n")
 	}
-	sb.WriteString("Detail: anime detail <slug>")
+	sb.WriteString(l.T("anime.hint_detail"))
 	return sb.String()
 }
 
-func formatDetail(d *animekita.Detail) string {
+func formatDetail(l i18n.Lang, d *animekita.Detail) string {
 	if d == nil {
-		return "Data detail kosong."
+		return l.T("anime.detail_empty")
 	}
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "*%s*\n", fallback(d.Title, "Tanpa judul"))
+	fmt.Fprintf(&sb, "*%s*\n", fallback(d.Title, l.T("anime.untitled")))
 	if d.Type != "" || d.Status != "" {
 		fmt.Fprintf(&sb, "%s | %s\n", fallback(d.Type, "?"), fallback(d.Status, "?"))
 	}
@@ -454,7 +458,7 @@ func formatDetail(d *animekita.Detail) string {
 		fmt.Fprintf(&sb, "Rating: %s\n", d.Rating)
 	}
 	if d.Published != "" {
-		fmt.Fprintf(&sb, "Rilis: %s\n", d.Published)
+		sb.WriteString(l.T("anime.detail_released", d.Published) + "\n")
 	}
 	if d.Author != "" {
 		fmt.Fprintf(&sb, "Studio/Author: %s\n", d.Author)
@@ -470,13 +474,13 @@ func formatDetail(d *animekita.Detail) string {
 		sb.WriteString("\n")
 	}
 	if strings.TrimSpace(d.Synopsis) != "" {
-		sb.WriteString("\nSinopsis:\n")
+		sb.WriteString("\n" + l.T("anime.detail_synopsis") + "\n")
 		sb.WriteString(strings.TrimSpace(d.Synopsis))
 		sb.WriteString("\n")
 	}
-	sb.WriteString("\nChapter terbaru:\n")
+	sb.WriteString("\n" + l.T("anime.detail_chapters") + "\n")
 	if len(d.Chapters) == 0 {
-		sb.WriteString("- Belum ada data.\n")
+		sb.WriteString(l.T("anime.no_data") + "\n")
 	} else {
 		limit := len(d.Chapters)
 		if limit > maxChapterItems {
@@ -487,27 +491,27 @@ func formatDetail(d *animekita.Detail) string {
 			fmt.Fprintf(&sb, "- %s (%s) -> %s\n", fallback(ch.Name, "ep"), fallback(ch.Date, "?"), ch.URL)
 		}
 		if len(d.Chapters) > limit {
-			fmt.Fprintf(&sb, "... %d episode lainnya di API.\n", len(d.Chapters)-limit)
+			sb.WriteString(l.T("anime.more_episodes", len(d.Chapters)-limit) + "\n")
 		}
 	}
-	sb.WriteString("\nAmbil stream: anime episode <slug> [reso]")
+	sb.WriteString("\n" + l.T("anime.hint_stream"))
 	return sb.String()
 }
 
-func formatEpisode(slug string, ep *animekita.Episode, note string) string {
+func formatEpisode(l i18n.Lang, slug string, ep *animekita.Episode, note string) string {
 	if ep == nil {
-		return fmt.Sprintf("Tidak ada data untuk %s.", slug)
+		return l.T("anime.episode_none", slug)
 	}
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "*Episode %s*\n", slug)
 	if len(ep.Resolutions) > 0 {
-		sb.WriteString("Resolusi tersedia: ")
+		sb.WriteString(l.T("anime.episode_resos"))
 		sb.WriteString(strings.Join(ep.Resolutions, ", "))
 		sb.WriteString("\n")
 	}
-	sb.WriteString("Link streaming:\n")
+	sb.WriteString(l.T("anime.episode_links") + "\n")
 	if len(ep.Streams) == 0 {
-		sb.WriteString("- Belum ada tautan.\n")
+		sb.WriteString(l.T("anime.episode_no_links") + "\n")
 	} else {
 		limit := len(ep.Streams)
 		if limit > maxStreamItems {
@@ -518,7 +522,7 @@ func formatEpisode(slug string, ep *animekita.Episode, note string) string {
 			fmt.Fprintf(&sb, "- %s -> %s\n", fallback(s.Resolution, "?"), s.Link)
 		}
 		if len(ep.Streams) > limit {
-			fmt.Fprintf(&sb, "... %d link lainnya di API.\n", len(ep.Streams)-limit)
+			sb.WriteString(l.T("anime.more_links", len(ep.Streams)-limit) + "\n")
 		}
 	}
 	if strings.TrimSpace(note) != "" {
@@ -555,14 +559,39 @@ func (h *Handler) deliverPixeldrain(chat types.JID, streams []animekita.EpisodeS
 		return
 	}
 	dest := wa.DestJID(chat)
+	lang := i18n.For(chat.String())
+	var links []animekita.EpisodeStream
 	for _, st := range streams {
-		if !isPixeldrainLink(st.Link) {
-			continue
+		if isPixeldrainLink(st.Link) {
+			links = append(links, st)
+		}
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	// Satu pesan status yang diedit per langkah, diakhiri ringkasan
+	var ph *wa.Placeholder
+	sent := 0
+	var failed []string
+	for i, st := range links {
+		status := lang.T("anime.pixeldrain_step", fallback(st.Resolution, st.Link), i+1, len(links))
+		if ph == nil {
+			ph, _ = h.sender.Placeholder(dest, status)
+		} else {
+			_ = ph.Edit(status)
 		}
 		if err := h.fetchAndSendPixeldrain(dest, st); err != nil {
-			_ = h.sender.Text(dest, fmt.Sprintf("Gagal unduh Pixeldrain %s: %v", fallback(st.Resolution, st.Link), err))
+			failed = append(failed, lang.T("anime.pixeldrain_fail", fallback(st.Resolution, st.Link), err))
+			continue
 		}
+		sent++
+	}
+	summary := lang.T("anime.pixeldrain_done", sent, len(links))
+	if len(failed) > 0 {
+		summary += "\n" + strings.Join(failed, "\n")
 	}
+	_ = ph.Edit(summary)
 }
 
 func (h *Handler) fetchAndSendPixeldrain(chat types.JID, st animekita.EpisodeStream) error {
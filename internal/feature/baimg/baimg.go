@@ -19,6 +19,8 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
@@ -133,7 +135,7 @@ func sanitize(in []string) []string {
 	return res
 }
 
-func (h *Handler) TryHandleText(ctx context.Context, client *whatsmeow.Client, m *events.Message, text string, _ bool) bool {
+func (h *Handler) TryHandleText(ctx context.Context, client wa.Client, m *events.Message, text string, _ bool) bool {
 	low := strings.ToLower(strings.TrimSpace(text))
 
 	// 1) Perintah pendek
@@ -168,10 +170,10 @@ func (h *Handler) flattenAll() []string {
 	return res
 }
 
-func (h *Handler) sendRandom(ctx context.Context, client *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) sendRandom(ctx context.Context, client wa.Client, m *events.Message) bool {
 	urls := h.flattenAll()
 	if len(urls) == 0 {
-		replyText(ctx, client, m, "Index Blue Archive kosong / gagal dimuat.")
+		replyText(ctx, client, m, tr(m, "baimg.index_empty"))
 		return true
 	}
 	rand.Seed(time.Now().UnixNano())
@@ -186,14 +188,14 @@ func (h *Handler) sendRandom(ctx context.Context, client *whatsmeow.Client, m *e
 	return true
 }
 
-func (h *Handler) sendByName(ctx context.Context, client *whatsmeow.Client, m *events.Message, name string) bool {
+func (h *Handler) sendByName(ctx context.Context, client wa.Client, m *events.Message, name string) bool {
 	name = strings.ToLower(strings.TrimSpace(name))
 	if name == "" {
 		return false
 	}
 	urls := h.index[name]
 	if len(urls) == 0 {
-		replyText(ctx, client, m, "Maaf, karakter itu belum ada di index BA-ku.")
+		replyText(ctx, client, m, tr(m, "baimg.unknown"))
 		return true
 	}
 	max := 3
@@ -206,7 +208,12 @@ func (h *Handler) sendByName(ctx context.Context, client *whatsmeow.Client, m *e
 	return true
 }
 
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+// tr: teks katalog dalam bahasa chat m.
+func tr(m *events.Message, key string, args ...any) string {
+	return i18n.For(m.Info.Chat.String()).T(key, args...)
+}
+
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:       pbf.String(m.Info.ID),
 		QuotedMessage:  m.Message,
@@ -221,24 +228,24 @@ func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message,
 	})
 }
 
-func sendImageURLReply(ctx context.Context, client *whatsmeow.Client, m *events.Message, url, caption string) error {
+func sendImageURLReply(ctx context.Context, client wa.Client, m *events.Message, url, caption string) error {
 	// download
 	httpc := &http.Client{Timeout: 25 * time.Second}
 	resp, err := httpc.Get(url)
 	if err != nil {
-		replyText(ctx, client, m, "Gagal mengunduh gambar BA.")
+		replyText(ctx, client, m, tr(m, "baimg.download_failed"))
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		replyText(ctx, client, m, "Gagal mengunduh gambar BA: "+resp.Status)
+		replyText(ctx, client, m, tr(m, "baimg.download_status", resp.Status))
 		return errors.New("bad status")
 	}
 	b, _ := io.ReadAll(resp.Body)
 	// upload
 	up, err := client.Upload(ctx, b, whatsmeow.MediaImage)
 	if err != nil {
-		replyText(ctx, client, m, "Gagal mengunggah gambar BA.")
+		replyText(ctx, client, m, tr(m, "baimg.upload_failed"))
 		return err
 	}
 	ci := &waProto.ContextInfo{
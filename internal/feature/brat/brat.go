@@ -22,22 +22,26 @@ import (
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types/events"
 	pbf "google.golang.org/protobuf/proto"
+
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa"
 )
 
 var reBrat = regexp.MustCompile(`(?i)\b(brat)\b`)
 
 type Handler struct {
-	triggerRegex *regexp.Regexp
+	trig *trigger.Matcher
 }
 
-func New(triggerRegex *regexp.Regexp) *Handler {
+func New(trig *trigger.Matcher) *Handler {
 	return &Handler{
-		triggerRegex: triggerRegex,
+		trig: trig,
 	}
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string, isOwner bool) bool {
-	if !h.triggerRegex.MatchString(text) {
+func (h *Handler) TryHandle(client wa.Client, m *events.Message, text string, isOwner bool) bool {
+	reTrig := h.trig.Regexp(m.Info.Chat.String())
+	if !reTrig.MatchString(text) {
 		return false
 	}
 	
@@ -47,7 +51,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 
 	log.Printf("[BRAT] Processing: %s", text)
 
-	stickerText := h.extractStickerText(text)
+	stickerText := h.extractStickerText(reTrig, text)
 	if strings.TrimSpace(stickerText) == "" {
 		stickerText = "brat"
 	}
@@ -59,15 +63,15 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	return true
 }
 
-func (h *Handler) extractStickerText(text string) string {
-	cleaned := h.triggerRegex.ReplaceAllString(text, " ")
+func (h *Handler) extractStickerText(reTrig *regexp.Regexp, text string) string {
+	cleaned := reTrig.ReplaceAllString(text, " ")
 	cleaned = reBrat.ReplaceAllString(cleaned, " ")
 	cleaned = regexp.MustCompile(`\s+`).ReplaceAllString(strings.TrimSpace(cleaned), " ")
 	
 	// Jika hasil cleaning kosong, ambil text asli tanpa trigger word
 	if strings.TrimSpace(cleaned) == "" {
 		// Ambil text asli dan hapus hanya trigger word
-		original := h.triggerRegex.ReplaceAllString(text, "")
+		original := reTrig.ReplaceAllString(text, "")
 		original = reBrat.ReplaceAllString(original, "")
 		original = strings.TrimSpace(original)
 		if original != "" {
@@ -249,7 +253,7 @@ func (h *Handler) toWebP(ctx context.Context, input []byte) ([]byte, error) {
 }
 
 // Kirim sebagai sticker (diambil dari sticker.go)
-func (h *Handler) sendAsSticker(ctx context.Context, client *whatsmeow.Client, m *events.Message, text string) {
+func (h *Handler) sendAsSticker(ctx context.Context, client wa.Client, m *events.Message, text string) {
 	// Timeout untuk proses
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -0,0 +1,291 @@
+package feature
+
+import (
+	"context"
+	"sort"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/wa"
+)
+
+// Msg adalah konteks pesan bersama yang dibangun router sekali per pesan
+// lalu diteruskan ke setiap fitur.
+type Msg struct {
+	Client wa.Client
+	Event  *events.Message
+
+	// Ctx dibatalkan jika pesan ditarik (revoke) saat masih diproses; fitur
+	// lambat (mis. imggen) sebaiknya berhenti sebelum mengirim hasil.
+	Ctx context.Context
+
+	// Edited: pesan hasil edit yang dievaluasi ulang (Event berisi isi baru,
+	// Info.ID = ID pesan asli).
+	Edited bool
+
+	Chat      types.JID
+	Sender    types.JID
+	Text      string // teks asli (conversation/extended/caption)
+	SenderJID string // Sender.String(), kunci memory nama
+
+	IsOwner bool
+	IsGroup bool
+
+	// Role: role efektif pengirim (owner dari ENV selalu perm.Owner).
+	Role perm.Role
+
+	// Lang: bahasa balasan untuk chat ini (!lang), default Indonesia.
+	Lang i18n.Lang
+
+	// Perintah "!cmd args"
+	IsCmd bool
+	Cmd   string
+	Args  string
+
+	// Trigger (mis. "elaina") di teks
+	HasTrigger bool
+
+	// Pesan yang di-reply
+	QuotedImg  bool
+	QuotedAud  bool
+	QuotedText string
+
+	// TikTok: teks + matched text dari link preview
+	TikTokText    string
+	HasTrigTikTok bool
+	HasTikTokLink bool
+
+	// Addressed=false berarti pesan tidak ditujukan ke bot (reply/media di grup
+	// tanpa trigger atau perintah); fitur non-perintah sebaiknya diam.
+	Addressed bool
+
+	// Muted: bot tidak membalas pesan ini (pengirim banned atau chat di luar
+	// ACL); hanya fitur Observer yang tetap dijalankan.
+	Muted bool
+
+	// Disabled: cache toggle fitur per chat, diisi malas oleh guard router.
+	Disabled map[string]bool
+
+	// Outcome: hasil khusus untuk audit log (mis. OutcomeDenied), diisi guard
+	// atau handler; kosong = ditentukan dari hasil Dispatch.
+	Outcome string
+
+	// Rollback dipasang guard (mis. kuota) untuk membatalkan efeknya bila
+	// fitur tidak menangani pesan atau gagal (Outcome = OutcomeFailed).
+	Rollback func()
+}
+
+// Hasil perintah yang dicatat di audit log.
+const (
+	OutcomeOK        = "ok"
+	OutcomeUnhandled = "unhandled"
+	OutcomeDenied    = "denied"
+	OutcomeQuota     = "quota"
+	OutcomeFailed    = "failed" // fitur/tindakan moderasi gagal dijalankan
+)
+
+// T menerjemahkan kunci katalog ke bahasa chat.
+func (m *Msg) T(key string, args ...any) string { return m.Lang.T(key, args...) }
+
+// Can: pengirim punya role minimal need.
+func (m *Msg) Can(need perm.Role) bool { return m.Role >= need }
+
+// HasQuoted: ada pesan yang di-reply (gambar/audio/teks).
+func (m *Msg) HasQuoted() bool {
+	return m.QuotedImg || m.QuotedAud || m.QuotedText != ""
+}
+
+// Feature adalah satu kemampuan bot yang bisa didaftarkan ke Registry.
+//
+// Match adalah predikat murah (gating) yang menentukan apakah Handle boleh
+// dipanggil. Handle mengembalikan true jika pesan sudah ditangani sehingga
+// fitur berikutnya tidak dicoba lagi.
+type Feature interface {
+	Name() string
+	Priority() int
+	Match(m *Msg) bool
+	Handle(m *Msg) bool
+}
+
+// Helper opsional: baris bantuan yang ditampilkan oleh !help.
+type Helper interface {
+	Help() []string
+}
+
+// Toggleable opsional: fitur yang bisa dimatikan per chat (!fitur off <nama>).
+// ToggleName kosong berarti fitur inti yang selalu aktif.
+type Toggleable interface {
+	ToggleName() string
+}
+
+// Restricted opsional: fitur yang butuh role minimal (mis. perm.Premium).
+type Restricted interface {
+	Requires() perm.Role
+}
+
+// Observer opsional: pengamat pasif (mis. moderasi grup) yang tetap
+// dijalankan untuk pesan Muted.
+type Observer interface {
+	Observes() bool
+}
+
+// Verdict adalah hasil Guard terhadap satu fitur yang Match.
+type Verdict int
+
+const (
+	Allow Verdict = iota // lanjut ke Handle
+	Skip                 // lewati fitur ini, coba fitur berikutnya
+	Stop                 // pesan dianggap selesai (guard sudah membalas)
+)
+
+// Guard dijalankan setelah Match dan sebelum Handle.
+type Guard func(m *Msg, f Feature) Verdict
+
+// Spec adalah Feature berbasis fungsi, cukup untuk membungkus handler lama.
+type Spec struct {
+	ID       string
+	Prio     int
+	Lines    []string
+	Core     bool      // tidak bisa dimatikan per chat
+	Toggle   string    // nama toggle; kosong = ID (mis. beberapa spec berbagi satu toggle)
+	Need     perm.Role // role minimal; nol (perm.User) = semua orang
+	Observe  bool      // pengamat pasif, lihat Observer
+	MatchFn  func(m *Msg) bool
+	HandleFn func(m *Msg) bool
+}
+
+func (s *Spec) ToggleName() string {
+	if s.Core {
+		return ""
+	}
+	if s.Toggle != "" {
+		return s.Toggle
+	}
+	return s.ID
+}
+
+func (s *Spec) Requires() perm.Role { return s.Need }
+
+func (s *Spec) Observes() bool { return s.Observe }
+
+func (s *Spec) Name() string       { return s.ID }
+func (s *Spec) Priority() int      { return s.Prio }
+func (s *Spec) Help() []string     { return s.Lines }
+func (s *Spec) Handle(m *Msg) bool { return s.HandleFn != nil && s.HandleFn(m) }
+
+func (s *Spec) Match(m *Msg) bool {
+	if s.MatchFn == nil {
+		return true
+	}
+	return s.MatchFn(m)
+}
+
+// Registry menyimpan fitur terurut berdasarkan prioritas (besar → kecil).
+// Fitur dengan prioritas sama dipertahankan sesuai urutan pendaftaran.
+type Registry struct {
+	list   []Feature
+	guards []Guard
+}
+
+func NewRegistry() *Registry { return &Registry{} }
+
+func (r *Registry) Register(fs ...Feature) {
+	for _, f := range fs {
+		if f != nil {
+			r.list = append(r.list, f)
+		}
+	}
+	sort.SliceStable(r.list, func(i, j int) bool {
+		return r.list[i].Priority() > r.list[j].Priority()
+	})
+}
+
+// Use menambahkan guard; guard dievaluasi sesuai urutan pemasangan.
+func (r *Registry) Use(g Guard) {
+	if g != nil {
+		r.guards = append(r.guards, g)
+	}
+}
+
+// Features mengembalikan salinan daftar fitur terurut.
+func (r *Registry) Features() []Feature {
+	out := make([]Feature, len(r.list))
+	copy(out, r.list)
+	return out
+}
+
+// Lookup mencari fitur berdasarkan nama.
+func (r *Registry) Lookup(name string) (Feature, bool) {
+	for _, f := range r.list {
+		if f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Dispatch mencoba fitur satu per satu dan mengembalikan nama fitur yang
+// menangani pesan (kosong jika tidak ada).
+func (r *Registry) Dispatch(m *Msg) (string, bool) {
+	for _, f := range r.list {
+		if !f.Match(m) {
+			continue
+		}
+		m.Rollback = nil
+		switch r.guard(m, f) {
+		case Skip:
+			continue
+		case Stop:
+			return f.Name(), true
+		}
+		handled := f.Handle(m)
+		if m.Rollback != nil && (!handled || m.Outcome == OutcomeFailed) {
+			m.Rollback()
+		}
+		m.Rollback = nil
+		if handled {
+			return f.Name(), true
+		}
+	}
+	return "", false
+}
+
+func (r *Registry) guard(m *Msg, f Feature) Verdict {
+	for _, g := range r.guards {
+		if v := g(m, f); v != Allow {
+			return v
+		}
+	}
+	return Allow
+}
+
+// Toggles mengembalikan nama toggle unik (urut prioritas) dari fitur yang bisa dimatikan.
+func (r *Registry) Toggles() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, f := range r.list {
+		t, ok := f.(Toggleable)
+		if !ok {
+			continue
+		}
+		if name := t.ToggleName(); name != "" && !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// HelpLines mengumpulkan baris bantuan semua fitur sesuai urutan prioritas.
+func (r *Registry) HelpLines() []string {
+	var out []string
+	for _, f := range r.list {
+		if h, ok := f.(Helper); ok {
+			out = append(out, h.Help()...)
+		}
+	}
+	return out
+}
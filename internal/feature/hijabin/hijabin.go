@@ -23,34 +23,24 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/llm"
 	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
-	apiURL   string
-	apiKey   string
-	re       *regexp.Regexp
-	httpc    *http.Client
-	gemKeys  []string
-	keyIndex int
-	debug    bool
+	apiURL  string
+	apiKey  string
+	re      *regexp.Regexp
+	httpc   *http.Client
+	gemKeys *llm.KeyPool // pool bersama (llm.ImageKeys)
+	debug   bool
 }
 
 func New(cfg config.Config, _ *wa.Sender) *Handler {
 	url := strings.TrimSpace(os.Getenv("HIJABIN_API_URL"))
 	key := strings.TrimSpace(os.Getenv("HIJABIN_API_KEY"))
 
-	// dukung banyak nama env kunci Gemini
-	var gk []string
-	for _, name := range []string{"GEMINI_IMG_KEYS", "GEMINI_KEYS", "GEMINI_API_KEY"} {
-		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
-			gk = appendKeys(gk, v)
-		}
-	}
-	if len(gk) == 0 && len(cfg.GeminiKeys) > 0 {
-		gk = cfg.GeminiKeys
-	}
-
 	dbg := strings.EqualFold(strings.TrimSpace(os.Getenv("HIJABIN_DEBUG")), "true")
 
 	return &Handler{
@@ -58,17 +48,18 @@ func New(cfg config.Config, _ *wa.Sender) *Handler {
 		apiKey:  key,
 		re:      regexp.MustCompile(`(?i)\b(hijab(in|kan)?|kerudung(i|kan)?|berhijabkan)\b`),
 		httpc:   &http.Client{Timeout: 120 * time.Second},
-		gemKeys: gk,
+		gemKeys: llm.ImageKeys(),
 		debug:   dbg,
 	}
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string, _ bool, _ *regexp.Regexp) bool {
-	if !h.re.MatchString(text) {
-		return false
-	}
+// Matches: ada kata kunci hijabin dan gambar (langsung/quoted).
+func (h *Handler) Matches(m *events.Message, text string) bool {
+	return h.re.MatchString(text) && sourceImage(m) != nil
+}
 
-	// ambil gambar utama atau quoted
+// sourceImage: ambil gambar utama atau quoted.
+func sourceImage(m *events.Message) *waProto.ImageMessage {
 	img := m.Message.GetImageMessage()
 	if img == nil {
 		if xt := m.Message.GetExtendedTextMessage(); xt != nil && xt.ContextInfo != nil {
@@ -77,55 +68,80 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 			}
 		}
 	}
-	if img == nil {
-		return false
+	return img
+}
+
+// TryHandle: ctx dibatalkan router jika pesan ditarik; hasil maupun pesan
+// error tidak dikirim. ok=true hanya jika gambar hasil terkirim (kuota
+// dikembalikan jika gagal).
+func (h *Handler) TryHandle(parent context.Context, client wa.Client, m *events.Message, text string, _ bool) (handled, ok bool) {
+	if !h.Matches(m, text) {
+		return false, false
 	}
+	img := sourceImage(m)
+	lang := i18n.For(m.Info.Chat.String())
 
 	// batasi tipe file seperti contoh Node (jpeg/png)
 	mt := img.GetMimetype()
 	if !regexp.MustCompile(`^image/(jpe?g|png)$`).MatchString(strings.ToLower(mt)) {
-		replyText(context.Background(), client, m, "Format tidak didukung. Kirim/reply **jpeg/jpg/png** ya ✨")
-		return true
+		replyText(context.Background(), client, m, lang.T("hijabin.bad_format"))
+		return true, ok
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 120*time.Second)
 	defer cancel()
 
+	pg := wa.StartProgress(parent, client, m.Info, wa.PresenceTyping)
+	defer func() { pg.Done(ok) }()
+	canceled := func() bool {
+		if parent.Err() == nil {
+			return false
+		}
+		log.Printf("[HIJABIN] dibatalkan chat=%s id=%s", m.Info.Chat.String(), m.Info.ID)
+		return true
+	}
+
 	blob, err := client.Download(ctx, img)
+	if canceled() {
+		return true, ok
+	}
 	if err != nil {
-		replyText(ctx, client, m, "Gagal mengunduh gambar 😔")
+		replyText(ctx, client, m, lang.T("hijabin.download_failed"))
 		if h.debug {
 			log.Printf("[HIJABIN] download error: %v", err)
 		}
-		return true
+		return true, ok
 	}
 
 	if h.debug {
 		log.Printf("[HIJABIN] start | apiURL=%q hasGemini=%t mimetype=%s size=%d",
-			h.apiURL, len(h.gemKeys) > 0, mt, len(blob))
+			h.apiURL, h.gemKeys.Len() > 0, mt, len(blob))
 	}
 
 	// proses
 	out, outMT, err := h.processHijab(ctx, blob, mt)
+	if canceled() {
+		return true, ok
+	}
 	if err != nil {
 		if errors.Is(err, errNotConfigured) {
-			replyText(ctx, client, m, "Fitur hijabin belum dikonfigurasi. Set **HIJABIN_API_URL** (dan KEY jika perlu) *atau* **GEMINI_API_KEY/GEMINI_KEYS** di `.env`.")
+			replyText(ctx, client, m, lang.T("hijabin.not_configured"))
 		} else {
-			replyText(ctx, client, m, "Gagal memproses hijabin. Coba lagi ya ✨")
+			replyText(ctx, client, m, lang.T("hijabin.failed"))
 		}
 		if h.debug {
 			log.Printf("[HIJABIN] process error: %v", err)
 		}
-		return true
+		return true, ok
 	}
 
 	up, err := client.Upload(ctx, out, whatsmeow.MediaImage)
 	if err != nil {
-		replyText(ctx, client, m, "Upload gambar hasil gagal.")
+		replyText(ctx, client, m, lang.T("hijabin.upload_failed"))
 		if h.debug {
 			log.Printf("[HIJABIN] upload error: %v", err)
 		}
-		return true
+		return true, ok
 	}
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
@@ -133,7 +149,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 		Participant:   pbf.String(m.Info.Sender.String()),
 		RemoteJID:     pbf.String(m.Info.Chat.String()),
 	}
-	_, _ = client.SendMessage(ctx, m.Info.Chat, &waProto.Message{
+	_, err = client.SendMessage(ctx, m.Info.Chat, &waProto.Message{
 		ImageMessage: &waProto.ImageMessage{
 			URL:           pbf.String(up.URL),
 			DirectPath:    pbf.String(up.DirectPath),
@@ -142,14 +158,15 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 			FileSHA256:    up.FileSHA256,
 			FileLength:    pbf.Uint64(uint64(len(out))),
 			Mimetype:      pbf.String(outMT),
-			Caption:       pbf.String("*Selamat, gambar sudah berhijab.*"),
+			Caption:       pbf.String(lang.T("hijabin.caption")),
 			ContextInfo:   ci,
 		},
 	})
+	ok = err == nil
 	if h.debug {
 		log.Printf("[HIJABIN] success | outMT=%s outSize=%d", outMT, len(out))
 	}
-	return true
+	return true, ok
 }
 
 var errNotConfigured = errors.New("hijab service not configured")
@@ -187,7 +204,7 @@ func (h *Handler) processHijab(ctx context.Context, img []byte, mimeType string)
 	}
 
 	// 2) Gemini image generation (meniru contoh Node.js)
-	if len(h.gemKeys) > 0 {
+	if h.gemKeys.Len() > 0 {
 		if h.debug {
 			log.Printf("[HIJABIN] trying Gemini image generation…")
 		}
@@ -345,19 +362,18 @@ func httpGetBytes(u string) ([]byte, string, error) {
 
 // ---------- Gemini image generation ----------
 func (h *Handler) callGemini(ctx context.Context, img []byte, mt string, prompt string) ([]byte, string, error) {
-	if len(h.gemKeys) == 0 {
-		return nil, "", errors.New("no gemini keys")
-	}
 	models := []string{
 		"gemini-2.0-flash-exp-image-generation",
 		"gemini-2.0-flash-preview-image-generation",
 	}
-	var lastErr error
-	for attempt := 0; attempt < len(h.gemKeys); attempt++ {
-		key := h.gemKeys[h.keyIndex]
+	var out []byte
+	var outMT string
+	// Tiap key mencoba semua model; kegagalan terakhir dinilai pool
+	// (kuota → cooldown key, auth → bench, lainnya → berhenti).
+	err := h.gemKeys.Do(ctx, func(key string) error {
+		var lastErr error
 		for _, model := range models {
-			ep := "https://generativelanguage.googleapis.com/v1beta/models/" + model + ":generateContent?key=" + key
-
+			ep := llm.GeminiEndpoint(model, key)
 			body := map[string]any{
 				"contents": []any{
 					map[string]any{
@@ -395,7 +411,7 @@ func (h *Handler) callGemini(ctx context.Context, img []byte, mt string, prompt
 				log.Printf("[HIJABIN] gemini status=%d bytes=%d", resp.StatusCode, len(rb))
 			}
 			if resp.StatusCode >= 300 {
-				lastErr = fmt.Errorf("gemini %d: %s", resp.StatusCode, strings.TrimSpace(string(rb)))
+				lastErr = llm.NewStatusError(resp, rb)
 				continue
 			}
 			var jr map[string]any
@@ -413,11 +429,11 @@ func (h *Handler) callGemini(ctx context.Context, img []byte, mt string, prompt
 											if dataStr, _ := inl["data"].(string); dataStr != "" {
 												dec, err := base64.StdEncoding.DecodeString(dataStr)
 												if err == nil {
-													outMT := mt
+													out, outMT = dec, mt
 													if mm, _ := inl["mime_type"].(string); mm != "" {
 														outMT = mm
 													}
-													return dec, outMT, nil
+													return nil
 												}
 											}
 										}
@@ -430,13 +446,12 @@ func (h *Handler) callGemini(ctx context.Context, img []byte, mt string, prompt
 			}
 			lastErr = errors.New("gemini: output tidak berisi inline_data")
 		}
-		// ganti key untuk percobaan berikutnya
-		h.keyIndex = (h.keyIndex + 1) % len(h.gemKeys)
-	}
-	if lastErr == nil {
-		lastErr = errors.New("gemini: gagal menghasilkan gambar")
+		return lastErr
+	})
+	if err != nil {
+		return nil, "", err
 	}
-	return nil, "", lastErr
+	return out, outMT, nil
 }
 
 // ---------- util ----------
@@ -457,21 +472,7 @@ func decodeDataURL(dataURL string) ([]byte, string, bool) {
 	return dec, ct, true
 }
 
-func appendKeys(dst []string, raw string) []string {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return dst
-	}
-	for _, p := range strings.Split(raw, ",") {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			dst = append(dst, p)
-		}
-	}
-	return dst
-}
-
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -7,8 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
-	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -19,12 +19,17 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/llm"
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
 	cfg     config.Config
-	apiKeys []string
-	reImg   *regexp.Regexp
+	reCmd   *regexp.Regexp // "!gambar"
+	reAsk   *regexp.Regexp // "<trigger> buatin gambar"
+	trig    *trigger.Matcher
 	client  *http.Client
 }
 
@@ -73,78 +78,77 @@ type ErrorResp struct {
 	Message string `json:"message"`
 }
 
-func New(cfg config.Config) *Handler {
-	// Parse API keys dari environment variable
-	geminiAPIKey := os.Getenv("GEMINI_API_KEY")
-	if geminiAPIKey == "" {
-		geminiAPIKey = ""
-	}
-	
-	apiKeys := strings.Split(geminiAPIKey, ",")
-	for i := range apiKeys {
-		apiKeys[i] = strings.TrimSpace(apiKeys[i])
-	}
-
-	// Regex untuk detect image generation request
-	reImg := regexp.MustCompile(`(?i)(elaina\s+buatin\s+gambar|!gambar)`)
-
+func New(cfg config.Config, trig *trigger.Matcher) *Handler {
 	return &Handler{
 		cfg:     cfg,
-		apiKeys: apiKeys,
-		reImg:   reImg,
+		reCmd:   regexp.MustCompile(`(?i)!gambar\b`),
+		reAsk:   regexp.MustCompile(`(?i)\bbuatin\s+gambar\b`),
+		trig:    trig,
 		client:  &http.Client{Timeout: 60 * time.Second},
 	}
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, txt string, isOwner bool) bool {
-	if !h.reImg.MatchString(txt) {
-		return false
+// Matches: "!gambar" atau trigger chat + "buatin gambar" (dipakai gating & kuota).
+func (h *Handler) Matches(chat, txt string) bool {
+	return h.reCmd.MatchString(txt) || (h.reAsk.MatchString(txt) && h.trig.Match(chat, txt))
+}
+
+// TryHandle: ctx dibatalkan router jika pesan ditarik sebelum gambar jadi.
+// ok=true hanya jika gambar terkirim (kuota dikembalikan jika gagal).
+func (h *Handler) TryHandle(ctx context.Context, client wa.Client, m *events.Message, txt string, isOwner bool) (handled, ok bool) {
+	chat := m.Info.Chat.String()
+	if !h.Matches(chat, txt) {
+		return false, false
 	}
 
 	// Extract prompt dari text
-	prompt := h.extractPrompt(txt)
+	prompt := h.extractPrompt(chat, txt)
 	if prompt == "" {
-		h.replyError(client, m, "Prompt gambar kosong. Contoh: elaina buatin gambar kucing lucu")
-		return true
+		h.replyError(client, m, i18n.For(chat).T("imggen.empty_prompt", h.trig.Primary(chat)))
+		return true, false
 	}
 
-	// Generate image
-	go h.generateImage(client, m, prompt)
-	return true
+	// Generate image (sudah berjalan di worker dispatcher)
+	pg := wa.StartProgress(ctx, client, m.Info, wa.PresenceTyping)
+	ok = h.generateImage(ctx, client, m, prompt)
+	pg.Done(ok)
+	return true, ok
 }
 
-func (h *Handler) extractPrompt(txt string) string {
+func (h *Handler) extractPrompt(chat, txt string) string {
 	// Remove trigger words dan ambil sisa text sebagai prompt
-	cleaned := h.reImg.ReplaceAllString(txt, "")
-	return strings.TrimSpace(cleaned)
+	if h.reCmd.MatchString(txt) {
+		return strings.TrimSpace(h.reCmd.ReplaceAllString(txt, ""))
+	}
+	cleaned := h.trig.Strip(chat, h.reAsk.ReplaceAllString(txt, ""))
+	return strings.Trim(cleaned, " ,:")
 }
 
-func (h *Handler) generateImage(client *whatsmeow.Client, m *events.Message, prompt string) {
-	// Try each API key until success
-	for i, apiKey := range h.apiKeys {
-		if apiKey == "" {
-			continue
-		}
-
-		imageData, err := h.callGeminiAPI(apiKey, prompt)
-		if err == nil && len(imageData) > 0 {
-			h.sendImage(client, m, imageData, prompt)
-			return
-		}
-
-		// Log error dan coba API key berikutnya
-		if i < len(h.apiKeys)-1 {
-			time.Sleep(1 * time.Second) // Brief delay before trying next key
-		}
+func (h *Handler) generateImage(ctx context.Context, client wa.Client, m *events.Message, prompt string) bool {
+	// Key diambil dari pool bersama: key yang kena limit di-cooldown
+	var imageData []byte
+	err := llm.ImageKeys().Do(ctx, func(apiKey string) error {
+		var err error
+		imageData, err = h.callGeminiAPI(ctx, apiKey, prompt)
+		return err
+	})
+	if ctx.Err() != nil {
+		// Pesan ditarik: jangan kirim hasil maupun pesan error
+		log.Printf("[IMGGEN] dibatalkan chat=%s id=%s", m.Info.Chat.String(), m.Info.ID)
+		return false
+	}
+	if err == nil && len(imageData) > 0 {
+		return h.sendImage(client, m, imageData, prompt)
 	}
 
-	// Semua API key gagal
-	h.replyError(client, m, "Gagal generate gambar. Semua API key limit atau error.")
+	log.Printf("[IMGGEN] gagal chat=%s: %v", m.Info.Chat.String(), err)
+	h.replyError(client, m, i18n.For(m.Info.Chat.String()).T("imggen.failed"))
+	return false
 }
 
-func (h *Handler) callGeminiAPI(apiKey, prompt string) ([]byte, error) {
+func (h *Handler) callGeminiAPI(ctx context.Context, apiKey, prompt string) ([]byte, error) {
 	// Menggunakan Gemini 2.0 Flash Preview Image Generation
-	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/gemini-2.0-flash-preview-image-generation:generateContent?key=%s", apiKey)
+	url := llm.GeminiEndpoint("gemini-2.0-flash-preview-image-generation", apiKey)
 
 	// Enhanced prompt untuk image generation
 	enhancedPrompt := fmt.Sprintf("Generate a high-quality image: %s", prompt)
@@ -165,7 +169,12 @@ func (h *Handler) callGeminiAPI(apiKey, prompt string) ([]byte, error) {
 		return nil, err
 	}
 
-	resp, err := h.client.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +186,7 @@ func (h *Handler) callGeminiAPI(apiKey, prompt string) ([]byte, error) {
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
+		return nil, llm.NewStatusError(resp, body)
 	}
 
 	var genResp GeminiResponse
@@ -210,12 +219,12 @@ func (h *Handler) callGeminiAPI(apiKey, prompt string) ([]byte, error) {
 	return nil, fmt.Errorf("no image data found in response")
 }
 
-func (h *Handler) sendImage(client *whatsmeow.Client, m *events.Message, imageData []byte, prompt string) {
+func (h *Handler) sendImage(client wa.Client, m *events.Message, imageData []byte, prompt string) bool {
 	// Upload image ke WhatsApp
 	uploaded, err := client.Upload(context.Background(), imageData, whatsmeow.MediaImage)
 	if err != nil {
-		h.replyError(client, m, "Gagal upload gambar")
-		return
+		h.replyError(client, m, i18n.For(m.Info.Chat.String()).T("imggen.upload_failed"))
+		return false
 	}
 
 	// Send image message
@@ -234,16 +243,17 @@ func (h *Handler) sendImage(client *whatsmeow.Client, m *events.Message, imageDa
 		FileEncSHA256: uploaded.FileEncSHA256,
 		FileSHA256:    uploaded.FileSHA256,
 		FileLength:    pbf.Uint64(uint64(len(imageData))),
-		Caption:       pbf.String(fmt.Sprintf("🎨 Generated: %s", prompt)),
+		Caption:       pbf.String(i18n.For(m.Info.Chat.String()).T("imggen.caption", prompt)),
 		ContextInfo:   ci,
 	}
 
-	_, _ = client.SendMessage(context.Background(), m.Info.Chat, &waProto.Message{
+	_, err = client.SendMessage(context.Background(), m.Info.Chat, &waProto.Message{
 		ImageMessage: imgMsg,
 	})
+	return err == nil
 }
 
-func (h *Handler) replyError(client *whatsmeow.Client, m *events.Message, errMsg string) {
+func (h *Handler) replyError(client wa.Client, m *events.Message, errMsg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -72,6 +72,15 @@ func (d *Detector) IsOwner(info types.MessageInfo) bool {
 	return false
 }
 
+// NotifyJID: JID owner untuk notifikasi sistem (OWNER_JID/OWNER_NUMBER, lalu OWNER_IDS).
+func (d *Detector) NotifyJID() (types.JID, bool) {
+	if d.jid != nil { return *d.jid, true }
+	for _, j := range d.extras {
+		if j.Server == types.DefaultUserServer { return j, true }
+	}
+	return types.JID{}, false
+}
+
 func (d *Detector) Debug(info types.MessageInfo, got bool) {
 	if !d.debug { return }
 	log.Printf("[OWNER-DBG] sender=%s chat=%s digits(sender)=%s digits(chat)=%s => isOwner=%t",
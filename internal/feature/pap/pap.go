@@ -22,13 +22,15 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
 	urls    []string
 	rePap   *regexp.Regexp
 	httpc   *http.Client
-	caption string
+	botName string
 
 	mu  sync.Mutex
 	rnd *rand.Rand
@@ -49,7 +51,7 @@ func New(cfg config.Config) *Handler {
 		urls:    urls,
 		rePap:   regexp.MustCompile(`(?i)\bpap\b`),
 		httpc:   &http.Client{Timeout: 25 * time.Second},
-		caption: "Pap dari " + botName,
+		botName: botName,
 		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -86,7 +88,7 @@ func loadLinks(path string) ([]string, error) {
 	return urls, nil
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string) bool {
+func (h *Handler) TryHandle(client wa.Client, m *events.Message, text string) bool {
 	if len(h.urls) == 0 {
 		return false
 	}
@@ -114,7 +116,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	}
 
 	if lastErr != nil {
-		replyText(ctx, client, m, "Maaf, aku belum bisa kirim pap sekarang.")
+		replyText(ctx, client, m, i18n.For(m.Info.Chat.String()).T("pap.failed"))
 	}
 	return true
 }
@@ -145,7 +147,7 @@ func (h *Handler) dropURL(idx int) {
 	log.Printf("pap: menghapus link %s dari daftar setelah gagal diakses", url)
 }
 
-func (h *Handler) sendPap(ctx context.Context, client *whatsmeow.Client, m *events.Message, url string) error {
+func (h *Handler) sendPap(ctx context.Context, client wa.Client, m *events.Message, url string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
@@ -187,14 +189,14 @@ func (h *Handler) sendPap(ctx context.Context, client *whatsmeow.Client, m *even
 			FileSHA256:    upload.FileSHA256,
 			FileLength:    pbf.Uint64(uint64(len(data))),
 			Mimetype:      pbf.String(resp.Header.Get("Content-Type")),
-			Caption:       pbf.String(h.caption),
+			Caption:       pbf.String(i18n.For(m.Info.Chat.String()).T("pap.caption", h.botName)),
 			ContextInfo:   ci,
 		},
 	})
 	return err
 }
 
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
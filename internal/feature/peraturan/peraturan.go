@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,7 +16,11 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/db"
+	"wa-elaina/internal/dedup"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/llm"
+	"wa-elaina/internal/perm"
+	"wa-elaina/internal/wa"
 )
 
 const redeemKeyword = "mengurangi warn"
@@ -25,8 +30,15 @@ type Handler struct {
 	store   *db.Store
 	mod     *llm.ModerationClient
 	botName string
+	dedup   *dedup.Filter
+	audit   Auditor
 }
 
+// Auditor mencatat tindakan moderasi otomatis (warn, hapus pesan, kick,
+// kurangi warn) ke audit log. target adalah JID pengguna yang dikenai
+// tindakan, detail berisi alasan; ok=false bila tindakan gagal dijalankan.
+type Auditor func(chat types.JID, action, target, detail string, ok bool)
+
 func New(store *db.Store) *Handler {
 	mod := llm.NewModerationClientFromEnv()
 	if store == nil {
@@ -39,55 +51,78 @@ func New(store *db.Store) *Handler {
 	}
 }
 
+// UseDedup memasang filter agar satu pesan tidak pernah dievaluasi (dan
+// diberi warn) dua kali, meski handler dipanggil ulang untuk pesan yang sama.
+func (h *Handler) UseDedup(f *dedup.Filter) {
+	if h != nil {
+		h.dedup = f
+	}
+}
+
+// UseAuditor memasang pencatat tindakan moderasi.
+func (h *Handler) UseAuditor(a Auditor) {
+	if h != nil {
+		h.audit = a
+	}
+}
+
+func (h *Handler) record(m *events.Message, action, detail string, ok bool) {
+	if h.audit != nil {
+		h.audit(m.Info.Chat, action, perm.Key(m.Info.Sender), detail, ok)
+	}
+}
+
 func (h *Handler) Ready() bool {
 	return h != nil && h.mod != nil && h.mod.Ready()
 }
 
-func (h *Handler) TryCommand(cli *whatsmeow.Client, m *events.Message, args string, isOwner bool) bool {
+// TryCommand: !peraturan untuk moderator bot (isMod) atau admin grup.
+// denied=true jika pengirim ditolak karena bukan admin (untuk audit log).
+func (h *Handler) TryCommand(cli wa.Client, m *events.Message, args string, isMod bool) (handled, denied bool) {
 	if h == nil || cli == nil || m == nil {
-		return false
+		return false, false
 	}
 	if !h.mod.Ready() {
-		h.replyText(cli, m, "PERATURAN_APIKEY belum diatur.")
-		return true
+		h.replyText(cli, m, tr(m, "peraturan.no_apikey"))
+		return true, false
 	}
 	if m.Info.Chat.Server != types.GroupServer {
-		h.replyText(cli, m, "Perintah peraturan hanya berlaku di grup.")
-		return true
+		h.replyText(cli, m, tr(m, "peraturan.group_only"))
+		return true, false
 	}
 
-	canAdmin := isOwner || h.isAdmin(cli, m.Info.Chat, m.Info.Sender)
+	canAdmin := isMod || perm.IsGroupAdmin(cli, m.Info.Chat, m.Info.Sender)
 	if !canAdmin {
-		h.replyText(cli, m, "Hanya admin grup atau owner bot yang bisa mengatur fitur peraturan.")
-		return true
+		h.replyText(cli, m, tr(m, "peraturan.admin_only"))
+		return true, true
 	}
 
 	sub := strings.Fields(strings.ToLower(strings.TrimSpace(args)))
 	if len(sub) == 0 {
-		h.replyText(cli, m, "Gunakan: !peraturan on|off|sync|status|rules|clear @user\nUntuk mengurangi warn: sebut nama bot lalu tulis `saya mau mengurangi warn` dan ucapkan `subhanallah` tepat 5 kali.")
-		return true
+		h.replyText(cli, m, tr(m, "peraturan.usage"))
+		return true, false
 	}
 
 	switch sub[0] {
 	case "on":
-		return h.enable(cli, m)
+		return h.enable(cli, m), false
 	case "off":
-		return h.disable(cli, m)
+		return h.disable(cli, m), false
 	case "sync", "reload":
-		return h.sync(cli, m)
+		return h.sync(cli, m), false
 	case "status":
-		return h.status(cli, m)
+		return h.status(cli, m), false
 	case "rules":
-		return h.showRules(cli, m)
+		return h.showRules(cli, m), false
 	case "clear":
-		return h.clearWarn(cli, m, sub[1:])
+		return h.clearWarn(cli, m, sub[1:]), false
 	default:
-		h.replyText(cli, m, "Perintah tidak dikenal. Gunakan: !peraturan on|off|sync|status|rules|clear @user\nPengurangan warn: sebut nama bot + \"saya mau mengurangi warn\" + `subhanallah` x5.")
-		return true
+		h.replyText(cli, m, tr(m, "peraturan.unknown"))
+		return true, false
 	}
 }
 
-func (h *Handler) HandleMessage(cli *whatsmeow.Client, m *events.Message, text string) {
+func (h *Handler) HandleMessage(cli wa.Client, m *events.Message, text string) {
 	if h == nil || cli == nil || m == nil || m.Info.Chat.Server != types.GroupServer {
 		return
 	}
@@ -102,6 +137,15 @@ func (h *Handler) HandleMessage(cli *whatsmeow.Client, m *events.Message, text s
 	if content == "" {
 		return
 	}
+	// Edit memakai ID pesan asli; tambahkan waktu edit agar isi baru tetap
+	// dievaluasi (pesan aman yang diedit jadi pelanggaran tidak lolos).
+	key := "peraturan:" + m.Info.Chat.String() + "/" + m.Info.ID
+	if m.IsEdit {
+		key += "@edit" + strconv.FormatInt(m.Info.Timestamp.UnixNano(), 10)
+	}
+	if h.dedup.TooOld(m.Info.Timestamp) || h.dedup.Seen(key) {
+		return
+	}
 
 	lower := strings.ToLower(content)
 	isRedeem := strings.Contains(lower, strings.ToLower(h.botName)) &&
@@ -118,7 +162,7 @@ func (h *Handler) HandleMessage(cli *whatsmeow.Client, m *events.Message, text s
 	h.handleWarn(ctx, cli, m, state, content)
 }
 
-func (h *Handler) handleWarn(ctx context.Context, cli *whatsmeow.Client, m *events.Message, state db.PeraturanState, content string) {
+func (h *Handler) handleWarn(ctx context.Context, cli wa.Client, m *events.Message, state db.PeraturanState, content string) {
 	res, err := h.mod.Evaluate(ctx, llm.ModerationInput{
 		Mode:    llm.ModerationModeWarn,
 		Rules:   state.Rules,
@@ -136,31 +180,35 @@ func (h *Handler) handleWarn(ctx context.Context, cli *whatsmeow.Client, m *even
 
 	reason := strings.TrimSpace(res.Reason)
 	if reason == "" {
-		reason = "Melanggar aturan grup."
+		reason = tr(m, "peraturan.default_reason")
 	}
 
-	h.revokeMessage(cli, m)
+	h.record(m, "revoke", m.Info.ID+" — "+reason, h.revokeMessage(cli, m))
 
 	rec, err := h.store.AddWarn(m.Info.Chat.String(), m.Info.Sender.String(), reason)
 	if err != nil {
 		log.Printf("[PERATURAN] add warn error: %v", err)
+		h.record(m, "warn", reason, false)
 		return
 	}
-	warnText := fmt.Sprintf("*Peringatan %d/%d untuk @%s*\nAlasan: %s", rec.Count, warnLimit, m.Info.Sender.User, reason)
+	h.record(m, "warn", fmt.Sprintf("%d/%d — %s", rec.Count, warnLimit, reason), true)
+	warnText := tr(m, "peraturan.warn", rec.Count, warnLimit, m.Info.Sender.User, reason)
 	h.sendMention(cli, m, warnText, []types.JID{m.Info.Sender})
 
 	if rec.Count >= warnLimit {
 		target := m.Info.Sender.ToNonAD()
 		if _, err := cli.UpdateGroupParticipants(m.Info.Chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove); err != nil {
 			log.Printf("[PERATURAN] gagal keluarkan %s: %v", m.Info.Sender.String(), err)
+			h.record(m, "kick", reason, false)
 			return
 		}
+		h.record(m, "kick", reason, true)
 		_ = h.store.ClearWarns(m.Info.Chat.String(), m.Info.Sender.String())
-		h.sendMention(cli, m, fmt.Sprintf("*%s dikeluarkan karena melebihi batas peringatan.*", "@"+m.Info.Sender.User), []types.JID{m.Info.Sender})
+		h.sendMention(cli, m, tr(m, "peraturan.kicked", m.Info.Sender.User), []types.JID{m.Info.Sender})
 	}
 }
 
-func (h *Handler) handleRedeem(ctx context.Context, cli *whatsmeow.Client, m *events.Message, state db.PeraturanState, content string) {
+func (h *Handler) handleRedeem(ctx context.Context, cli wa.Client, m *events.Message, state db.PeraturanState, content string) {
 	res, err := h.mod.Evaluate(ctx, llm.ModerationInput{
 		Mode:    llm.ModerationModeRedeem,
 		Rules:   state.Rules,
@@ -178,82 +226,84 @@ func (h *Handler) handleRedeem(ctx context.Context, cli *whatsmeow.Client, m *ev
 	rec, err := h.store.DecrementWarn(m.Info.Chat.String(), m.Info.Sender.String())
 	if err != nil {
 		log.Printf("[PERATURAN] decrement warn error: %v", err)
+		h.record(m, "redeem", "", false)
 		return
 	}
+	h.record(m, "redeem", fmt.Sprintf("%d/%d", rec.Count, warnLimit), true)
 	if rec.Count <= 0 {
-		h.sendMention(cli, m, fmt.Sprintf("*%s, warn kamu sudah 0. Tetap jaga kedisiplinan ya.*", "@"+m.Info.Sender.User), []types.JID{m.Info.Sender})
+		h.sendMention(cli, m, tr(m, "peraturan.warn_zero", m.Info.Sender.User), []types.JID{m.Info.Sender})
 		return
 	}
-	h.sendMention(cli, m, fmt.Sprintf("*Warn kamu berkurang menjadi %d/%d.*", rec.Count, warnLimit), []types.JID{m.Info.Sender})
+	h.sendMention(cli, m, tr(m, "peraturan.warn_reduced", rec.Count, warnLimit), []types.JID{m.Info.Sender})
 }
 
-func (h *Handler) enable(cli *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) enable(cli wa.Client, m *events.Message) bool {
 	info, err := cli.GetGroupInfo(m.Info.Chat)
 	if err != nil {
-		h.replyText(cli, m, "Gagal mengambil info grup: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.group_info_failed", err))
 		return true
 	}
 	desc := strings.TrimSpace(info.GroupTopic.Topic)
 	if desc == "" {
-		h.replyText(cli, m, "Deskripsi grup kosong. Atur deskripsi berisi aturan terlebih dahulu.")
+		h.replyText(cli, m, tr(m, "peraturan.desc_empty_enable"))
 		return true
 	}
 	rules := sanitizeRules(desc)
 	if err := h.store.SetPeraturanState(m.Info.Chat.String(), true, rules); err != nil {
-		h.replyText(cli, m, "Gagal menyimpan aturan: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.save_failed", err))
 		return true
 	}
-	h.replyText(cli, m, "Fitur peraturan aktif.\nAturan tersimpan sebanyak "+fmt.Sprint(len(strings.Split(rules, "\n")))+" baris.")
+	h.replyText(cli, m, tr(m, "peraturan.enabled", len(strings.Split(rules, "\n"))))
 	return true
 }
 
-func (h *Handler) disable(cli *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) disable(cli wa.Client, m *events.Message) bool {
 	state, _ := h.store.GetPeraturanState(m.Info.Chat.String())
 	if !state.Enabled {
-		h.replyText(cli, m, "Fitur peraturan sudah nonaktif.")
+		h.replyText(cli, m, tr(m, "peraturan.already_off"))
 		return true
 	}
 	if err := h.store.SetPeraturanState(m.Info.Chat.String(), false, state.Rules); err != nil {
-		h.replyText(cli, m, "Gagal menonaktifkan: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.disable_failed", err))
 		return true
 	}
-	h.replyText(cli, m, "Fitur peraturan dinonaktifkan.")
+	h.replyText(cli, m, tr(m, "peraturan.disabled"))
 	return true
 }
 
-func (h *Handler) sync(cli *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) sync(cli wa.Client, m *events.Message) bool {
 	info, err := cli.GetGroupInfo(m.Info.Chat)
 	if err != nil {
-		h.replyText(cli, m, "Gagal mengambil info grup: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.group_info_failed", err))
 		return true
 	}
 	desc := strings.TrimSpace(info.GroupTopic.Topic)
 	if desc == "" {
-		h.replyText(cli, m, "Deskripsi grup kosong.")
+		h.replyText(cli, m, tr(m, "peraturan.desc_empty"))
 		return true
 	}
 	if err := h.store.SetPeraturanState(m.Info.Chat.String(), true, sanitizeRules(desc)); err != nil {
-		h.replyText(cli, m, "Gagal menyinkronkan aturan: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.sync_failed", err))
 		return true
 	}
-	h.replyText(cli, m, "Aturan diperbarui dari deskripsi grup.")
+	h.replyText(cli, m, tr(m, "peraturan.synced"))
 	return true
 }
 
-func (h *Handler) status(cli *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) status(cli wa.Client, m *events.Message) bool {
 	state, err := h.store.GetPeraturanState(m.Info.Chat.String())
 	if err != nil {
-		h.replyText(cli, m, "Gagal memuat status: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.status_failed", err))
 		return true
 	}
-	status := "Nonaktif"
+	status := tr(m, "peraturan.status_off")
 	if state.Enabled {
-		status = "Aktif"
+		status = tr(m, "peraturan.status_on")
 	}
 	builder := strings.Builder{}
-	builder.WriteString(fmt.Sprintf("*Status:* %s\n", status))
+	builder.WriteString(tr(m, "peraturan.status", status) + "\n")
 	if state.Rules != "" {
-		builder.WriteString("*Aturan tersimpan:* \n")
+		builder.WriteString(tr(m, "peraturan.status_rules") + "\n")
 		lines := strings.Split(state.Rules, "\n")
 		max := len(lines)
 		if max > 6 {
@@ -265,12 +315,12 @@ func (h *Handler) status(cli *whatsmeow.Client, m *events.Message) bool {
 			builder.WriteString("\n")
 		}
 		if len(lines) > max {
-			builder.WriteString(fmt.Sprintf("... (%d baris lagi)\n", len(lines)-max))
+			builder.WriteString(tr(m, "peraturan.status_more", len(lines)-max) + "\n")
 		}
 	}
 	warns, err := h.store.ListWarns(m.Info.Chat.String())
 	if err == nil && len(warns) > 0 {
-		builder.WriteString("\n*Daftar warn teratas:*\n")
+		builder.WriteString("\n" + tr(m, "peraturan.status_warns") + "\n")
 		limit := len(warns)
 		if limit > 5 {
 			limit = 5
@@ -283,31 +333,31 @@ func (h *Handler) status(cli *whatsmeow.Client, m *events.Message) bool {
 	return true
 }
 
-func (h *Handler) showRules(cli *whatsmeow.Client, m *events.Message) bool {
+func (h *Handler) showRules(cli wa.Client, m *events.Message) bool {
 	state, err := h.store.GetPeraturanState(m.Info.Chat.String())
 	if err != nil {
-		h.replyText(cli, m, "Gagal memuat aturan: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.rules_failed", err))
 		return true
 	}
 	if strings.TrimSpace(state.Rules) == "" {
-		h.replyText(cli, m, "Belum ada aturan tersimpan.")
+		h.replyText(cli, m, tr(m, "peraturan.no_rules"))
 		return true
 	}
-	h.replyText(cli, m, "*Aturan grup:*\n"+state.Rules)
+	h.replyText(cli, m, tr(m, "peraturan.rules", state.Rules))
 	return true
 }
 
-func (h *Handler) clearWarn(cli *whatsmeow.Client, m *events.Message, args []string) bool {
+func (h *Handler) clearWarn(cli wa.Client, m *events.Message, args []string) bool {
 	target := h.extractMention(m)
 	if target == "" {
-		h.replyText(cli, m, "Sertakan mention pengguna yang ingin kamu hapus warn-nya.")
+		h.replyText(cli, m, tr(m, "peraturan.clear_need_mention"))
 		return true
 	}
 	if err := h.store.ClearWarns(m.Info.Chat.String(), target); err != nil {
-		h.replyText(cli, m, "Gagal menghapus warn: "+err.Error())
+		h.replyText(cli, m, tr(m, "peraturan.clear_failed", err))
 		return true
 	}
-	h.replyText(cli, m, fmt.Sprintf("Warn untuk %s direset.", target))
+	h.replyText(cli, m, tr(m, "peraturan.cleared", target))
 	return true
 }
 
@@ -323,20 +373,7 @@ func (h *Handler) extractMention(m *events.Message) string {
 	return mentions[0]
 }
 
-func (h *Handler) isAdmin(cli *whatsmeow.Client, group, sender types.JID) bool {
-	info, err := cli.GetGroupInfo(group)
-	if err != nil || info == nil {
-		return false
-	}
-	for _, p := range info.Participants {
-		if p.JID.String() == sender.String() {
-			return p.IsAdmin || p.IsSuperAdmin
-		}
-	}
-	return false
-}
-
-func (h *Handler) replyText(cli *whatsmeow.Client, m *events.Message, msg string) {
+func (h *Handler) replyText(cli wa.Client, m *events.Message, msg string) {
 	ctx := context.Background()
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
@@ -355,7 +392,7 @@ func (h *Handler) replyText(cli *whatsmeow.Client, m *events.Message, msg string
 	}
 }
 
-func (h *Handler) sendMention(cli *whatsmeow.Client, m *events.Message, text string, mentions []types.JID) {
+func (h *Handler) sendMention(cli wa.Client, m *events.Message, text string, mentions []types.JID) {
 	ctx := context.Background()
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
@@ -377,6 +414,11 @@ func (h *Handler) sendMention(cli *whatsmeow.Client, m *events.Message, text str
 	}
 }
 
+// tr menerjemahkan kunci katalog ke bahasa chat pesan (!lang).
+func tr(m *events.Message, key string, args ...any) string {
+	return i18n.For(m.Info.Chat.String()).T(key, args...)
+}
+
 func sanitizeRules(s string) string {
 	lines := strings.Split(s, "\n")
 	out := make([]string, 0, len(lines))
@@ -397,15 +439,18 @@ func botNameFromEnv() string {
 	return "Bot"
 }
 
-func (h *Handler) revokeMessage(cli *whatsmeow.Client, m *events.Message) {
+// revokeMessage menghapus pesan pelanggar; false jika gagal.
+func (h *Handler) revokeMessage(cli wa.Client, m *events.Message) bool {
 	if cli == nil || m == nil || m.Info.ID == "" {
-		return
+		return false
 	}
 	msg := cli.BuildRevoke(m.Info.Chat, m.Info.Sender, types.MessageID(m.Info.ID))
 	if msg == nil {
-		return
+		return false
 	}
 	if _, err := cli.SendMessage(context.Background(), m.Info.Chat, msg); err != nil {
 		log.Printf("[PERATURAN] gagal hapus pesan: %v", err)
+		return false
 	}
+	return true
 }
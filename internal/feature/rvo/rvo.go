@@ -11,28 +11,36 @@ import (
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types/events"
 	pbf "google.golang.org/protobuf/proto"
+
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
-	re *regexp.Regexp
+	re   *regexp.Regexp
+	trig *trigger.Matcher
 }
 
-func New() *Handler {
+func New(trig *trigger.Matcher) *Handler {
 	// trigger sederhana: "rvo"
 	return &Handler{
-		re: regexp.MustCompile(`(?i)\brvo\b`),
+		re:   regexp.MustCompile(`(?i)\brvo\b`),
+		trig: trig,
 	}
 }
 
 // TryHandle mengekstrak media view-once dari pesan yang di-reply lalu mengirim ulang sebagai media biasa.
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string) bool {
+func (h *Handler) TryHandle(client wa.Client, m *events.Message, text string) bool {
 	if !h.re.MatchString(text) {
 		return false
 	}
+	chat := m.Info.Chat.String()
+	lang := i18n.For(chat)
 
 	xt := m.Message.GetExtendedTextMessage()
 	if xt == nil || xt.ContextInfo == nil || xt.ContextInfo.QuotedMessage == nil {
-		h.replyText(context.Background(), client, m, "Reply foto/video *view-once* lalu ketik *elaina rvo* ya ✨")
+		h.replyText(context.Background(), client, m, lang.T("rvo.usage", h.trig.Primary(chat)))
 		return true
 	}
 
@@ -44,7 +52,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	// Pastikan memang media view-once (foto/video)
 	dl, mediaKind, origMime, caption := downloadable(inner)
 	if dl == nil {
-		h.replyText(context.Background(), client, m, "Pesan yang di-reply bukan media *view-once* (foto/video).")
+		h.replyText(context.Background(), client, m, lang.T("rvo.not_view_once"))
 		return true
 	}
 
@@ -55,7 +63,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	blob, err := client.Download(ctx, dl)
 	if err != nil {
 		log.Printf("[RVO] download error: %v", err)
-		h.replyText(ctx, client, m, "Gagal mengunduh media view-once 😔")
+		h.replyText(ctx, client, m, lang.T("rvo.download_failed"))
 		return true
 	}
 
@@ -67,14 +75,14 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	case "video":
 		cat = whatsmeow.MediaVideo
 	default:
-		h.replyText(ctx, client, m, "Jenis media tidak didukung.")
+		h.replyText(ctx, client, m, lang.T("rvo.unsupported"))
 		return true
 	}
 
 	up, err := client.Upload(ctx, blob, cat)
 	if err != nil {
 		log.Printf("[RVO] upload error: %v", err)
-		h.replyText(ctx, client, m, "Gagal mengunggah ulang media 😔")
+		h.replyText(ctx, client, m, lang.T("rvo.upload_failed"))
 		return true
 	}
 
@@ -164,7 +172,7 @@ func downloadable(msg *waProto.Message) (dl whatsmeow.DownloadableMessage, kind,
 	return nil, "", "", ""
 }
 
-func (h *Handler) replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, s string) {
+func (h *Handler) replyText(ctx context.Context, client wa.Client, m *events.Message, s string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
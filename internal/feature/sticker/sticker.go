@@ -18,69 +18,85 @@ import (
 	"go.mau.fi/whatsmeow/types"
 	pbf "google.golang.org/protobuf/proto"
 
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/trigger"
 	"wa-elaina/internal/util"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
 	reCmd *regexp.Regexp
 	reNat *regexp.Regexp
-	reEla *regexp.Regexp
+	trig  *trigger.Matcher
 }
 
-func New() *Handler {
+func New(trig *trigger.Matcher) *Handler {
 	return &Handler{
 		reCmd: regexp.MustCompile(`(?i)\b(!s|!sgif|!stiker|!sticker|stiker|sticker)\b`),
 		reNat: regexp.MustCompile(`(?i)\b(stiker|sticker)\b`),
-		reEla: regexp.MustCompile(`(?i)\belaina\b`),
+		trig:  trig,
 	}
 }
 
-func (h *Handler) TryHandleTo(client *whatsmeow.Client, to types.JID, msg *waProto.Message, rawText string) bool {
+// TryHandleTo membalas ke chat info.Chat dan memberi reaksi progres di pesan info;
+// ctx dibatalkan router jika pesan ditarik.
+func (h *Handler) TryHandleTo(ctx context.Context, client wa.Client, info types.MessageInfo, msg *waProto.Message, rawText string) bool {
+	to := info.Chat
 	text := getText(rawText, msg)
 	low := strings.ToLower(strings.TrimSpace(text))
 	hasMedia := hasImageOrVideo(msg)
 
 	if h.reCmd.MatchString(low) {
-		return h.handleStickerTo(client, to, msg, low)
+		return h.handleStickerTo(ctx, client, info, msg, low)
 	}
 	
 	if h.reNat.MatchString(low) && hasMedia {
-		return h.handleStickerTo(client, to, msg, low)
+		return h.handleStickerTo(ctx, client, info, msg, low)
 	}
 	
-	if hasMedia && h.reEla.MatchString(low) && (strings.Contains(low, "stiker") || strings.Contains(low, "sticker")) {
-		return h.handleStickerTo(client, to, msg, low)
+	if hasMedia && h.trig.Match(to.String(), low) && (strings.Contains(low, "stiker") || strings.Contains(low, "sticker")) {
+		return h.handleStickerTo(ctx, client, info, msg, low)
 	}
 	
 	return false
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, msg *waProto.Message, rawText string) bool {
+func (h *Handler) TryHandle(client wa.Client, msg *waProto.Message, rawText string) bool {
 	text := getText(rawText, msg)
 	low := strings.ToLower(strings.TrimSpace(text))
 	hasMedia := hasImageOrVideo(msg)
 
 	if h.reCmd.MatchString(low) {
-		return h.handleStickerTo(client, types.JID{}, msg, low)
+		return h.handleStickerTo(context.Background(), client, types.MessageInfo{}, msg, low)
 	}
 	
 	if h.reNat.MatchString(low) && hasMedia {
-		return h.handleStickerTo(client, types.JID{}, msg, low)
+		return h.handleStickerTo(context.Background(), client, types.MessageInfo{}, msg, low)
 	}
 	
-	if hasMedia && h.reEla.MatchString(low) && (strings.Contains(low, "stiker") || strings.Contains(low, "sticker")) {
-		return h.handleStickerTo(client, types.JID{}, msg, low)
+	// Tanpa JID chat → alias trigger bawaan
+	if hasMedia && h.trig.Match("", low) && (strings.Contains(low, "stiker") || strings.Contains(low, "sticker")) {
+		return h.handleStickerTo(context.Background(), client, types.MessageInfo{}, msg, low)
 	}
 	
 	return false
 }
 
-func (h *Handler) handleStickerTo(client *whatsmeow.Client, to types.JID, msg *waProto.Message, low string) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), 75*time.Second)
+func (h *Handler) handleStickerTo(parent context.Context, client wa.Client, info types.MessageInfo, msg *waProto.Message, low string) bool {
+	ctx, cancel := context.WithTimeout(parent, 75*time.Second)
 	defer cancel()
 
+	to := info.Chat
+	var pg *wa.Progress
+	if info.ID != "" {
+		pg = wa.StartProgress(parent, client, info, wa.PresenceNone)
+	}
+	ok := false
+	defer func() { pg.Done(ok) }()
+
 	isAnimated := strings.Contains(low, "!sgif")
 	url := firstURL(low)
+	lang := i18n.For(to.String())
 
 	var data []byte
 	var err error
@@ -88,7 +104,7 @@ func (h *Handler) handleStickerTo(client *whatsmeow.Client, to types.JID, msg *w
 	if url != "" {
 		data, _, err = util.DownloadBytes(nil, url, 50<<20)
 		if err != nil {
-			_ = sendText(ctx, client, to, msg, "Gagal unduh: "+err.Error())
+			_ = sendText(ctx, client, to, msg, lang.T("sticker.download_failed", err))
 			return true
 		}
 		if !isAnimated && strings.HasSuffix(strings.ToLower(url), ".gif") {
@@ -119,20 +135,22 @@ func (h *Handler) handleStickerTo(client *whatsmeow.Client, to types.JID, msg *w
 			}
 		}
 		if err != nil || len(data) == 0 {
-			_ = sendText(ctx, client, to, msg, "Tidak menemukan media untuk dijadikan sticker. Sertakan URL atau reply gambar/video.")
+			_ = sendText(ctx, client, to, msg, lang.T("sticker.no_media"))
 			return true
 		}
 	}
 
 	outWebP, err := toWebP(ctx, data, isAnimated)
 	if err != nil {
-		_ = sendText(ctx, client, to, msg, "Konversi WebP gagal: "+err.Error())
+		_ = sendText(ctx, client, to, msg, lang.T("sticker.webp_failed", err))
 		return true
 	}
 
 	if err := sendStickerBytes(ctx, client, to, msg, outWebP, isAnimated); err != nil {
-		_ = sendText(ctx, client, to, msg, "Kirim sticker gagal: "+err.Error())
+		_ = sendText(ctx, client, to, msg, lang.T("sticker.send_failed", err))
+		return true
 	}
+	ok = true
 	return true
 }
 
@@ -172,7 +190,7 @@ func getText(fallback string, m *waProto.Message) string {
 	return ""
 }
 
-func sendText(ctx context.Context, client *whatsmeow.Client, to types.JID, in *waProto.Message, text string) error {
+func sendText(ctx context.Context, client wa.Client, to types.JID, in *waProto.Message, text string) error {
 	var jid types.JID
 	if to.User != "" {
 		jid = to
@@ -404,7 +422,7 @@ func toWebP(ctx context.Context, input []byte, animated bool) ([]byte, error) {
 	return os.ReadFile(out)
 }
 
-func sendStickerBytes(ctx context.Context, client *whatsmeow.Client, to types.JID, in *waProto.Message, webp []byte, animated bool) error {
+func sendStickerBytes(ctx context.Context, client wa.Client, to types.JID, in *waProto.Message, webp []byte, animated bool) error {
 	_ = animated
 
 	uploaded, err := client.Upload(ctx, webp, whatsmeow.MediaImage)
@@ -5,29 +5,32 @@ import (
 	"regexp"
 	"strings"
 
-	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	pbf "google.golang.org/protobuf/proto"
+
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
 	re   *regexp.Regexp // mendeteksi kata "tagall"
-	trig string
+	trig *trigger.Matcher
 }
 
-func New(trigger string) *Handler {
+func New(trig *trigger.Matcher) *Handler {
 	return &Handler{
 		re:   regexp.MustCompile(`(?i)\btagall\b`),
-		trig: trigger,
+		trig: trig,
 	}
 }
 
 // TryHandle: aktif jika di GRUP dan user mengetik:
 // - "!tagall", atau
-// - "elaina tagall" (atau ada kata "tagall" + trigger ditangani oleh router)
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text string) bool {
+// - "<trigger chat> tagall" (trigger per chat, lihat !trigger)
+func (h *Handler) TryHandle(client wa.Client, m *events.Message, text string) bool {
 	// Hanya relevan di grup
 	if m.Info.Chat.Server != types.GroupServer {
 		return false
@@ -44,7 +47,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 	}
 
 	// Deteksi pola
-	if !isBangTagAll && !h.re.MatchString(t) {
+	if !isBangTagAll && !(h.re.MatchString(t) && h.trig.Match(m.Info.Chat.String(), t)) {
 		return false
 	}
 
@@ -68,13 +71,13 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, text st
 			end = len(all)
 		}
 		sub := all[i:end]
-		h.sendMention(client, m, sub, "👋 Halo semuanya, hadir ya!")
+		h.sendMention(client, m, sub, i18n.For(m.Info.Chat.String()).T("tagall.hello"))
 	}
 
 	return true
 }
 
-func (h *Handler) sendMention(client *whatsmeow.Client, m *events.Message, jids []types.JID, text string) {
+func (h *Handler) sendMention(client wa.Client, m *events.Message, jids []types.JID, text string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -1,11 +1,13 @@
 package tkwrap
 
 import (
+	"context"
 	"net/http"
 	"regexp"
 
 	"go.mau.fi/whatsmeow/types"
 
+	dl "wa-elaina/downloader"
 	"wa-elaina/internal/config"
 	"wa-elaina/internal/tiktok"
 	"wa-elaina/internal/wa"
@@ -17,6 +19,9 @@ type Handler struct {
 }
 
 func New(cfg config.Config, s *wa.Sender) *Handler {
+	if cfg.TikWMBaseURL != "" {
+		dl.TikwmBaseURL = cfg.TikWMBaseURL
+	}
 	h := &tiktok.Handler{
 		Client: http.DefaultClient,
 		Send:   s,
@@ -37,9 +42,9 @@ func New(cfg config.Config, s *wa.Sender) *Handler {
 	}
 }
 
-func (h *Handler) TryHandle(text string, to types.JID) bool {
+func (h *Handler) TryHandle(ctx context.Context, info types.MessageInfo, text string) bool {
 	if !h.re.MatchString(text) {
 		return false
 	}
-	return h.tk.TryHandle(text, to)
+	return h.tk.TryHandle(ctx, info, text)
 }
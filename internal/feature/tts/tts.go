@@ -21,7 +21,10 @@ import (
 	pbf "google.golang.org/protobuf/proto"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/llm"
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa"
 )
 
 // Handler mengubah intent user -> naskah singkat (Gemini) -> audio (ElevenLabs)
@@ -29,6 +32,7 @@ type Handler struct {
 	enabled bool
 
 	// ElevenLabs
+	elBase       string // ELEVENLABS_BASE_URL
 	elKey        string
 	elVoice      string
 	elModel      string
@@ -43,13 +47,13 @@ type Handler struct {
 	maxWords int // VN_MAX_WORDS
 
 	reCmd  *regexp.Regexp
-	reTrig *regexp.Regexp // di-inject dari router
+	trig   *trigger.Matcher // di-inject dari router
 	httpc  *http.Client
 
 	verifyVoice bool // GET /v1/voices/{id} saat inisialisasi (opsional, via env)
 }
 
-func New(cfg config.Config, reTrigger *regexp.Regexp) *Handler {
+func New(cfg config.Config, trig *trigger.Matcher) *Handler {
 	key := getenvFirst("ELEVEN_API_KEY", "ELEVENLABS_API_KEY")
 	voice := getenvFirst("ELEVEN_VOICE_ID", "ELEVENLABS_VOICE_ID")
 
@@ -68,13 +72,14 @@ func New(cfg config.Config, reTrigger *regexp.Regexp) *Handler {
 
 	h := &Handler{
 		enabled:      key != "" && voice != "",
+		elBase:       strings.TrimRight(cfg.ElevenBaseURL, "/"),
 		elKey:        key,
 		elVoice:      voice,
 		elModel:      model,
 		outFmt:       outFmt,
 		optLatency:   clamp(optLatency, 0, 4),
 		reCmd:        regexp.MustCompile(`(?i)\b(vn|voice\s*note|kirim(?:kan)?\s*vn|ucapkan|bacakan|katakan)\b`),
-		reTrig:       reTrigger,
+		trig:         trig,
 		httpc:        &http.Client{Timeout: 60 * time.Second},
 		maxWords:     intFromEnv("VN_MAX_WORDS", 80),
 		stability:    floatFromEnv("ELEVEN_STABILITY", 0.45), // sedikit lebih dinamis
@@ -106,24 +111,33 @@ func New(cfg config.Config, reTrigger *regexp.Regexp) *Handler {
 	return h
 }
 
-// TryHandle: user minta VN → (1) buat naskah singkat (Gemini via llm.AskText),
-// (2) TTS ElevenLabs, (3) kirim audio sebagai reply.
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, userText string) bool {
+// Matches: teks menyebut trigger + perintah VN (dipakai gating & kuota).
+func (h *Handler) Matches(m *events.Message, userText string) bool {
 	// wajib ada trigger
-	if !h.reTrig.MatchString(userText) {
+	chat := m.Info.Chat.String()
+	if !h.trig.Match(chat, userText) {
 		return false
 	}
-	after := strings.TrimSpace(h.reTrig.ReplaceAllString(userText, ""))
+	after := h.trig.Strip(chat, userText)
 	if after == "" && m.Message.GetExtendedTextMessage() == nil {
 		return false
 	}
-	if !h.reCmd.MatchString(after) {
-		return false
-	}
+	return h.reCmd.MatchString(after)
+}
+
+// TryHandle: user minta VN → (1) buat naskah singkat (Gemini via llm.AskText),
+// (2) TTS ElevenLabs, (3) kirim audio sebagai reply. ctx dibatalkan router
+// jika pesan ditarik; audio tidak dikirim. ok=true hanya jika audio terkirim.
+func (h *Handler) TryHandle(ctx context.Context, client wa.Client, m *events.Message, userText string) (handled, ok bool) {
+	if !h.Matches(m, userText) {
+		return false, false
+	}
+	chat := m.Info.Chat.String()
+	after := h.trig.Strip(chat, userText)
+	lang := i18n.For(chat)
 	if !h.enabled {
-		h.replyText(context.Background(), client, m,
-			"Fitur VN belum dikonfigurasi. Set **ELEVEN_API_KEY/ELEVENLABS_API_KEY** dan **ELEVEN_VOICE_ID/ELEVENLABS_VOICE_ID** di `.env`, lalu restart bot ✨")
-		return true
+		h.replyText(context.Background(), client, m, lang.T("tts.not_configured"))
+		return true, ok
 	}
 
 	// --- Ambil maksud user ---
@@ -140,17 +154,21 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, userTex
 		}
 	}
 	if intent == "" {
-		h.replyText(context.Background(), client, m, "Tulis: *elaina vn <teks>* atau reply pesan lalu ketik *elaina vn* ya ✨")
-		return true
+		h.replyText(context.Background(), client, m, lang.T("tts.usage", h.trig.Primary(chat)))
+		return true, ok
 	}
 
+	pg := wa.StartProgress(ctx, client, m.Info, wa.PresenceAudio)
+	defer func() { pg.Done(ok) }()
+
 	// --- Buat naskah singkat via Gemini ---
 	sys := fmt.Sprintf(`Kamu copywriter ramah untuk voice note WhatsApp.
 Tulis SATU kalimat (maks %d kata), alami, hangat, jelas, tidak bertele-tele, langsung ke inti.
 Jangan menyebut kata "voice note".`, h.maxWords)
-	script := strings.TrimSpace(llm.AskText(sys, intent))
-	if script == "" {
-		script = intent
+	script, err := llm.AskText(sys, intent)
+	script = strings.TrimSpace(script)
+	if err != nil || script == "" {
+		script = intent // naskah gagal dibuat: bacakan permintaan apa adanya
 	}
 	script = trimWords(script, h.maxWords)
 	script = applyRateHint(script, h.rateHint)
@@ -158,13 +176,17 @@ Jangan menyebut kata "voice note".`, h.maxWords)
 	log.Printf("[TTS] script(%s): %q", h.rateHint, script)
 
 	// --- TTS ElevenLabs ---
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	elCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
-	audio, mimeType, err := h.elevenLabsTTS(ctx, script)
+	audio, mimeType, err := h.elevenLabsTTS(elCtx, script)
+	if ctx.Err() != nil {
+		log.Printf("[TTS] dibatalkan chat=%s id=%s", chat, m.Info.ID)
+		return true, ok
+	}
 	if err != nil {
-		h.replyText(context.Background(), client, m, "TTS gagal. Pastikan kredensial ElevenLabs & voice ID benar.")
+		h.replyText(context.Background(), client, m, lang.T("tts.failed"))
 		log.Printf("[TTS] ERROR elevenLabsTTS: %v", err)
-		return true
+		return true, ok
 	}
 
 	// --- Upload & kirim ---
@@ -172,9 +194,9 @@ Jangan menyebut kata "voice note".`, h.maxWords)
 	defer upCancel()
 	up, err := client.Upload(upCtx, audio, whatsmeow.MediaAudio)
 	if err != nil {
-		h.replyText(upCtx, client, m, "Gagal mengunggah audio 😔")
+		h.replyText(upCtx, client, m, lang.T("tts.upload_failed"))
 		log.Printf("[TTS] ERROR upload: %v", err)
-		return true
+		return true, ok
 	}
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
@@ -182,7 +204,7 @@ Jangan menyebut kata "voice note".`, h.maxWords)
 		Participant:   pbf.String(m.Info.Sender.String()),
 		RemoteJID:     pbf.String(m.Info.Chat.String()),
 	}
-	_, _ = client.SendMessage(upCtx, m.Info.Chat, &waProto.Message{
+	_, err = client.SendMessage(upCtx, m.Info.Chat, &waProto.Message{
 		AudioMessage: &waProto.AudioMessage{
 			URL:           pbf.String(up.URL),
 			DirectPath:    pbf.String(up.DirectPath),
@@ -194,7 +216,8 @@ Jangan menyebut kata "voice note".`, h.maxWords)
 			ContextInfo:   ci,
 		},
 	})
-	return true
+	ok = err == nil
+	return true, ok
 }
 
 // ------------------- ElevenLabs -------------------
@@ -206,8 +229,8 @@ func (h *Handler) elevenLabsTTS(ctx context.Context, text string) ([]byte, strin
 
 	// Parameter kualitas & latensi melalui query
 	ep := fmt.Sprintf(
-		"https://api.elevenlabs.io/v1/text-to-speech/%s?output_format=%s&optimize_streaming_latency=%d",
-		h.elVoice, h.outFmt, h.optLatency,
+		"%s/v1/text-to-speech/%s?output_format=%s&optimize_streaming_latency=%d",
+		h.elBase, h.elVoice, h.outFmt, h.optLatency,
 	)
 
 	payload := map[string]any{
@@ -242,7 +265,7 @@ func (h *Handler) elevenLabsTTS(ctx context.Context, text string) ([]byte, strin
 
 // Opsional: GET /v1/voices/{voice_id} → untuk memastikan voice ada (debug)
 func (h *Handler) getVoiceName(ctx context.Context) (string, error) {
-	ep := fmt.Sprintf("https://api.elevenlabs.io/v1/voices/%s", h.elVoice)
+	ep := fmt.Sprintf("%s/v1/voices/%s", h.elBase, h.elVoice)
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	req.Header.Set("xi-api-key", h.elKey)
 	res, err := h.httpc.Do(req)
@@ -266,7 +289,7 @@ func (h *Handler) getVoiceName(ctx context.Context) (string, error) {
 
 // ------------------- helpers -------------------
 
-func (h *Handler) replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func (h *Handler) replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
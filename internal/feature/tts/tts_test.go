@@ -0,0 +1,91 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"wa-elaina/internal/config"
+	"wa-elaina/internal/fakeapi"
+	"wa-elaina/internal/llm"
+	"wa-elaina/internal/trigger"
+	"wa-elaina/internal/wa/watest"
+)
+
+func TestTryHandle(t *testing.T) {
+	cases := []struct {
+		name   string
+		setup  func(s *fakeapi.Suite)
+		audio  bool   // VN terkirim
+		script string // teks yang dikirim ke ElevenLabs memuat ini
+		reply  string // balasan teks memuat ini
+	}{
+		{
+			name:   "berhasil",
+			audio:  true,
+			script: "Halo, aku Elaina (palsu).",
+		},
+		{
+			name:   "Gemini JSON rusak: permintaan dibacakan apa adanya",
+			setup:  func(s *fakeapi.Suite) { s.Gemini.Malformed(1) },
+			audio:  true,
+			script: "selamat pagi",
+		},
+		{
+			name:   "Gemini 429: permintaan dibacakan apa adanya",
+			setup:  func(s *fakeapi.Suite) { s.Gemini.RateLimit(1) },
+			audio:  true,
+			script: "selamat pagi",
+		},
+		{
+			name:  "ElevenLabs 429",
+			setup: func(s *fakeapi.Suite) { s.ElevenLabs.RateLimit(1) },
+			reply: "TTS gagal",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			suite := fakeapi.NewSuite()
+			t.Cleanup(suite.Close)
+			t.Setenv("ELEVEN_API_KEY", "el-key")
+			t.Setenv("ELEVEN_VOICE_ID", "voice1")
+			cfg := config.Config{
+				GeminiKeys:    []string{"k1"},
+				GeminiBaseURL: suite.Gemini.URL,
+				ElevenBaseURL: suite.ElevenLabs.URL,
+				LLMText:       llm.BackendGemini,
+			}
+			llm.Init(cfg)
+			h := New(cfg, trigger.New(nil, "elaina"))
+			if tc.setup != nil {
+				tc.setup(suite)
+			}
+
+			client := watest.NewFakeClient()
+			u := watest.UserJID("6282222222222")
+			if handled, _ := h.TryHandle(context.Background(), client, watest.Text(u, u, "elaina vn selamat pagi"), "elaina vn selamat pagi"); !handled {
+				t.Fatal("TryHandle = false")
+			}
+
+			ups := client.Uploads()
+			if got := len(ups) == 1 && bytes.Equal(ups[0].Data, fakeapi.Audio); got != tc.audio {
+				t.Fatalf("audio terkirim = %t, mau %t (uploads=%d)", got, tc.audio, len(ups))
+			}
+			if tc.script != "" {
+				var body string
+				for _, r := range suite.ElevenLabs.Requests() {
+					if strings.HasPrefix(r.Path, "/v1/text-to-speech/voice1") {
+						body = string(r.Body)
+					}
+				}
+				if !strings.Contains(body, tc.script) {
+					t.Fatalf("naskah ElevenLabs %q tidak memuat %q", body, tc.script)
+				}
+			}
+			if tc.reply != "" && !strings.Contains(strings.Join(client.Texts(), "\n"), tc.reply) {
+				t.Fatalf("balasan %q tidak memuat %q", client.Texts(), tc.reply)
+			}
+		})
+	}
+}
@@ -2,11 +2,9 @@ package vision
 
 import (
 	"context"
-	"regexp"
-	"strings"
+	"log"
 	"time"
 
-	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -14,22 +12,29 @@ import (
 
 	"wa-elaina/internal/config"
 	"wa-elaina/internal/feature/owner"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/llm"
+	"wa-elaina/internal/trigger"
 	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
-	cfg    config.Config
-	reTrig *regexp.Regexp
-	owner  *owner.Detector
+	cfg   config.Config
+	trig  *trigger.Matcher
+	owner *owner.Detector
 }
 
-func New(cfg config.Config, _ *wa.Sender, re *regexp.Regexp, own *owner.Detector) *Handler {
-	return &Handler{cfg: cfg, reTrig: re, owner: own}
+func New(cfg config.Config, _ *wa.Sender, trig *trigger.Matcher, own *owner.Detector) *Handler {
+	return &Handler{cfg: cfg, trig: trig, owner: own}
 }
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, caption string, isOwner bool) bool {
-	// Ambil gambar dari pesan ATAU quoted
+// Matches: ada gambar (langsung/quoted) dan trigger di caption/teks.
+func (h *Handler) Matches(m *events.Message, caption string) bool {
+	return sourceImage(m) != nil && h.trig.Match(m.Info.Chat.String(), caption)
+}
+
+// sourceImage: ambil gambar dari pesan ATAU quoted.
+func sourceImage(m *events.Message) *waProto.ImageMessage {
 	img := m.Message.GetImageMessage()
 	if img == nil {
 		if xt := m.Message.GetExtendedTextMessage(); xt != nil && xt.ContextInfo != nil {
@@ -38,35 +43,56 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, caption
 			}
 		}
 	}
-	if img == nil {
-		return false
-	}
-	// Wajib ada trigger "elaina" di caption/teks pengguna
-	if !h.reTrig.MatchString(caption) {
-		return false
+	return img
+}
+
+// TryHandle: ctx dibatalkan router jika pesan ditarik; jawaban tidak dikirim.
+// ok=false jika gambar/jawaban gagal didapat (balasan berisi pesan error).
+func (h *Handler) TryHandle(parent context.Context, client wa.Client, m *events.Message, caption string, isOwner bool) (handled, ok bool) {
+	// Wajib ada gambar + trigger "elaina" di caption/teks pengguna
+	if !h.Matches(m, caption) {
+		return false, false
 	}
+	img := sourceImage(m)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 60*time.Second)
 	defer cancel()
+	canceled := func() bool {
+		if parent.Err() == nil {
+			return false
+		}
+		log.Printf("[VISION] dibatalkan chat=%s id=%s", m.Info.Chat.String(), m.Info.ID)
+		return true
+	}
 
+	lang := i18n.For(m.Info.Chat.String())
 	blob, err := client.Download(ctx, img)
+	if canceled() {
+		return true, false
+	}
 	if err != nil {
-		replyText(ctx, client, m, "Maaf, gagal mengunduh gambar 😔")
-		return true
+		replyText(ctx, client, m, lang.T("vision.download_failed"))
+		return true, false
 	}
-	prompt := strings.TrimSpace(h.reTrig.ReplaceAllString(caption, ""))
+	prompt := h.trig.Strip(m.Info.Chat.String(), caption)
 	if prompt == "" {
-		prompt = "Tolong jelaskan gambar ini secara ringkas."
+		prompt = lang.T("vision.default_prompt")
+	}
+	system := lang.T("vision.system")
+	reply, err := llm.AskVision(system, prompt, blob, img.GetMimetype())
+	if canceled() {
+		return true, false
+	}
+	if err != nil {
+		reply = llm.Friendly(lang, err)
 	}
-	system := "Kamu Elaina — analis visual cerdas & hangat. Jawab ringkas, akurat, Bahasa Indonesia."
-	reply := llm.AskVision(system, prompt, blob, img.GetMimetype())
 
 	txt, mentions := h.owner.Decorate(isOwner, reply)
 	replyTextMention(ctx, client, m, txt, mentions)
-	return true
+	return true, err == nil
 }
 
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -81,7 +107,7 @@ func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message,
 	})
 }
 
-func replyTextMention(ctx context.Context, client *whatsmeow.Client, m *events.Message, text string, mentions []types.JID) {
+func replyTextMention(ctx context.Context, client wa.Client, m *events.Message, text string, mentions []types.JID) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -0,0 +1,53 @@
+package vn
+
+import (
+	"strings"
+	"unicode"
+)
+
+// fuzzyMatch: kata w (tanpa tanda baca) cukup mirip trigger satu kata.
+// Batas jarak edit mengikuti panjang trigger agar trigger pendek ("bot")
+// tidak ikut mencocokkan kata lain: <4 huruf harus sama persis, <6 huruf
+// boleh beda 1, selebihnya boleh beda 2.
+func fuzzyMatch(trigger, w string) bool {
+	trigger = strings.ToLower(trigger)
+	w = strings.ToLower(strings.TrimFunc(w, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+	if w == "" || strings.ContainsRune(trigger, ' ') {
+		return false
+	}
+	max := 2
+	switch n := len([]rune(trigger)); {
+	case n < 4:
+		max = 0
+	case n < 6:
+		max = 1
+	}
+	return editDistance([]rune(trigger), []rune(w), max) <= max
+}
+
+// editDistance: jarak Levenshtein a-b; berhenti lebih awal (hasil > max)
+// bila selisih panjang saja sudah melebihi max.
+func editDistance(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
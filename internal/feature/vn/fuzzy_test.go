@@ -0,0 +1,28 @@
+package vn
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		trigger, word string
+		want          bool
+	}{
+		{"elaina", "elaina", true},
+		{"elaina", "Elaina,", true},
+		{"elaina", "eleina", true},
+		{"elaina", "elena", true},
+		{"elaina", "elina", true},
+		{"elaina", "selamat", false},
+		{"ela", "ela", true},
+		{"ela", "elo", false},
+		{"bot", "but", false},
+		{"miko", "mika", true},
+		{"miko", "mikasa", false},
+		{"elaina", "", false},
+	}
+	for _, tc := range cases {
+		if got := fuzzyMatch(tc.trigger, tc.word); got != tc.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %t, mau %t", tc.trigger, tc.word, got, tc.want)
+		}
+	}
+}
@@ -2,11 +2,11 @@ package vn
 
 import (
 	"context"
+	"log"
 	"regexp"
 	"strings"
 	"time"
 
-	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -14,24 +14,56 @@ import (
 
 	"wa-elaina/internal/config"
 	"wa-elaina/internal/feature/owner"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/llm"
+	"wa-elaina/internal/trigger"
 	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
-	cfg    config.Config
-	reTrig *regexp.Regexp
-	own    *owner.Detector
+	cfg  config.Config
+	trig *trigger.Matcher
+	own  *owner.Detector
 }
 
-func New(cfg config.Config, _ *wa.Sender, re *regexp.Regexp, own *owner.Detector) *Handler {
-	return &Handler{cfg: cfg, reTrig: re, own: own}
+func New(cfg config.Config, _ *wa.Sender, trig *trigger.Matcher, own *owner.Detector) *Handler {
+	return &Handler{cfg: cfg, trig: trig, own: own}
 }
 
-var reMention = regexp.MustCompile(`(?i)\b(elaina|eleina|elena|elina|ela?ina)\b`)
 var reAskVN = regexp.MustCompile(`(?i)\b(vn|voice\s*note|pesan\s*suara)\b`)
 
-func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, isOwner bool) bool {
+// mentioned: teks menyebut trigger chat, atau kata yang mirip trigger utama
+// chat (salah eja hasil transkrip, mis. "eleina"/"elena" untuk "elaina").
+func (h *Handler) mentioned(chat, txt string) bool {
+	if h.trig.Match(chat, txt) {
+		return true
+	}
+	primary := h.trig.Primary(chat)
+	for _, w := range strings.Fields(txt) {
+		if fuzzyMatch(primary, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) stripMention(chat, txt string) string {
+	txt = h.trig.Strip(chat, txt)
+	primary := h.trig.Primary(chat)
+	var keep []string
+	for _, w := range strings.Fields(txt) {
+		if !fuzzyMatch(primary, w) {
+			keep = append(keep, w)
+		}
+	}
+	return strings.Join(keep, " ")
+}
+
+// TryHandle: ctx dibatalkan router jika pesan ditarik; jawaban tidak dikirim.
+func (h *Handler) TryHandle(parent context.Context, client wa.Client, m *events.Message, isOwner bool) bool {
+	chat := m.Info.Chat.String()
+	lang := i18n.For(chat)
+
 	// 1) Audio di pesan?
 	aud := m.Message.GetAudioMessage()
 
@@ -48,7 +80,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, isOwner
 					} else if xt := m.Message.GetExtendedTextMessage(); xt != nil {
 						txt = xt.GetText()
 					}
-					if !reMention.MatchString(txt) {
+					if !h.mentioned(chat, txt) {
 						return false
 					}
 				}
@@ -64,22 +96,32 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, isOwner
 		} else if xt := m.Message.GetExtendedTextMessage(); xt != nil {
 			txt = xt.GetText()
 		}
-		if reMention.MatchString(txt) && reAskVN.MatchString(txt) {
-			replyText(context.Background(), client, m, "Kirim/Reply **voice note**-nya ya, nanti Elaina transkrip dan jawab ✨")
+		if h.mentioned(chat, txt) && reAskVN.MatchString(txt) {
+			replyText(context.Background(), client, m, lang.T("vn.usage"))
 		}
 		return false
 	}
 
 	// 4) Download & transkrip
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 90*time.Second)
 	defer cancel()
+	canceled := func() bool {
+		if parent.Err() == nil {
+			return false
+		}
+		log.Printf("[VN] dibatalkan chat=%s id=%s", chat, m.Info.ID)
+		return true
+	}
 	blob, err := client.Download(ctx, aud)
+	if canceled() {
+		return true
+	}
 	if err != nil {
-		replyText(ctx, client, m, "Maaf, gagal mengambil voice note 😔")
+		replyText(ctx, client, m, lang.T("vn.download_failed"))
 		return true
 	}
-	tx := llm.Transcribe(blob, strings.ToLower(strings.TrimSpace(aud.GetMimetype())))
-	if strings.TrimSpace(tx) == "" {
+	tx, err := llm.Transcribe(lang, blob, strings.ToLower(strings.TrimSpace(aud.GetMimetype())))
+	if err != nil || strings.TrimSpace(tx) == "" {
 		return true
 	}
 
@@ -90,16 +132,21 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, isOwner
 	} else if xt := m.Message.GetExtendedTextMessage(); xt != nil {
 		userText = xt.GetText()
 	}
-	if !reMention.MatchString(userText) && m.Message.GetAudioMessage() == nil {
+	if !h.mentioned(chat, userText) && m.Message.GetAudioMessage() == nil {
 		return true
 	}
 
-	clean := strings.TrimSpace(reMention.ReplaceAllString(tx, ""))
+	clean := h.stripMention(chat, tx)
 	if clean == "" {
 		clean = tx
 	}
-	system := `Perankan "Elaina", penyihir cerdas & hangat. Bahasa Indonesia, ringkas, ramah.`
-	reply := llm.AskText(system, clean)
+	reply, err := llm.AskText(lang.T("vn.system"), clean)
+	if canceled() {
+		return true
+	}
+	if err != nil {
+		reply = llm.Friendly(lang, err)
+	}
 
 	txtOut, mentions := h.own.Decorate(isOwner, reply)
 	replyTextMention(ctx, client, m, txtOut, mentions)
@@ -107,7 +154,7 @@ func (h *Handler) TryHandle(client *whatsmeow.Client, m *events.Message, isOwner
 }
 
 // ---- reply helpers ----
-func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message, msg string) {
+func replyText(ctx context.Context, client wa.Client, m *events.Message, msg string) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -122,7 +169,7 @@ func replyText(ctx context.Context, client *whatsmeow.Client, m *events.Message,
 	})
 }
 
-func replyTextMention(ctx context.Context, client *whatsmeow.Client, m *events.Message, text string, mentions []types.JID) {
+func replyTextMention(ctx context.Context, client wa.Client, m *events.Message, text string, mentions []types.JID) {
 	ci := &waProto.ContextInfo{
 		StanzaID:      pbf.String(m.Info.ID),
 		QuotedMessage: m.Message,
@@ -7,16 +7,19 @@ import (
 	"reflect"
 	"strings"
 
-	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 
 	waProto "go.mau.fi/whatsmeow/binary/proto"
 	pbf "google.golang.org/protobuf/proto"
+
+	"wa-elaina/internal/access"
+	"wa-elaina/internal/wa"
 )
 
 type Handler struct {
 	enabled bool
 	tmpl    string
+	acl     *access.List
 }
 
 func NewFromEnv() *Handler {
@@ -31,9 +34,17 @@ func NewFromEnv() *Handler {
 	return &Handler{enabled: enabled, tmpl: tmpl}
 }
 
+// UseAccess memasang daftar ban/allow: grup yang diblokir (atau di luar
+// allowlist) tidak disambut, dan user yang di-ban tidak di-mention.
+func (h *Handler) UseAccess(l *access.List) {
+	if h != nil {
+		h.acl = l
+	}
+}
+
 // TryHandle: kompatibel lintas versi (ParticipantsUpdate / GroupParticipantsUpdate)
 // Panggil dari router: wel.TryHandle(client, evt)
-func (h *Handler) TryHandle(cli *whatsmeow.Client, evt interface{}) bool {
+func (h *Handler) TryHandle(cli wa.Client, evt interface{}) bool {
 	if !h.enabled || evt == nil {
 		return false
 	}
@@ -57,7 +68,7 @@ func (h *Handler) TryHandle(cli *whatsmeow.Client, evt interface{}) bool {
 		return false
 	}
 	jid, ok := jidField.Interface().(types.JID)
-	if !ok {
+	if !ok || !h.acl.ChatAllowed(jid) {
 		return false
 	}
 
@@ -89,7 +100,7 @@ func (h *Handler) TryHandle(cli *whatsmeow.Client, evt interface{}) bool {
 					action = strings.ToLower(strings.TrimSpace(toString(af.Interface())))
 				}
 			}
-			if action == "add" {
+			if action == "add" && !h.acl.UserBanned(j) {
 				added = append(added, j)
 			}
 		}
@@ -0,0 +1,53 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"wa-elaina/internal/db"
+)
+
+const (
+	auditDefaultLimit = 50
+	auditMaxLimit     = 500
+)
+
+// AuditSource: pembaca audit log (dipenuhi *db.Store).
+type AuditSource interface {
+	RecentAudit(n int, chat string) ([]db.AuditEntry, error)
+}
+
+// UseAudit memasang sumber audit log agar /audit aktif.
+func (s *Server) UseAudit(src AuditSource) { s.audit = src }
+
+// handleAudit: GET /audit?n=50&chat=<jid> (kunci AUDIT_API_KEY), terbaru dulu.
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	if !keyAuthorized(w, r, s.cfg.AuditAPIKey, "audit endpoint disabled (set AUDIT_API_KEY)") {
+		return
+	}
+	if s.audit == nil {
+		http.Error(w, "audit log not available", http.StatusServiceUnavailable)
+		return
+	}
+	n := auditDefaultLimit
+	if v := r.URL.Query().Get("n"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid 'n'", http.StatusBadRequest)
+			return
+		}
+		n = min(parsed, auditMaxLimit)
+	}
+	entries, err := s.audit.RecentAudit(n, r.URL.Query().Get("chat"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []db.AuditEntry{}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entries)
+}
@@ -0,0 +1,132 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"wa-elaina/internal/broadcast"
+	"wa-elaina/internal/db"
+)
+
+// UseBroadcasts memasang scheduler siaran agar /broadcasts aktif.
+func (s *Server) UseBroadcasts(b *broadcast.Scheduler) { s.bcast = b }
+
+// handleBroadcasts (kunci BROADCAST_API_KEY):
+//
+//	GET    /broadcasts                  → daftar job (JSON)
+//	POST   /broadcasts                  → buat job, body broadcast.Spec (JSON)
+//	POST   /broadcasts?id=N&action=pause|resume|run
+//	DELETE /broadcasts?id=N
+func (s *Server) handleBroadcasts(w http.ResponseWriter, r *http.Request) {
+	if !keyAuthorized(w, r, s.cfg.BroadcastAPIKey, "broadcast endpoint disabled (set BROADCAST_API_KEY)") {
+		return
+	}
+	if s.bcast == nil {
+		http.Error(w, "broadcast scheduler not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet:
+		list, err := s.bcast.List()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if list == nil {
+			list = []db.Broadcast{}
+		}
+		writeJSON(w, http.StatusOK, list)
+
+	case r.Method == http.MethodPost && r.URL.Query().Get("id") == "":
+		var spec broadcast.Spec
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&spec); err != nil {
+			http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		spec.CreatedBy = "api:" + clientIP(r)
+		b, err := s.bcast.Add(spec)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, b)
+
+	case r.Method == http.MethodPost:
+		id, ok := broadcastID(w, r)
+		if !ok {
+			return
+		}
+		switch r.URL.Query().Get("action") {
+		case "pause", "resume":
+			b, err := s.bcast.SetPaused(id, r.URL.Query().Get("action") == "pause")
+			if broadcastErr(w, err) {
+				return
+			}
+			writeJSON(w, http.StatusOK, b)
+		case "run":
+			if !s.ready.Load() {
+				http.Error(w, "WA not ready", http.StatusServiceUnavailable)
+				return
+			}
+			n, err := s.bcast.RunNow(id)
+			if n == 0 && broadcastErr(w, err) {
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"id": id, "sent": n})
+		default:
+			http.Error(w, "need 'action' (pause|resume|run)", http.StatusBadRequest)
+		}
+
+	case r.Method == http.MethodDelete:
+		id, ok := broadcastID(w, r)
+		if !ok {
+			return
+		}
+		removed, err := s.bcast.Delete(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !removed {
+			http.Error(w, broadcast.ErrNotFound.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func broadcastID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid 'id'", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// broadcastErr menulis respons error (true = sudah ditulis).
+func broadcastErr(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, broadcast.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	qrcode "github.com/skip2/go-qrcode"
+
+	"wa-elaina/internal/wa"
+)
+
+// UseLogin memasang state login agar /login/qr.png & /login/status aktif.
+func (s *Server) UseLogin(st *wa.LoginState) { s.login = st }
+
+// loginAuthorized: /login/* selalu butuh kunci (header X-API-Key atau ?key=,
+// karena browser tidak bisa menambah header saat membuka gambar).
+func (s *Server) loginAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	return keyAuthorized(w, r, s.cfg.LoginAPIKey, "login endpoint disabled (set LOGIN_API_KEY)")
+}
+
+// keyAuthorized: kunci wajib diisi (kosong = endpoint nonaktif) dan cocok
+// dengan header X-API-Key atau ?key=.
+func keyAuthorized(w http.ResponseWriter, r *http.Request, key, disabledMsg string) bool {
+	if key == "" {
+		http.Error(w, disabledMsg, http.StatusForbidden)
+		return false
+	}
+	got := r.Header.Get("X-API-Key")
+	if got == "" {
+		got = r.URL.Query().Get("key")
+	}
+	if subtle.ConstantTimeCompare([]byte(got), []byte(key)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleLoginQR(w http.ResponseWriter, r *http.Request) {
+	if !s.loginAuthorized(w, r) {
+		return
+	}
+	snap := s.login.Snapshot()
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Login-State", snap.Status)
+	if snap.QR == "" {
+		http.Error(w, "no active QR (state="+snap.Status+")", http.StatusNotFound)
+		return
+	}
+	png, err := qrcode.Encode(snap.QR, qrcode.Medium, 320)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(png)
+}
+
+func (s *Server) handleLoginStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.loginAuthorized(w, r) {
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.login.Snapshot())
+}
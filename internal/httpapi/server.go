@@ -11,6 +11,7 @@ import (
 
 	"go.mau.fi/whatsmeow/types"
 
+	"wa-elaina/internal/broadcast"
 	"wa-elaina/internal/config"
 	"wa-elaina/internal/wa"
 )
@@ -19,6 +20,9 @@ type Server struct {
 	cfg         config.Config
 	sender      *wa.Sender
 	ready       *atomic.Bool
+	login       *wa.LoginState
+	audit       AuditSource
+	bcast       *broadcast.Scheduler
 	rateCap     int
 	mu          sync.Mutex
 	tokenBucket map[string]*bucket
@@ -46,6 +50,10 @@ func (s *Server) RegisterHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/healthz", s.handleHealthz)
 	mux.HandleFunc("/help", s.handleHelp)
 	mux.HandleFunc("/send", s.handleSend)
+	mux.HandleFunc("/login/qr.png", s.handleLoginQR)
+	mux.HandleFunc("/login/status", s.handleLoginStatus)
+	mux.HandleFunc("/audit", s.handleAudit)
+	mux.HandleFunc("/broadcasts", s.handleBroadcasts)
 }
 
 func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
@@ -56,7 +64,11 @@ func (s *Server) handleHelp(w http.ResponseWriter, r *http.Request) {
 	_, _ = io.WriteString(w, "Endpoints:\n"+
 		"GET /healthz -> ok\n"+
 		"GET /help -> bantuan ini\n"+
-		"POST/GET /send?to=62xxxx&text=... (Header: X-API-Key)\n")
+		"POST/GET /send?to=62xxxx&text=... (Header: X-API-Key)\n"+
+		"GET /login/qr.png?key=... -> QR login saat ini (PNG)\n"+
+		"GET /login/status?key=... -> state login / kode pairing (JSON)\n"+
+		"GET /audit?n=50&chat=...&key=... -> audit log perintah terbaru (JSON)\n"+
+		"GET/POST/DELETE /broadcasts?key=... -> kelola broadcast terjadwal (JSON)\n")
 }
 
 func (s *Server) handleSend(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,366 @@
+package i18n
+
+// enMessages: bundle English. Kunci yang belum diterjemahkan jatuh ke idMessages.
+var enMessages = map[string]string{
+	// ---- !help ----
+	"help.greeting":      "Hi!",
+	"help.greeting_name": "Hi %s!",
+	"help.intro":         "%s Here are the commands you can use:",
+	"help.tips":          "Tip: say \"call me [name]\" so I remember your name!",
+	"help.imggen":        "- {trigger} buatin gambar <prompt> / !gambar <prompt> : generate an AI image",
+	"help.help":          "- !help : short help",
+	"help.whoami":        "- !whoami : show your JID/LID",
+	"help.persona":       "- !elaina persona elaina1|elaina2 : choose the AI persona (persisted)",
+	"help.mode_pro":      "- !elaina mode pro on|off : toggle Pro Mode (persisted)",
+	"help.fitur":         "- !fitur list / !fitur on|off <name> : manage features per chat (admin)",
+	"help.kuota":         "- !kuota / !kuota set <feature> <cooldown> <daily> : view/set feature limits (moderator)",
+	"help.trigger":       "- !trigger / !trigger set <alias1, alias2> : view/set the bot names for this chat (admin)",
+	"help.lang":          "- !lang / !lang id|en : view/set the bot language for this chat (admin)",
+	"help.role":          "- !role / !role grant <role> @user / !role revoke @user : view/manage roles (moderator/co-owner/owner)",
+	"help.ban":           "- !ban @user|number [reason] / !ban chat / !unban ... : block a user or chat (owner/co-owner)",
+	"help.allowgroup":    "- !allowgroup / !allowgroup add|del [group jid] : manage allowed groups (owner/co-owner)",
+	"help.broadcast":     "- !broadcast add <schedule> | <targets> | <text> [| media url] / !broadcast list : recurring broadcasts (owner/co-owner)",
+	"help.audit":         "- !audit [n] : last n commands across all chats (owner)",
+	"help.llmkeys":       "- !llmkeys : LLM API key stats & cooldowns (owner)",
+	"help.rvo":           "- !rvo : reveal a view-once media (reply to it)",
+	"help.tagall":        "- !tagall / {trigger} tagall : mention every group member",
+	"help.reminder":      "- {trigger} remind me tomorrow at 7am meeting / !reminder <time> <message> / !reminder list|hapus <id> : reminders",
+	"help.tiktok":        "- send a TikTok link : download via TikWM",
+	"help.ba":            "- ba / kirim gambar blue archive : Blue Archive picture",
+	"help.hijabin":       "- {trigger} hijabin : add a hijab to a picture (send/quote an image)",
+	"help.brat":          "- {trigger} brat <text> : make a brat sticker",
+	"help.vision":        "- send an image + mention '{trigger}' : describe the image",
+	"help.tts":           "- {trigger} vn <text> : send a voice note",
+	"help.vn":            "- voice note mentioning '{trigger}' : transcribe & answer",
+
+	// ---- perintah inti ----
+	"whoami.name":     "\nSaved name: %s",
+	"persona.invalid": "Invalid persona. Use: elaina1 or elaina2.",
+	"persona.set":     "Persona set to %s for this chat.",
+	"persona.pro_on":  "Pro Mode enabled (persisted).",
+	"persona.pro_off": "Pro Mode disabled (persisted).",
+	"persona.usage":   "Usage: !elaina persona elaina1|elaina2  or  !elaina mode pro on|off",
+
+	"chat.name_saved":    "*Okay! From now on I'll call you %[1]s* ✨\n\n_Nice to meet you, %[1]s!_ I'm Elaina, the beautiful and talented witch~ 🌟",
+	"chat.name_failed":   "*Sorry, something went wrong while saving your name.* Please try again~ 😅",
+	"chat.busy":          "I'm swamped with messages right now and missed your last one. Please send it again in a bit~ 🙏",
+	"chat.reply_context": "%s\n\nContext (the replied message): %s",
+
+	"fitur.usage":       "Usage: !fitur list  or  !fitur on|off <name>",
+	"fitur.admin_only":  "Only group admins or the bot owner can manage features.",
+	"fitur.unknown":     "Unknown feature: %s\nSee the list: !fitur list",
+	"fitur.save_failed": "Failed to save the feature setting: %v",
+	"fitur.enabled":     "Feature %s enabled for this chat.",
+	"fitur.disabled":    "Feature %s disabled for this chat.",
+	"fitur.list_title":  "*Features in this chat:*",
+	"fitur.list_footer": "Change: !fitur on|off <name> (admin/owner)",
+
+	"kuota.usage":        "Usage: !kuota  |  !kuota set <feature> <cooldown> <daily>  |  !kuota reset <feature>\nExample: !kuota set tts 30s 10 (0 = unlimited, owner only)",
+	"kuota.inactive":     "Quotas are not enabled.",
+	"kuota.admin_only":   "Only bot moderators, co-owners or the owner can manage quotas.",
+	"kuota.unlimited":    "Cooldown and daily quota must be greater than 0; only the owner can make a feature unlimited.",
+	"kuota.not_limited":  "Feature has no quota: %s\nConfigurable: %s",
+	"kuota.reset_failed": "Failed to reset the quota: %v",
+	"kuota.reset_done":   "Quota for %s is back to the default.",
+	"kuota.save_failed":  "Failed to save the quota: %v",
+	"kuota.saved":        "Quota for %s in this chat: %s",
+	"kuota.list_title":   "*Feature quotas in this chat:*",
+	"kuota.list_usage":   " (you: %d/%d today)",
+	"kuota.owner_free":   "You're the owner: no quota applies.",
+	"kuota.list_footer":  "Change: !kuota set <feature> <cooldown> <daily> (moderator/owner)",
+	"kuota.no_cooldown":  "no cooldown",
+	"kuota.cooldown":     "cooldown %s",
+	"kuota.no_daily":     "no daily limit",
+	"kuota.daily":        "%d/day",
+	"kuota.wait":         "Hold on, wait %d more seconds before using %s again ⏳",
+	"kuota.exhausted":    "Your daily %s quota is used up (%d/%d). Try again tomorrow ✨",
+
+	"trigger.list":         "*Bot names in this chat:* %s%s\nChange: !trigger set <alias1, alias2> | add <alias> | del <alias> | reset (admin/owner)",
+	"trigger.default_mark": " (default)",
+	"trigger.usage":        "Usage: !trigger  |  !trigger set <alias1, alias2>  |  !trigger add <alias>  |  !trigger del <alias>  |  !trigger reset",
+	"trigger.admin_only":   "Only group admins or the bot owner can change the bot names.",
+	"trigger.invalid":      "%v\nAliases may only contain letters/digits/-/_ (multiple words allowed), separated by commas.",
+	"trigger.not_found":    "Alias %s is not in the list.",
+	"trigger.last":         "At least one alias is required. Use !trigger reset to go back to the default.",
+	"trigger.save_failed":  "Failed to save the bot names: %v",
+	"trigger.saved":        "Okay! In this chat I now answer to: %s ✨",
+	"trigger.reset":        "Bot names are back to the default: %s",
+
+	"lang.current":     "Language for this chat: %s (%s).\nChange: !lang id|en (admin/owner)",
+	"lang.usage":       "Usage: !lang  or  !lang id|en",
+	"lang.unknown":     "Unknown language: %s\nAvailable: %s",
+	"lang.admin_only":  "Only group admins or the bot owner can change the language.",
+	"lang.save_failed": "Failed to save the language: %v",
+	"lang.set":         "Okay, I'll use %s in this chat from now on ✨",
+
+	"role.me":          "Your role: *%s*",
+	"role.usage":       "Usage: !role  |  !role list  |  !role grant <role> @user|number  |  !role revoke @user|number\nRoles: %s",
+	"role.unknown":     "Unknown role: %s\nGrantable: %s",
+	"role.no_target":   "Mention, reply to, or type the number of the user.",
+	"role.self":        "You can't change your own role.",
+	"role.forbidden":   "Your role (%s) is not high enough to change this role.",
+	"role.save_failed": "Failed to save the role: %v",
+	"role.granted":     "@%s is now *%s*.",
+	"role.revoked":     "@%s's role was revoked, back to a regular user.",
+	"role.list_title":  "*Roles:*",
+	"role.list_empty":  "No roles have been granted yet.",
+	"role.list_denied": "Only moderators and above can see the role list.",
+	"role.need":        "This feature is for *%s* and above. Ask the owner/co-owner for an upgrade ✨",
+
+	"access.owner_only":  "This command is for the bot owner/co-owner only.",
+	"access.ban_usage":   "Usage: !ban  |  !ban @user|number [reason]  |  !ban chat [jid]",
+	"access.unban_usage": "Usage: !unban @user|number  |  !unban chat [jid]",
+	"access.allow_usage": "Usage: !allowgroup  |  !allowgroup add [group jid]  |  !allowgroup del [group jid]\nNo jid = this group.",
+	"access.save_failed": "Failed to save the list: %v",
+	"access.staff":       "The owner/co-owners can't be banned.",
+	"access.user_banned": "@%s is blocked, I won't reply to them anymore.",
+	"access.chat_banned": "Chat %s is blocked.",
+	"access.unbanned":    "%s is no longer blocked.",
+	"access.not_banned":  "%s is not on the block list.",
+	"access.ban_title":   "*Block list:*",
+	"access.ban_empty":   "The block list is empty.",
+	"access.chat_label":  "chat %s",
+	"access.allowed":     "Group %s added to the allowlist.",
+	"access.disallowed":  "Group %s removed from the allowlist.",
+	"access.not_allowed": "Group %s is not on the allowlist.",
+	"access.allow_title": "*Group allowlist* (mode: %s)",
+	"access.allow_empty": "(empty)",
+	"access.mode_on":     "on, the bot only replies in listed groups",
+	"access.mode_off":    "off, enable it with ALLOWLIST_ONLY=true",
+
+	"audit.owner_only": "The audit log is for the bot owner only.",
+	"audit.usage":      "Usage: !audit [n] (max %d)",
+	"audit.failed":     "Failed to read the audit log: %v",
+	"audit.empty":      "The audit log is empty.",
+	"audit.title":      "*Last %d commands:*",
+
+	"llmkeys.title":      "*LLM API key health*",
+	"llmkeys.empty":      "No LLM API keys are registered.",
+	"llmkeys.pool":       "\n*%s* (%d keys)",
+	"llmkeys.line":       "%d. `%s` • ok %d • failed %d (quota %d, auth %d) • %s",
+	"llmkeys.active":     "active",
+	"llmkeys.cooldown":   "cooling down until %s",
+	"llmkeys.rejected":   "rejected until %s",
+	"llmkeys.last_error": "   ↳ %s",
+
+	"broadcast.usage":         "Usage:\n!broadcast add <schedule> | <target,...|here> | <text> [| <media url>]\n!broadcast list | hapus <id> | pause <id> | resume <id> | run <id>\n!broadcast catchup <id> skip|once|all\n\nSchedule: 5-field cron (\"0 8 * * 1\" = Monday 08:00), @daily, or \"every 2h\" / \"every 30m\".",
+	"broadcast.invalid":       "Invalid broadcast: %v\nType !broadcast help for the format.",
+	"broadcast.failed":        "Failed to process the broadcast: %v",
+	"broadcast.saved":         "Broadcast #%d saved ✅\nSchedule: %s • %d targets\nNext run: %s",
+	"broadcast.not_found":     "Broadcast #%d not found.",
+	"broadcast.deleted":       "Broadcast #%d deleted.",
+	"broadcast.paused":        "Broadcast #%d paused.",
+	"broadcast.resumed":       "Broadcast #%d resumed, next run %s.",
+	"broadcast.running":       "Sending broadcast #%d now...",
+	"broadcast.ran":           "Broadcast #%d sent to %d targets.",
+	"broadcast.catchup_set":   "Broadcast #%d catch-up: %s.",
+	"broadcast.list_title":    "*Scheduled broadcasts:*",
+	"broadcast.list_empty":    "No broadcasts yet. Create one with !broadcast add ...",
+	"broadcast.list_footer":   "Manage: !broadcast pause|resume|run|hapus <id>",
+	"broadcast.next":          "next %s",
+	"broadcast.status_paused": "⏸ paused",
+
+	"reminder.usage":       "Usage: !reminder <time> <message>  |  !reminder list  |  !reminder hapus <id>\nExample: !reminder tomorrow at 7am meeting",
+	"reminder.no_time":     "I couldn't figure out the time 😅 Example: \"remind me tomorrow at 7am meeting\" or \"in 30 minutes take out the laundry\".",
+	"reminder.past":        "That time (%s) has already passed. Try another time.",
+	"reminder.too_far":     "Reminders can be at most one year ahead.",
+	"reminder.limit":       "You already have %d active reminders. Delete one with !reminder hapus <id> first.",
+	"reminder.save_failed": "Failed to save the reminder: %v",
+	"reminder.saved":       "Okay! Reminder #%d: %s\n📝 %s ⏰",
+	"reminder.no_text":     "(no description)",
+	"reminder.list_title":  "*Your active reminders:*",
+	"reminder.list_empty":  "You have no active reminders.",
+	"reminder.list_footer": "Delete: !reminder hapus <id>",
+	"reminder.deleted":     "Reminder #%d deleted.",
+	"reminder.not_found":   "Reminder #%d not found (or it isn't yours).",
+	"reminder.fire":        "⏰ *Reminder:* %s",
+	"reminder.fire_group":  "⏰ *Reminder* for @%s: %s",
+	"reminder.late":        "\n_(late, was due %s)_",
+
+	// ---- sticker ----
+	"sticker.download_failed": "Download failed: %v",
+	"sticker.no_media":        "No media found to turn into a sticker. Include a URL or reply to an image/video.",
+	"sticker.webp_failed":     "WebP conversion failed: %v",
+	"sticker.send_failed":     "Sending the sticker failed: %v",
+
+	// ---- tts ----
+	"tts.not_configured": "Voice notes are not configured. Set **ELEVEN_API_KEY/ELEVENLABS_API_KEY** and **ELEVEN_VOICE_ID/ELEVENLABS_VOICE_ID** in `.env`, then restart the bot ✨",
+	"tts.usage":          "Type: *%[1]s vn <text>* or reply to a message and type *%[1]s vn* ✨",
+	"tts.failed":         "TTS failed. Make sure the ElevenLabs credentials & voice ID are correct.",
+	"tts.upload_failed":  "Failed to upload the audio 😔",
+
+	// ---- anime ----
+	"anime.menu_title":       "*AnimeKita Menu*",
+	"anime.menu_new":         "anime new [page]       - latest releases",
+	"anime.menu_movie":       "anime movie            - movie list",
+	"anime.menu_schedule":    "anime schedule [day]   - release schedule (e.g. anime schedule selasa)",
+	"anime.menu_list":        "anime list <letter>    - anime by first letter",
+	"anime.menu_genre":       "anime genre <name> [page] - list by genre",
+	"anime.menu_search":      "anime search <keyword> - search anime",
+	"anime.menu_detail":      "anime detail <slug>    - details + chapter list",
+	"anime.menu_episode":     "anime episode <slug> [reso] - streaming links per episode",
+	"anime.menu_footer":      "Use the slug/link from the detail result to fetch an episode.",
+	"anime.new_failed":       "Failed to fetch the latest releases: %v",
+	"anime.new_title":        "Latest releases (page %d)",
+	"anime.movie_failed":     "Failed to fetch the movie list: %v",
+	"anime.movie_title":      "Movie list",
+	"anime.schedule_failed":  "Failed to fetch the schedule: %v",
+	"anime.list_usage":       "Format: anime list <letter>. Example: anime list a",
+	"anime.list_failed":      "Failed to fetch the full list: %v",
+	"anime.genre_usage":      "Format: anime genre <name> [page].\nAvailable genres: %s",
+	"anime.genre_unknown":    "Unknown genre. Pick one of:\n%s",
+	"anime.genre_failed":     "Failed to fetch genre %s: %v",
+	"anime.genre_title":      "Genre %s (page %d)",
+	"anime.search_usage":     "Format: anime search <keywords>",
+	"anime.search_failed":    "Search for \"%s\" failed: %v",
+	"anime.detail_usage":     "Format: anime detail <slug>",
+	"anime.detail_failed":    "Failed to fetch details for %s: %v",
+	"anime.episode_usage":    "Format: anime episode <slug> [reso]. Use a slug from the detail chapter list.",
+	"anime.episode_failed":   "Failed to fetch streams for %s (%s): %v",
+	"anime.episode_loading":  "⏳ Fetching streams for %s (%s)...",
+	"anime.pixeldrain_note":  "\n%s will try to download the Pixeldrain links automatically.",
+	"anime.pixeldrain_fail":  "Pixeldrain download %s failed: %v",
+	"anime.pixeldrain_step":  "⏳ Downloading Pixeldrain %s (%d/%d)...",
+	"anime.pixeldrain_done":  "✅ Pixeldrain: %d/%d files sent.",
+	"anime.empty":            "%s is empty.",
+	"anime.untitled":         "Untitled",
+	"anime.more_entries":     "... %d more entries.",
+	"anime.more_results":     "... %d more results.",
+	"anime.more_short":       "  ... %d more",
+	"anime.hint_detail":      "Details: anime detail <slug>",
+	"anime.hint_info":        "Full info: anime detail <slug>",
+	"anime.hint_letter":      "Use anime detail <slug> for info.",
+	"anime.schedule_empty":   "The schedule is empty.",
+	"anime.schedule_title":   "*Release Schedule*",
+	"anime.schedule_none":    "No schedule for \"%s\".",
+	"anime.letter_symbol":    "No list for the # symbol.",
+	"anime.letter_none":      "No anime starting with %s.",
+	"anime.letter_title":     "*Letter %s*",
+	"anime.search_none":      "No results for \"%s\".",
+	"anime.search_title":     "*Search results for \"%s\"*",
+	"anime.detail_empty":     "No detail data.",
+	"anime.detail_released":  "Released: %s",
+	"anime.detail_synopsis":  "Synopsis:",
+	"anime.detail_chapters":  "Latest chapters:",
+	"anime.no_data":          "- No data yet.",
+	"anime.more_episodes":    "... %d more episodes in the API.",
+	"anime.hint_stream":      "Get streams: anime episode <slug> [reso]",
+	"anime.episode_none":     "No data for %s.",
+	"anime.episode_resos":    "Available resolutions: ",
+	"anime.episode_links":    "Streaming links:",
+	"anime.episode_no_links": "- No links yet.",
+	"anime.more_links":       "... %d more links in the API.",
+
+	// ---- tiktok ----
+	"tiktok.fetching":          "⏳ Fetching TikTok media...",
+	"tiktok.fetch_failed":      "Sorry, I couldn't fetch the TikTok media. Please send it again.",
+	"tiktok.slide_step":        "⏳ Sending slide %d/%d...",
+	"tiktok.slides_done":       "✅ TikTok: %d/%d slides sent.",
+	"tiktok.slides_failed":     "❌ Failed to download the TikTok slides.",
+	"tiktok.video_step":        "⏳ Downloading the TikTok video...",
+	"tiktok.video_done":        "✅ TikTok video sent.",
+	"tiktok.audio":             "🔊 Audio: %s",
+	"tiktok.no_media":          "Sorry, no valid media found in that TikTok.",
+	"tiktok.slide_caption":     "TikTok 🖼️ slide %d/%d",
+	"tiktok.slide_doc_caption": "TikTok 🖼️ slide %d/%d (document)",
+	"tiktok.video_caption":     "TikTok 🎬",
+	"tiktok.video_doc_caption": "TikTok 🎬 (document)",
+
+	// ---- imggen ----
+	"imggen.empty_prompt":  "Image prompt is empty. Example: %s buatin gambar a cute cat",
+	"imggen.failed":        "Failed to generate the image. Every API key is rate-limited or failing.",
+	"imggen.upload_failed": "Failed to upload the image",
+	"imggen.caption":       "🎨 Generated: %s",
+
+	// ---- hijabin ----
+	"hijabin.bad_format":      "Unsupported format. Send/reply a **jpeg/jpg/png** image ✨",
+	"hijabin.download_failed": "Failed to download the image 😔",
+	"hijabin.not_configured":  "The hijabin feature isn't configured. Set **HIJABIN_API_URL** (plus KEY if needed) *or* **GEMINI_API_KEY/GEMINI_KEYS** in `.env`.",
+	"hijabin.failed":          "Failed to process hijabin. Please try again ✨",
+	"hijabin.upload_failed":   "Failed to upload the result image.",
+	"hijabin.caption":         "*Done, the picture now wears a hijab.*",
+
+	// ---- vision ----
+	"vision.download_failed": "Sorry, failed to download the image 😔",
+	"vision.default_prompt":  "Please describe this image briefly.",
+	"vision.system":          "You are Elaina — a smart, warm visual analyst. Answer briefly and accurately, in English.",
+
+	// ---- pap ----
+	"pap.caption": "Pap from %s",
+	"pap.failed":  "Sorry, I can't send a pap right now.",
+
+	// ---- baimg ----
+	"baimg.index_empty":     "The Blue Archive index is empty or failed to load.",
+	"baimg.unknown":         "Sorry, that character isn't in my BA index yet.",
+	"baimg.download_failed": "Failed to download the BA image.",
+	"baimg.download_status": "Failed to download the BA image: %s",
+	"baimg.upload_failed":   "Failed to upload the BA image.",
+
+	// ---- tagall ----
+	"tagall.hello": "👋 Hello everyone, please check in!",
+
+	// ---- rvo ----
+	"rvo.usage":           "Reply to a *view-once* photo/video and type *%s rvo* ✨",
+	"rvo.not_view_once":   "The replied message isn't *view-once* media (photo/video).",
+	"rvo.download_failed": "Failed to download the view-once media 😔",
+	"rvo.unsupported":     "Unsupported media type.",
+	"rvo.upload_failed":   "Failed to re-upload the media 😔",
+
+	// ---- vn ----
+	"vn.usage":           "Send/reply with the **voice note** and Elaina will transcribe and answer it ✨",
+	"vn.download_failed": "Sorry, failed to fetch the voice note 😔",
+	"vn.system":          `Play "Elaina", a smart and warm witch. Reply in English, briefly and kindly.`,
+
+	// ---- koneksi WhatsApp (laporan ke owner) ----
+	"wa.reconnected": "⚠️ Elaina is connected again.\nPrevious disconnect reasons:\n- %s",
+
+	// ---- peraturan ----
+	"peraturan.no_apikey":          "PERATURAN_APIKEY is not set.",
+	"peraturan.group_only":         "The peraturan command only works in groups.",
+	"peraturan.admin_only":         "Only group admins or the bot owner can manage the rules feature.",
+	"peraturan.usage":              "Usage: !peraturan on|off|sync|status|rules|clear @user\nTo reduce a warn: mention the bot, write `saya mau mengurangi warn` and say `subhanallah` exactly 5 times.",
+	"peraturan.unknown":            "Unknown command. Usage: !peraturan on|off|sync|status|rules|clear @user\nReducing a warn: mention the bot + \"saya mau mengurangi warn\" + `subhanallah` x5.",
+	"peraturan.default_reason":     "Broke the group rules.",
+	"peraturan.warn":               "*Warning %d/%d for @%s*\nReason: %s",
+	"peraturan.kicked":             "*@%s was removed for exceeding the warning limit.*",
+	"peraturan.warn_zero":          "*@%s, your warns are back to 0. Keep it up.*",
+	"peraturan.warn_reduced":       "*Your warns dropped to %d/%d.*",
+	"peraturan.group_info_failed":  "Failed to fetch group info: %v",
+	"peraturan.desc_empty_enable":  "The group description is empty. Put the rules in the description first.",
+	"peraturan.save_failed":        "Failed to save the rules: %v",
+	"peraturan.enabled":            "Rules feature enabled.\n%d rule lines saved.",
+	"peraturan.already_off":        "The rules feature is already off.",
+	"peraturan.disable_failed":     "Failed to disable: %v",
+	"peraturan.disabled":           "Rules feature disabled.",
+	"peraturan.desc_empty":         "The group description is empty.",
+	"peraturan.sync_failed":        "Failed to sync the rules: %v",
+	"peraturan.synced":             "Rules updated from the group description.",
+	"peraturan.status_failed":      "Failed to load the status: %v",
+	"peraturan.status_on":          "Enabled",
+	"peraturan.status_off":         "Disabled",
+	"peraturan.status":             "*Status:* %s",
+	"peraturan.status_rules":       "*Saved rules:* ",
+	"peraturan.status_more":        "... (%d more lines)",
+	"peraturan.status_warns":       "*Top warns:*",
+	"peraturan.rules_failed":       "Failed to load the rules: %v",
+	"peraturan.no_rules":           "No rules saved yet.",
+	"peraturan.rules":              "*Group rules:*\n%s",
+	"peraturan.clear_need_mention": "Mention the user whose warns you want to clear.",
+	"peraturan.clear_failed":       "Failed to clear warns: %v",
+	"peraturan.cleared":            "Warns for %s have been reset.",
+
+	// ---- instruksi bahasa untuk persona LLM ----
+	"llm.answer_in": "LANGUAGE: Always reply in English, even if the persona description above is written in another language or the user writes in another language. Keep the persona's personality and WhatsApp formatting.",
+
+	// ---- prompt sistem LLM ----
+	"llm.transcribe":    "Transcribe the audio into clean English.",
+	"llm.elaina_system": `Play "Elaina", a smart and warm witch. Reply in English, casual but polite, few emoji.`,
+	"llm.user_name":     "EXTRA INFO: The user you are talking to is called %s. Use this name naturally in the conversation, especially when greeting or replying.",
+
+	// ---- error LLM (detail mentah hanya di log) ----
+	"llm.err_quota":   "My magic is running low right now~ 🪄 Give me a moment and try again.",
+	"llm.err_blocked": "Hmm, that's something Elaina can't answer 🙊 Try asking another way.",
+	"llm.err_timeout": "My spell took too long to cast ⏳ Please send it again in a bit.",
+	"llm.err_empty":   "Elaina was lost for words just now 😅 Please ask again.",
+	"llm.err_failed":  "Sorry, Elaina's magic is acting up 😔 Please try again later.",
+}
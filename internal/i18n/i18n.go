@@ -0,0 +1,107 @@
+// Package i18n menyimpan katalog pesan yang dilihat pengguna (Indonesia &
+// Inggris) serta bahasa per chat yang disetel lewat !lang.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Lang adalah kode bahasa katalog ("id", "en").
+type Lang string
+
+const (
+	ID Lang = "id"
+	EN Lang = "en"
+
+	// Default dipakai bila chat belum memilih bahasa atau kunci tidak ada.
+	Default = ID
+)
+
+var bundles = map[Lang]map[string]string{
+	ID: idMessages,
+	EN: enMessages,
+}
+
+// names: nama bahasa untuk ditampilkan & instruksi ke LLM.
+var names = map[Lang]string{
+	ID: "Bahasa Indonesia",
+	EN: "English",
+}
+
+// Parse menerima kode atau nama bahasa ("en", "english", "inggris", ...).
+func Parse(s string) (Lang, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "id", "ind", "indonesia", "indonesian", "bahasa":
+		return ID, true
+	case "en", "eng", "english", "inggris":
+		return EN, true
+	}
+	return "", false
+}
+
+// Supported mengembalikan kode bahasa yang tersedia (urut).
+func Supported() []string {
+	out := make([]string, 0, len(bundles))
+	for l := range bundles {
+		out = append(out, string(l))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Name: nama bahasa yang bisa dibaca manusia.
+func (l Lang) Name() string {
+	if n, ok := names[l]; ok {
+		return n
+	}
+	return names[Default]
+}
+
+// T menerjemahkan key ke bahasa l. Urutan fallback: bahasa l → Indonesia →
+// key itu sendiri (agar teks literal lama tetap tampil apa adanya).
+func (l Lang) T(key string, args ...any) string {
+	msg, ok := bundles[l][key]
+	if !ok {
+		if msg, ok = bundles[Default][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// T adalah singkatan Lang(l).T untuk kode bahasa mentah.
+func T(l Lang, key string, args ...any) string { return l.T(key, args...) }
+
+// ---- bahasa per chat ----
+
+var (
+	mu       sync.RWMutex
+	resolver func(chat string) Lang
+)
+
+// SetResolver memasang sumber bahasa per chat (router: db.Store + cache).
+func SetResolver(fn func(chat string) Lang) {
+	mu.Lock()
+	resolver = fn
+	mu.Unlock()
+}
+
+// For mengembalikan bahasa chat; Default bila belum ada resolver.
+func For(chat string) Lang {
+	mu.RLock()
+	fn := resolver
+	mu.RUnlock()
+	if fn == nil {
+		return Default
+	}
+	if l := fn(chat); l != "" {
+		return l
+	}
+	return Default
+}
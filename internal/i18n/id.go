@@ -0,0 +1,367 @@
+package i18n
+
+// idMessages adalah bundle bawaan (Bahasa Indonesia). Setiap kunci baru wajib
+// ada di sini; bundle lain boleh belum lengkap (fallback ke sini).
+var idMessages = map[string]string{
+	// ---- !help ----
+	"help.greeting":      "Hai!",
+	"help.greeting_name": "Hai %s!",
+	"help.intro":         "%s Ini perintah yang bisa kamu gunakan:",
+	"help.tips":          "Tips: katakan \"panggil aku [nama]\" supaya aku ingat namamu!",
+	"help.imggen":        "- {trigger} buatin gambar <prompt> / !gambar <prompt> : generate gambar AI",
+	"help.help":          "- !help : bantuan ringkas",
+	"help.whoami":        "- !whoami : lihat JID/LID kamu",
+	"help.persona":       "- !elaina persona elaina1|elaina2 : pilih persona AI (persist)",
+	"help.mode_pro":      "- !elaina mode pro on|off : aktifkan Mode Pro (persist)",
+	"help.fitur":         "- !fitur list / !fitur on|off <nama> : atur fitur per chat (admin)",
+	"help.kuota":         "- !kuota / !kuota set <fitur> <cooldown> <harian> : lihat/atur batas fitur (moderator)",
+	"help.trigger":       "- !trigger / !trigger set <alias1, alias2> : lihat/atur nama panggilan bot di chat ini (admin)",
+	"help.lang":          "- !lang / !lang id|en : lihat/atur bahasa bot di chat ini (admin)",
+	"help.role":          "- !role / !role grant <role> @user / !role revoke @user : lihat/atur role (moderator/co-owner/owner)",
+	"help.ban":           "- !ban @user|nomor [alasan] / !ban chat / !unban ... : blokir user atau chat (owner/co-owner)",
+	"help.allowgroup":    "- !allowgroup / !allowgroup add|del [jid grup] : atur grup yang diizinkan (owner/co-owner)",
+	"help.broadcast":     "- !broadcast add <jadwal> | <target> | <teks> [| url media] / !broadcast list : siaran berulang (owner/co-owner)",
+	"help.audit":         "- !audit [n] : n perintah terakhir di semua chat (owner)",
+	"help.llmkeys":       "- !llmkeys : statistik & cooldown API key LLM (owner)",
+	"help.rvo":           "- !rvo : buka media sekali lihat (reply ke pesannya)",
+	"help.tagall":        "- !tagall / {trigger} tagall : mention semua anggota grup",
+	"help.reminder":      "- {trigger} ingatkan aku besok jam 7 meeting / !reminder <waktu> <pesan> / !reminder list|hapus <id> : pengingat",
+	"help.tiktok":        "- kirim link TikTok : unduh via TikWM",
+	"help.ba":            "- ba / kirim gambar blue archive : gambar BA",
+	"help.hijabin":       "- {trigger} hijabin : berhijabkan gambar (kirim/quote gambar)",
+	"help.brat":          "- {trigger} brat <teks> : buat sticker brat",
+	"help.vision":        "- kirim gambar + sebut '{trigger}' : analisis gambar",
+	"help.tts":           "- {trigger} vn <teks> : kirim voice note",
+	"help.vn":            "- vn sebut '{trigger}' : transkrip & jawab",
+
+	// ---- perintah inti ----
+	"whoami.name":     "\nNama tersimpan: %s",
+	"persona.invalid": "Persona tidak valid. Gunakan: elaina1 atau elaina2.",
+	"persona.set":     "Persona disetel ke %s untuk chat ini.",
+	"persona.pro_on":  "Mode Pro diaktifkan (persist).",
+	"persona.pro_off": "Mode Pro dimatikan (persist).",
+	"persona.usage":   "Gunakan: !elaina persona elaina1|elaina2  atau  !elaina mode pro on|off",
+
+	"chat.name_saved":    "*Oke! Mulai sekarang aku akan memanggilmu %[1]s* ✨\n\n_Senang berkenalan denganmu, %[1]s!_ Aku Elaina, penyihir cantik dan berbakat~ 🌟",
+	"chat.name_failed":   "*Maaf, ada masalah saat menyimpan namamu.* Coba lagi ya~ 😅",
+	"chat.busy":          "Aku lagi kebanjiran pesan, pesanmu barusan terlewat. Coba kirim lagi sebentar lagi ya~ 🙏",
+	"chat.reply_context": "%s\n\nKonteks (pesan yang di-reply): %s",
+
+	"fitur.usage":       "Gunakan: !fitur list  atau  !fitur on|off <nama>",
+	"fitur.admin_only":  "Hanya admin grup atau owner bot yang bisa mengatur fitur.",
+	"fitur.unknown":     "Fitur tidak dikenal: %s\nLihat daftar: !fitur list",
+	"fitur.save_failed": "Gagal menyimpan pengaturan fitur: %v",
+	"fitur.enabled":     "Fitur %s diaktifkan untuk chat ini.",
+	"fitur.disabled":    "Fitur %s dimatikan untuk chat ini.",
+	"fitur.list_title":  "*Fitur di chat ini:*",
+	"fitur.list_footer": "Ubah: !fitur on|off <nama> (admin/owner)",
+
+	"kuota.usage":        "Gunakan: !kuota  |  !kuota set <fitur> <cooldown> <harian>  |  !kuota reset <fitur>\nContoh: !kuota set tts 30s 10 (0 = tanpa batas, khusus owner)",
+	"kuota.inactive":     "Fitur kuota belum aktif.",
+	"kuota.admin_only":   "Hanya moderator bot, co-owner, atau owner yang bisa mengatur kuota.",
+	"kuota.unlimited":    "Cooldown & kuota harian wajib lebih dari 0; hanya owner yang bisa membuat fitur tanpa batas.",
+	"kuota.not_limited":  "Fitur tanpa kuota: %s\nYang bisa diatur: %s",
+	"kuota.reset_failed": "Gagal mereset kuota: %v",
+	"kuota.reset_done":   "Kuota %s kembali ke bawaan.",
+	"kuota.save_failed":  "Gagal menyimpan kuota: %v",
+	"kuota.saved":        "Kuota %s untuk chat ini: %s",
+	"kuota.list_title":   "*Kuota fitur di chat ini:*",
+	"kuota.list_usage":   " (kamu: %d/%d hari ini)",
+	"kuota.owner_free":   "Kamu owner: bebas kuota.",
+	"kuota.list_footer":  "Ubah: !kuota set <fitur> <cooldown> <harian> (moderator/owner)",
+	"kuota.no_cooldown":  "tanpa cooldown",
+	"kuota.cooldown":     "cooldown %s",
+	"kuota.no_daily":     "tanpa batas harian",
+	"kuota.daily":        "%d/hari",
+	"kuota.wait":         "Sabar ya, tunggu %d detik lagi sebelum pakai %s lagi ⏳",
+	"kuota.exhausted":    "Kuota harian %s kamu sudah habis (%d/%d). Coba lagi besok ya ✨",
+
+	"trigger.list":         "*Nama panggilan bot di chat ini:* %s%s\nUbah: !trigger set <alias1, alias2> | add <alias> | del <alias> | reset (admin/owner)",
+	"trigger.default_mark": " (bawaan)",
+	"trigger.usage":        "Gunakan: !trigger  |  !trigger set <alias1, alias2>  |  !trigger add <alias>  |  !trigger del <alias>  |  !trigger reset",
+	"trigger.admin_only":   "Hanya admin grup atau owner bot yang bisa mengatur nama panggilan.",
+	"trigger.invalid":      "%v\nAlias hanya boleh huruf/angka/-/_ (boleh beberapa kata), dipisah koma.",
+	"trigger.not_found":    "Alias %s tidak ada di daftar.",
+	"trigger.last":         "Minimal harus ada satu alias. Pakai !trigger reset untuk kembali ke bawaan.",
+	"trigger.save_failed":  "Gagal menyimpan nama panggilan: %v",
+	"trigger.saved":        "Oke! Di chat ini aku sekarang dipanggil: %s ✨",
+	"trigger.reset":        "Nama panggilan kembali ke bawaan: %s",
+
+	"lang.current":     "Bahasa chat ini: %s (%s).\nUbah: !lang id|en (admin/owner)",
+	"lang.usage":       "Gunakan: !lang  atau  !lang id|en",
+	"lang.unknown":     "Bahasa tidak dikenal: %s\nTersedia: %s",
+	"lang.admin_only":  "Hanya admin grup atau owner bot yang bisa mengatur bahasa.",
+	"lang.save_failed": "Gagal menyimpan bahasa: %v",
+	"lang.set":         "Oke, mulai sekarang aku pakai %s di chat ini ✨",
+
+	"role.me":          "Role kamu: *%s*",
+	"role.usage":       "Gunakan: !role  |  !role list  |  !role grant <role> @user|nomor  |  !role revoke @user|nomor\nRole: %s",
+	"role.unknown":     "Role tidak dikenal: %s\nYang bisa diberikan: %s",
+	"role.no_target":   "Sebut (tag), reply, atau tulis nomor user yang dimaksud.",
+	"role.self":        "Tidak bisa mengubah role sendiri.",
+	"role.forbidden":   "Role kamu (%s) tidak cukup untuk mengubah role ini.",
+	"role.save_failed": "Gagal menyimpan role: %v",
+	"role.granted":     "Role @%s sekarang *%s*.",
+	"role.revoked":     "Role @%s dicabut, kembali jadi user biasa.",
+	"role.list_title":  "*Daftar role:*",
+	"role.list_empty":  "Belum ada role yang diberikan.",
+	"role.list_denied": "Hanya moderator ke atas yang bisa melihat daftar role.",
+	"role.need":        "Fitur ini khusus role *%s* ke atas. Minta owner/co-owner untuk upgrade ya ✨",
+
+	"access.owner_only":  "Perintah ini khusus owner/co-owner bot.",
+	"access.ban_usage":   "Gunakan: !ban  |  !ban @user|nomor [alasan]  |  !ban chat [jid]",
+	"access.unban_usage": "Gunakan: !unban @user|nomor  |  !unban chat [jid]",
+	"access.allow_usage": "Gunakan: !allowgroup  |  !allowgroup add [jid grup]  |  !allowgroup del [jid grup]\nTanpa jid = grup ini.",
+	"access.save_failed": "Gagal menyimpan daftar: %v",
+	"access.staff":       "Owner/co-owner tidak bisa di-ban.",
+	"access.user_banned": "@%s diblokir, aku tidak akan membalas pesannya lagi.",
+	"access.chat_banned": "Chat %s diblokir.",
+	"access.unbanned":    "%s sudah tidak diblokir.",
+	"access.not_banned":  "%s tidak ada di daftar blokir.",
+	"access.ban_title":   "*Daftar blokir:*",
+	"access.ban_empty":   "Daftar blokir kosong.",
+	"access.chat_label":  "chat %s",
+	"access.allowed":     "Grup %s ditambahkan ke allowlist.",
+	"access.disallowed":  "Grup %s dihapus dari allowlist.",
+	"access.not_allowed": "Grup %s tidak ada di allowlist.",
+	"access.allow_title": "*Allowlist grup* (mode: %s)",
+	"access.allow_empty": "(kosong)",
+	"access.mode_on":     "aktif, bot hanya menjawab di grup terdaftar",
+	"access.mode_off":    "nonaktif, nyalakan dengan ALLOWLIST_ONLY=true",
+
+	"audit.owner_only": "Audit log khusus owner bot.",
+	"audit.usage":      "Gunakan: !audit [n] (maks %d)",
+	"audit.failed":     "Gagal membaca audit log: %v",
+	"audit.empty":      "Audit log masih kosong.",
+	"audit.title":      "*%d perintah terakhir:*",
+
+	"llmkeys.title":      "*Kesehatan API key LLM*",
+	"llmkeys.empty":      "Belum ada API key LLM yang terdaftar.",
+	"llmkeys.pool":       "\n*%s* (%d key)",
+	"llmkeys.line":       "%d. `%s` • ok %d • gagal %d (kuota %d, auth %d) • %s",
+	"llmkeys.active":     "aktif",
+	"llmkeys.cooldown":   "cooldown s/d %s",
+	"llmkeys.rejected":   "ditolak s/d %s",
+	"llmkeys.last_error": "   ↳ %s",
+
+	"broadcast.usage":         "Gunakan:\n!broadcast add <jadwal> | <target,...|sini> | <teks> [| <url media>]\n!broadcast list | hapus <id> | pause <id> | resume <id> | run <id>\n!broadcast catchup <id> skip|once|all\n\nJadwal: cron 5 kolom (\"0 8 * * 1\" = Senin 08:00), @daily, atau \"tiap 2 jam\" / \"every 30m\".",
+	"broadcast.invalid":       "Broadcast tidak valid: %v\nKetik !broadcast help untuk format.",
+	"broadcast.failed":        "Gagal memproses broadcast: %v",
+	"broadcast.saved":         "Broadcast #%d disimpan ✅\nJadwal: %s • %d target\nJalan berikutnya: %s",
+	"broadcast.not_found":     "Broadcast #%d tidak ditemukan.",
+	"broadcast.deleted":       "Broadcast #%d dihapus.",
+	"broadcast.paused":        "Broadcast #%d dijeda.",
+	"broadcast.resumed":       "Broadcast #%d dilanjutkan, jalan berikutnya %s.",
+	"broadcast.running":       "Mengirim broadcast #%d sekarang...",
+	"broadcast.ran":           "Broadcast #%d terkirim ke %d target.",
+	"broadcast.catchup_set":   "Catch-up broadcast #%d: %s.",
+	"broadcast.list_title":    "*Broadcast terjadwal:*",
+	"broadcast.list_empty":    "Belum ada broadcast. Buat dengan !broadcast add ...",
+	"broadcast.list_footer":   "Kelola: !broadcast pause|resume|run|hapus <id>",
+	"broadcast.next":          "berikutnya %s",
+	"broadcast.status_paused": "⏸ dijeda",
+
+	"reminder.usage":       "Gunakan: !reminder <waktu> <pesan>  |  !reminder list  |  !reminder hapus <id>\nContoh: !reminder besok jam 7 meeting",
+	"reminder.no_time":     "Aku belum paham waktunya 😅 Contoh: \"ingatkan aku besok jam 7 meeting\" atau \"30 menit lagi angkat jemuran\".",
+	"reminder.past":        "Waktu itu (%s) sudah lewat. Coba waktu lain ya.",
+	"reminder.too_far":     "Pengingat maksimal setahun ke depan ya.",
+	"reminder.limit":       "Kamu sudah punya %d pengingat aktif. Hapus dulu dengan !reminder hapus <id>.",
+	"reminder.save_failed": "Gagal menyimpan pengingat: %v",
+	"reminder.saved":       "Oke! Pengingat #%d: %s\n📝 %s ⏰",
+	"reminder.no_text":     "(tanpa keterangan)",
+	"reminder.list_title":  "*Pengingat aktifmu:*",
+	"reminder.list_empty":  "Kamu belum punya pengingat aktif.",
+	"reminder.list_footer": "Hapus: !reminder hapus <id>",
+	"reminder.deleted":     "Pengingat #%d dihapus.",
+	"reminder.not_found":   "Pengingat #%d tidak ditemukan (atau bukan milikmu).",
+	"reminder.fire":        "⏰ *Pengingat:* %s",
+	"reminder.fire_group":  "⏰ *Pengingat* untuk @%s: %s",
+	"reminder.late":        "\n_(terlambat, seharusnya %s)_",
+
+	// ---- sticker ----
+	"sticker.download_failed": "Gagal unduh: %v",
+	"sticker.no_media":        "Tidak menemukan media untuk dijadikan sticker. Sertakan URL atau reply gambar/video.",
+	"sticker.webp_failed":     "Konversi WebP gagal: %v",
+	"sticker.send_failed":     "Kirim sticker gagal: %v",
+
+	// ---- tts ----
+	"tts.not_configured": "Fitur VN belum dikonfigurasi. Set **ELEVEN_API_KEY/ELEVENLABS_API_KEY** dan **ELEVEN_VOICE_ID/ELEVENLABS_VOICE_ID** di `.env`, lalu restart bot ✨",
+	"tts.usage":          "Tulis: *%[1]s vn <teks>* atau reply pesan lalu ketik *%[1]s vn* ya ✨",
+	"tts.failed":         "TTS gagal. Pastikan kredensial ElevenLabs & voice ID benar.",
+	"tts.upload_failed":  "Gagal mengunggah audio 😔",
+
+	// ---- anime ----
+	"anime.menu_title":       "*Menu AnimeKita*",
+	"anime.menu_new":         "anime new [page]       - rilisan baru",
+	"anime.menu_movie":       "anime movie            - daftar movie",
+	"anime.menu_schedule":    "anime schedule [hari]  - jadwal rilis (contoh: anime schedule selasa)",
+	"anime.menu_list":        "anime list <huruf>     - daftar anime per awal huruf",
+	"anime.menu_genre":       "anime genre <nama> [page] - daftar berdasarkan genre",
+	"anime.menu_search":      "anime search <keyword> - cari anime",
+	"anime.menu_detail":      "anime detail <slug>    - info detail + daftar chapter",
+	"anime.menu_episode":     "anime episode <slug> [reso] - link streaming per episode",
+	"anime.menu_footer":      "Gunakan slug/link dari hasil detail untuk mengambil episode.",
+	"anime.new_failed":       "Gagal ambil data rilis terbaru: %v",
+	"anime.new_title":        "Rilisan terbaru (page %d)",
+	"anime.movie_failed":     "Gagal ambil daftar movie: %v",
+	"anime.movie_title":      "Daftar movie",
+	"anime.schedule_failed":  "Gagal ambil jadwal: %v",
+	"anime.list_usage":       "Format: anime list <huruf>. Contoh: anime list a",
+	"anime.list_failed":      "Gagal ambil daftar lengkap: %v",
+	"anime.genre_usage":      "Format: anime genre <nama> [page].\nGenre tersedia: %s",
+	"anime.genre_unknown":    "Genre tidak dikenal. Pilih salah satu dari:\n%s",
+	"anime.genre_failed":     "Gagal ambil daftar genre %s: %v",
+	"anime.genre_title":      "Genre %s (page %d)",
+	"anime.search_usage":     "Format: anime search <kata kunci>",
+	"anime.search_failed":    "Gagal mencari \"%s\": %v",
+	"anime.detail_usage":     "Format: anime detail <slug>",
+	"anime.detail_failed":    "Gagal ambil detail untuk %s: %v",
+	"anime.episode_usage":    "Format: anime episode <slug> [reso]. Gunakan slug dari daftar chapter detail.",
+	"anime.episode_failed":   "Gagal ambil stream untuk %s (%s): %v",
+	"anime.episode_loading":  "⏳ Mengambil stream %s (%s)...",
+	"anime.pixeldrain_note":  "\n%s akan mencoba unduh tautan Pixeldrain otomatis.",
+	"anime.pixeldrain_fail":  "Gagal unduh Pixeldrain %s: %v",
+	"anime.pixeldrain_step":  "⏳ Mengunduh Pixeldrain %s (%d/%d)...",
+	"anime.pixeldrain_done":  "✅ Pixeldrain: %d/%d file terkirim.",
+	"anime.empty":            "%s kosong.",
+	"anime.untitled":         "Tanpa judul",
+	"anime.more_entries":     "... %d entri lainnya.",
+	"anime.more_results":     "... %d hasil lainnya.",
+	"anime.more_short":       "  ... %d lainnya",
+	"anime.hint_detail":      "Detail: anime detail <slug>",
+	"anime.hint_info":        "Ambil info lengkap: anime detail <slug>",
+	"anime.hint_letter":      "Gunakan anime detail <slug> untuk info.",
+	"anime.schedule_empty":   "Jadwal kosong.",
+	"anime.schedule_title":   "*Jadwal Rilis*",
+	"anime.schedule_none":    "Tidak ada jadwal untuk \"%s\".",
+	"anime.letter_symbol":    "Tidak ada daftar untuk simbol #.",
+	"anime.letter_none":      "Tidak ada anime yang diawali huruf %s.",
+	"anime.letter_title":     "*Daftar huruf %s*",
+	"anime.search_none":      "Tidak ada hasil untuk \"%s\".",
+	"anime.search_title":     "*Hasil pencarian \"%s\"*",
+	"anime.detail_empty":     "Data detail kosong.",
+	"anime.detail_released":  "Rilis: %s",
+	"anime.detail_synopsis":  "Sinopsis:",
+	"anime.detail_chapters":  "Chapter terbaru:",
+	"anime.no_data":          "- Belum ada data.",
+	"anime.more_episodes":    "... %d episode lainnya di API.",
+	"anime.hint_stream":      "Ambil stream: anime episode <slug> [reso]",
+	"anime.episode_none":     "Tidak ada data untuk %s.",
+	"anime.episode_resos":    "Resolusi tersedia: ",
+	"anime.episode_links":    "Link streaming:",
+	"anime.episode_no_links": "- Belum ada tautan.",
+	"anime.more_links":       "... %d link lainnya di API.",
+
+	// ---- tiktok ----
+	"tiktok.fetching":          "⏳ Mengambil media TikTok...",
+	"tiktok.fetch_failed":      "Maaf, gagal mengambil media TikTok. Coba kirim lagi ya.",
+	"tiktok.slide_step":        "⏳ Mengirim slide %d/%d...",
+	"tiktok.slides_done":       "✅ TikTok: %d/%d slide terkirim.",
+	"tiktok.slides_failed":     "❌ Gagal mengunduh slide TikTok.",
+	"tiktok.video_step":        "⏳ Mengunduh video TikTok...",
+	"tiktok.video_done":        "✅ Video TikTok terkirim.",
+	"tiktok.audio":             "🔊 Audio: %s",
+	"tiktok.no_media":          "Maaf, tidak menemukan media valid dari TikTok.",
+	"tiktok.slide_caption":     "TikTok 🖼️ slide %d/%d",
+	"tiktok.slide_doc_caption": "TikTok 🖼️ slide %d/%d (dokumen)",
+	"tiktok.video_caption":     "TikTok 🎬",
+	"tiktok.video_doc_caption": "TikTok 🎬 (dokumen)",
+
+	// ---- imggen ----
+	"imggen.empty_prompt":  "Prompt gambar kosong. Contoh: %s buatin gambar kucing lucu",
+	"imggen.failed":        "Gagal generate gambar. Semua API key limit atau error.",
+	"imggen.upload_failed": "Gagal upload gambar",
+	"imggen.caption":       "🎨 Generated: %s",
+
+	// ---- hijabin ----
+	"hijabin.bad_format":      "Format tidak didukung. Kirim/reply **jpeg/jpg/png** ya ✨",
+	"hijabin.download_failed": "Gagal mengunduh gambar 😔",
+	"hijabin.not_configured":  "Fitur hijabin belum dikonfigurasi. Set **HIJABIN_API_URL** (dan KEY jika perlu) *atau* **GEMINI_API_KEY/GEMINI_KEYS** di `.env`.",
+	"hijabin.failed":          "Gagal memproses hijabin. Coba lagi ya ✨",
+	"hijabin.upload_failed":   "Upload gambar hasil gagal.",
+	"hijabin.caption":         "*Selamat, gambar sudah berhijab.*",
+
+	// ---- vision ----
+	"vision.download_failed": "Maaf, gagal mengunduh gambar 😔",
+	"vision.default_prompt":  "Tolong jelaskan gambar ini secara ringkas.",
+	"vision.system":          "Kamu Elaina — analis visual cerdas & hangat. Jawab ringkas, akurat, Bahasa Indonesia.",
+
+	// ---- pap ----
+	"pap.caption": "Pap dari %s",
+	"pap.failed":  "Maaf, aku belum bisa kirim pap sekarang.",
+
+	// ---- baimg ----
+	"baimg.index_empty":     "Index Blue Archive kosong / gagal dimuat.",
+	"baimg.unknown":         "Maaf, karakter itu belum ada di index BA-ku.",
+	"baimg.download_failed": "Gagal mengunduh gambar BA.",
+	"baimg.download_status": "Gagal mengunduh gambar BA: %s",
+	"baimg.upload_failed":   "Gagal mengunggah gambar BA.",
+
+	// ---- tagall ----
+	"tagall.hello": "👋 Halo semuanya, hadir ya!",
+
+	// ---- rvo ----
+	"rvo.usage":           "Reply foto/video *view-once* lalu ketik *%s rvo* ya ✨",
+	"rvo.not_view_once":   "Pesan yang di-reply bukan media *view-once* (foto/video).",
+	"rvo.download_failed": "Gagal mengunduh media view-once 😔",
+	"rvo.unsupported":     "Jenis media tidak didukung.",
+	"rvo.upload_failed":   "Gagal mengunggah ulang media 😔",
+
+	// ---- vn ----
+	"vn.usage":           "Kirim/Reply **voice note**-nya ya, nanti Elaina transkrip dan jawab ✨",
+	"vn.download_failed": "Maaf, gagal mengambil voice note 😔",
+	"vn.system":          `Perankan "Elaina", penyihir cerdas & hangat. Bahasa Indonesia, ringkas, ramah.`,
+
+	// ---- koneksi WhatsApp (laporan ke owner) ----
+	"wa.reconnected": "⚠️ Elaina tersambung kembali.\nAlasan putus sebelumnya:\n- %s",
+
+	// ---- peraturan ----
+	"peraturan.no_apikey":          "PERATURAN_APIKEY belum diatur.",
+	"peraturan.group_only":         "Perintah peraturan hanya berlaku di grup.",
+	"peraturan.admin_only":         "Hanya admin grup atau owner bot yang bisa mengatur fitur peraturan.",
+	"peraturan.usage":              "Gunakan: !peraturan on|off|sync|status|rules|clear @user\nUntuk mengurangi warn: sebut nama bot lalu tulis `saya mau mengurangi warn` dan ucapkan `subhanallah` tepat 5 kali.",
+	"peraturan.unknown":            "Perintah tidak dikenal. Gunakan: !peraturan on|off|sync|status|rules|clear @user\nPengurangan warn: sebut nama bot + \"saya mau mengurangi warn\" + `subhanallah` x5.",
+	"peraturan.default_reason":     "Melanggar aturan grup.",
+	"peraturan.warn":               "*Peringatan %d/%d untuk @%s*\nAlasan: %s",
+	"peraturan.kicked":             "*@%s dikeluarkan karena melebihi batas peringatan.*",
+	"peraturan.warn_zero":          "*@%s, warn kamu sudah 0. Tetap jaga kedisiplinan ya.*",
+	"peraturan.warn_reduced":       "*Warn kamu berkurang menjadi %d/%d.*",
+	"peraturan.group_info_failed":  "Gagal mengambil info grup: %v",
+	"peraturan.desc_empty_enable":  "Deskripsi grup kosong. Atur deskripsi berisi aturan terlebih dahulu.",
+	"peraturan.save_failed":        "Gagal menyimpan aturan: %v",
+	"peraturan.enabled":            "Fitur peraturan aktif.\nAturan tersimpan sebanyak %d baris.",
+	"peraturan.already_off":        "Fitur peraturan sudah nonaktif.",
+	"peraturan.disable_failed":     "Gagal menonaktifkan: %v",
+	"peraturan.disabled":           "Fitur peraturan dinonaktifkan.",
+	"peraturan.desc_empty":         "Deskripsi grup kosong.",
+	"peraturan.sync_failed":        "Gagal menyinkronkan aturan: %v",
+	"peraturan.synced":             "Aturan diperbarui dari deskripsi grup.",
+	"peraturan.status_failed":      "Gagal memuat status: %v",
+	"peraturan.status_on":          "Aktif",
+	"peraturan.status_off":         "Nonaktif",
+	"peraturan.status":             "*Status:* %s",
+	"peraturan.status_rules":       "*Aturan tersimpan:* ",
+	"peraturan.status_more":        "... (%d baris lagi)",
+	"peraturan.status_warns":       "*Daftar warn teratas:*",
+	"peraturan.rules_failed":       "Gagal memuat aturan: %v",
+	"peraturan.no_rules":           "Belum ada aturan tersimpan.",
+	"peraturan.rules":              "*Aturan grup:*\n%s",
+	"peraturan.clear_need_mention": "Sertakan mention pengguna yang ingin kamu hapus warn-nya.",
+	"peraturan.clear_failed":       "Gagal menghapus warn: %v",
+	"peraturan.cleared":            "Warn untuk %s direset.",
+
+	// ---- instruksi bahasa untuk persona LLM ----
+	"llm.answer_in": "BAHASA: Selalu jawab dalam Bahasa Indonesia, apa pun bahasa pesan pengguna.",
+
+	// ---- prompt sistem LLM ----
+	"llm.transcribe":    "Transkripsikan audio ke Bahasa Indonesia yang bersih.",
+	"llm.elaina_system": `Perankan "Elaina", penyihir cerdas & hangat. Bahasa Indonesia, santai-sopan, emoji hemat.`,
+	"llm.user_name":     "INFO TAMBAHAN: Nama pengguna yang sedang berbicara denganmu adalah %s. Gunakan nama ini secara natural dalam percakapan, terutama saat menyapa atau merespons.",
+
+	// ---- error LLM (detail mentah hanya di log) ----
+	"llm.err_quota":   "Mana sihirku lagi habis nih~ 🪄 Tunggu sebentar lalu coba lagi ya.",
+	"llm.err_blocked": "Hmm, yang itu nggak bisa Elaina jawab 🙊 Coba tanyakan dengan cara lain ya.",
+	"llm.err_timeout": "Mantraku kelamaan dirapal ⏳ Coba kirim ulang sebentar lagi ya.",
+	"llm.err_empty":   "Elaina kehilangan kata-kata barusan 😅 Coba ulangi pertanyaannya ya.",
+	"llm.err_failed":  "Maaf, sihir Elaina sedang kacau 😔 Coba lagi nanti ya.",
+}
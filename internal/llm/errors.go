@@ -0,0 +1,56 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"wa-elaina/internal/i18n"
+)
+
+// Jenis kegagalan LLM. Error dari AskText/AskVision/Transcribe/AskAsPersona
+// membungkus salah satunya (cek dengan errors.Is); detail mentah dari API
+// hanya untuk log, jangan dikirim ke chat.
+var (
+	ErrQuota   = errors.New("kuota LLM habis")
+	ErrBlocked = errors.New("diblokir filter keamanan LLM")
+	ErrTimeout = errors.New("LLM timeout")
+	ErrEmpty   = errors.New("respons LLM kosong")
+)
+
+// typed memetakan error backend ke salah satu jenis di atas (detail tetap
+// terbungkus untuk log). Error yang tidak dikenali dikembalikan apa adanya.
+func typed(err error) error {
+	var se *StatusError
+	var ne net.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrQuota), errors.Is(err, ErrBlocked), errors.Is(err, ErrTimeout), errors.Is(err, ErrEmpty):
+		return err
+	case errors.Is(err, ErrAllBenched):
+		return fmt.Errorf("%w: %w", ErrQuota, err)
+	case errors.As(err, &se) && (se.Code == 429 || strings.Contains(se.Body, "RESOURCE_EXHAUSTED")):
+		return fmt.Errorf("%w: %w", ErrQuota, err)
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &ne) && ne.Timeout():
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// Friendly: pesan bergaya persona untuk err dalam bahasa lang.
+func Friendly(lang i18n.Lang, err error) string {
+	switch {
+	case errors.Is(err, ErrQuota):
+		return lang.T("llm.err_quota")
+	case errors.Is(err, ErrBlocked):
+		return lang.T("llm.err_blocked")
+	case errors.Is(err, ErrTimeout):
+		return lang.T("llm.err_timeout")
+	case errors.Is(err, ErrEmpty):
+		return lang.T("llm.err_empty")
+	}
+	return lang.T("llm.err_failed")
+}
@@ -1,101 +1,142 @@
 package llm
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"io"
-	"net/http"
+	"fmt"
 	"strings"
-	"time"
 
-	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
 )
 
-var (
-	keys []string
-	idx  int
-	httpc = &http.Client{ Timeout: 60 * time.Second }
+const (
+	DefaultGeminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	DefaultGeminiModel   = "gemini-2.5-flash-lite"
 )
 
-func Init(cfg config.Config) { keys = cfg.GeminiKeys }
+var geminiBase = DefaultGeminiBaseURL
 
-func getKey() string { if len(keys)==0 { return "" }; return keys[idx] }
-func rotate() { if len(keys)>1 { idx=(idx+1)%len(keys) } }
+// GeminiEndpoint membangun URL generateContent untuk model & key tertentu
+// memakai base URL dari config (GEMINI_BASE_URL).
+func GeminiEndpoint(model, key string) string {
+	return geminiBase + "/models/" + model + ":generateContent?key=" + key
+}
 
-func AskText(system, user string) string {
-	var last string
-	for i:=0; i<max(1,len(keys)); i++ {
-		key := getKey(); if key=="" { return "LLM key belum diatur" }
-		body := map[string]any{
-			"system_instruction": map[string]any{"role":"system","parts":[]map[string]string{{"text":system}}},
-			"contents": []map[string]any{{"role":"user","parts":[]map[string]string{{"text":user}}}},
-		}
-		s, status := send(key, body)
-		if status==200 && s!="" { return s }
-		last = s; rotate()
+// Gemini: backend generateContent Google; key diambil dari KeyPool bersama.
+type Gemini struct {
+	base  string
+	model string
+	keys  *KeyPool
+}
+
+func NewGemini(base string, keys *KeyPool, model string) *Gemini {
+	if base == "" {
+		base = DefaultGeminiBaseURL
 	}
-	return strings.TrimSpace(last)
+	if model == "" {
+		model = DefaultGeminiModel
+	}
+	return &Gemini{base: strings.TrimRight(base, "/"), model: model, keys: keys}
 }
 
-func AskTextAsElaina(user string) string {
-	sys := `Perankan "Elaina", penyihir cerdas & hangat. Bahasa Indonesia, santai-sopan, emoji hemat.`
-	return AskText(sys, user)
+func (g *Gemini) Name() string { return BackendGemini + "/" + g.model }
+
+func (g *Gemini) Text(ctx context.Context, system, user string) (string, error) {
+	return g.generate(ctx, system, userContent(map[string]any{"text": user}), false)
 }
 
-func AskVision(system, prompt string, img []byte, mime string) string {
-	if mime=="" { mime="image/jpeg" }
-	var last string
-	for i:=0; i<max(1,len(keys)); i++ {
-		key := getKey(); if key=="" { return "LLM key belum diatur" }
-		b64 := base64.StdEncoding.EncodeToString(img)
-		parts := []any{ map[string]any{"text": prompt}, map[string]any{"inlineData": map[string]any{"mimeType": mime, "data": b64}} }
-		body := map[string]any{
-			"system_instruction": map[string]any{"role":"system","parts":[]map[string]string{{"text":system}}},
-			"contents": []map[string]any{{"role":"user","parts": parts }},
-		}
-		s, status := send(key, body)
-		if status==200 && s!="" { return s }
-		last = s; rotate()
+func (g *Gemini) Chat(ctx context.Context, system string, msgs []Message) (string, error) {
+	var contents []map[string]any
+	for _, m := range mergeTurns(msgs) {
+		contents = append(contents, map[string]any{
+			"role":  m.Role, // "user" / "model" sama dengan peran Gemini
+			"parts": []any{map[string]any{"text": m.Text}},
+		})
 	}
-	return strings.TrimSpace(last)
+	return g.generate(ctx, system, contents, false)
 }
 
-func Transcribe(audio []byte, mime string) string {
-	if mime=="" { mime="audio/ogg" }
-	var last string
-	for i:=0; i<max(1,len(keys)); i++ {
-		key := getKey(); if key=="" { return "" }
-		b64 := base64.StdEncoding.EncodeToString(audio)
-		body := map[string]any{
-			"system_instruction": map[string]any{"role":"system","parts":[]map[string]string{{"text":"Transkripsikan audio ke Bahasa Indonesia yang bersih."}}},
-			"contents": []map[string]any{{"role":"user","parts":[]any{ map[string]any{"inlineData": map[string]any{"mimeType": mime, "data": b64}}}}},
-		}
-		s, status := send(key, body)
-		if status==200 && s!="" { return s }
-		last = s; rotate()
+func (g *Gemini) Vision(ctx context.Context, system, prompt string, img []byte, mime string) (string, error) {
+	return g.generate(ctx, system, userContent(map[string]any{"text": prompt}, inlineData(img, mime)), false)
+}
+
+func (g *Gemini) Transcribe(ctx context.Context, audio []byte, mime string, lang i18n.Lang) (string, error) {
+	return g.generate(ctx, lang.T("llm.transcribe"), userContent(inlineData(audio, mime)), false)
+}
+
+func (g *Gemini) JSON(ctx context.Context, system, user string) (string, error) {
+	return g.generate(ctx, system, userContent(map[string]any{"text": user}), true)
+}
+
+func userContent(parts ...any) []map[string]any {
+	return []map[string]any{{"role": "user", "parts": parts}}
+}
+
+func inlineData(b []byte, mime string) map[string]any {
+	return map[string]any{"inlineData": map[string]any{
+		"mimeType": mime,
+		"data":     base64.StdEncoding.EncodeToString(b),
+	}}
+}
+
+func (g *Gemini) generate(ctx context.Context, system string, contents []map[string]any, jsonMode bool) (string, error) {
+	body := map[string]any{"contents": contents}
+	if system != "" {
+		body["system_instruction"] = map[string]any{"parts": []map[string]string{{"text": system}}}
+	}
+	if jsonMode {
+		body["generationConfig"] = map[string]any{"responseMimeType": "application/json"}
 	}
-	return strings.TrimSpace(last)
+
+	var out string
+	err := g.keys.Do(ctx, func(key string) error {
+		rb, err := postJSON(ctx, g.base+"/models/"+g.model+":generateContent?key="+key, nil, body)
+		if err != nil {
+			return err
+		}
+		out, err = geminiText(rb)
+		return err
+	})
+	return out, err
 }
 
-func send(key string, body any) (string, int) {
-	endpoint := "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-flash-lite:generateContent?key="+key
-	b, _ := json.Marshal(body)
-	req, _ := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(b))
-	req.Header.Set("Content-Type","application/json")
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second); defer cancel()
-	req = req.WithContext(ctx)
-
-	resp, err := httpc.Do(req)
-	if err != nil { return "LLM error: "+err.Error(), 0 }
-	defer resp.Body.Close()
-	rb, _ := io.ReadAll(resp.Body)
-
-	var out struct{ Candidates []struct{ Content struct{ Parts []struct{ Text string `json:"text"` } `json:"parts"` } `json:"content"` } `json:"candidates"` }
-	if json.Unmarshal(rb, &out) == nil && len(out.Candidates)>0 && len(out.Candidates[0].Content.Parts)>0 {
-		return strings.TrimSpace(out.Candidates[0].Content.Parts[0].Text), resp.StatusCode
+// geminiText mengambil teks kandidat pertama; prompt/jawaban yang diblokir
+// menjadi ErrBlocked.
+func geminiText(rb []byte) (string, error) {
+	var out struct {
+		PromptFeedback struct {
+			BlockReason string `json:"blockReason"`
+		} `json:"promptFeedback"`
+		Candidates []struct {
+			FinishReason string `json:"finishReason"`
+			Content      struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.Unmarshal(rb, &out); err != nil {
+		return "", err
+	}
+	if r := out.PromptFeedback.BlockReason; r != "" {
+		return "", fmt.Errorf("%w: gemini blockReason=%s", ErrBlocked, r)
+	}
+	if len(out.Candidates) == 0 {
+		return "", fmt.Errorf("%w: gemini tanpa kandidat", ErrEmpty)
+	}
+	c := out.Candidates[0]
+	var sb strings.Builder
+	for _, p := range c.Content.Parts {
+		sb.WriteString(p.Text)
+	}
+	s := strings.TrimSpace(sb.String())
+	switch {
+	case s != "":
+		return s, nil
+	case c.FinishReason == "SAFETY" || c.FinishReason == "PROHIBITED_CONTENT" || c.FinishReason == "BLOCKLIST" || c.FinishReason == "SPII":
+		return "", fmt.Errorf("%w: gemini finishReason=%s", ErrBlocked, c.FinishReason)
 	}
-	return strings.TrimSpace(string(rb)), resp.StatusCode
+	return "", fmt.Errorf("%w: gemini finishReason=%s", ErrEmpty, c.FinishReason)
 }
-func max(a,b int) int { if a>b {return a}; return b }
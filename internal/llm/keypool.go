@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	quotaBench   = time.Minute // cooldown 429 tanpa retry-after
+	maxQuotaWait = time.Hour
+	authBench    = time.Hour // key ditolak (401/403/API_KEY_INVALID)
+)
+
+// ErrAllBenched: semua key sedang cooldown atau ditolak.
+var ErrAllBenched = errors.New("semua API key sedang cooldown")
+
+// failKind mengelompokkan error per penyebab agar hanya masalah milik key
+// (kuota, auth) yang membuat key di-bench.
+type failKind int
+
+const (
+	failNone      failKind = iota
+	failQuota              // 429 / RESOURCE_EXHAUSTED → cooldown retry-after
+	failAuth               // key tidak valid / tidak diizinkan → bench lama
+	failTransient          // jaringan, timeout, 5xx → coba key lain, tanpa bench
+	failRequest            // isi request/respons (400, kosong) → key lain tak membantu
+)
+
+var reRetryDelay = regexp.MustCompile(`"retryDelay"\s*:\s*"([0-9.]+)s"`)
+
+func classify(err error) (failKind, time.Duration) {
+	if err == nil {
+		return failNone, 0
+	}
+	var se *StatusError
+	if !errors.As(err, &se) {
+		var ne net.Error
+		if errors.As(err, &ne) || errors.Is(err, context.DeadlineExceeded) {
+			return failTransient, 0
+		}
+		return failRequest, 0
+	}
+	body := se.Body
+	switch {
+	case se.Code == 429 || strings.Contains(body, "RESOURCE_EXHAUSTED"):
+		wait := se.RetryAfter
+		if m := reRetryDelay.FindStringSubmatch(body); wait == 0 && m != nil {
+			if f, err := strconv.ParseFloat(m[1], 64); err == nil {
+				wait = time.Duration(f * float64(time.Second))
+			}
+		}
+		if wait <= 0 {
+			wait = quotaBench
+		}
+		return failQuota, min(wait, maxQuotaWait)
+	case se.Code == 401 || se.Code == 403,
+		strings.Contains(body, "API_KEY_INVALID"), strings.Contains(body, "API key not valid"):
+		return failAuth, authBench
+	case se.Code >= 500 || se.Code == 408:
+		return failTransient, 0
+	}
+	return failRequest, 0
+}
+
+// KeyPool berbagi sekumpulan API key antar goroutine: round-robin ke key
+// sehat, key yang kena kuota di-bench sampai retry-after lewat, key yang
+// ditolak di-bench lebih lama. Aman dipakai bersamaan.
+type KeyPool struct {
+	name string
+	mu   sync.Mutex
+	keys []*keyState
+	next int
+}
+
+type keyState struct {
+	key     string
+	until   time.Time // bench sampai
+	auth    bool      // bench karena auth
+	stat    KeyStat
+	lastErr string
+}
+
+// KeyStat: ringkasan kesehatan satu key (key disamarkan).
+type KeyStat struct {
+	Key       string
+	OK        int
+	Fail      int
+	Quota     int
+	AuthFail  int
+	BenchedTo time.Time // nol = aktif
+	AuthBench bool
+	LastError string
+}
+
+func NewKeyPool(name string, keys []string) *KeyPool {
+	p := &KeyPool{name: name}
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if k = strings.TrimSpace(k); k != "" && !seen[k] {
+			seen[k] = true
+			p.keys = append(p.keys, &keyState{key: k})
+		}
+	}
+	return p
+}
+
+func (p *KeyPool) Name() string { return p.name }
+func (p *KeyPool) Len() int     { return len(p.keys) }
+
+// acquire memberi key sehat berikutnya yang belum dicoba.
+func (p *KeyPool) acquire(tried map[string]bool) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for range p.keys {
+		ks := p.keys[p.next]
+		p.next = (p.next + 1) % len(p.keys)
+		if !tried[ks.key] && !now.Before(ks.until) {
+			return ks.key, true
+		}
+	}
+	return "", false
+}
+
+func (p *KeyPool) report(key string, kind failKind, wait time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ks := range p.keys {
+		if ks.key != key {
+			continue
+		}
+		switch kind {
+		case failNone:
+			ks.stat.OK++
+			ks.until, ks.auth = time.Time{}, false
+			return
+		case failQuota:
+			ks.stat.Quota++
+		case failAuth:
+			ks.stat.AuthFail++
+		}
+		ks.stat.Fail++
+		if wait > 0 {
+			ks.until, ks.auth = time.Now().Add(wait), kind == failAuth
+		}
+		if err != nil {
+			ks.lastErr = err.Error()
+		}
+		return
+	}
+}
+
+// Do memanggil call dengan key sehat sampai berhasil. Key lain hanya dicoba
+// jika kegagalan disebabkan key/server (kuota, auth, transient); error
+// request dikembalikan langsung.
+func (p *KeyPool) Do(ctx context.Context, call func(key string) error) error {
+	if p == nil || len(p.keys) == 0 {
+		return ErrNoKey
+	}
+	tried := map[string]bool{}
+	var last error
+	for {
+		key, ok := p.acquire(tried)
+		if !ok {
+			if last == nil {
+				last = ErrAllBenched
+			}
+			return last
+		}
+		tried[key] = true
+		err := call(key)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err() // dibatalkan pemanggil: bukan salah key
+		}
+		kind, wait := classify(err)
+		p.report(key, kind, wait, err)
+		if kind == failNone || kind == failRequest {
+			return err
+		}
+		last = err
+	}
+}
+
+// Stats: statistik tiap key, urut sesuai konfigurasi.
+func (p *KeyPool) Stats() []KeyStat {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	out := make([]KeyStat, 0, len(p.keys))
+	for _, ks := range p.keys {
+		st := ks.stat
+		st.Key = maskKey(ks.key)
+		if now.Before(ks.until) {
+			st.BenchedTo, st.AuthBench = ks.until, ks.auth
+		}
+		st.LastError = ks.lastErr
+		out = append(out, st)
+	}
+	return out
+}
+
+func maskKey(k string) string {
+	if len(k) <= 8 {
+		return "…" + k[len(k)/2:]
+	}
+	return k[:4] + "…" + k[len(k)-4:]
+}
+
+// Pool bersama yang terdaftar (untuk !llmkeys).
+var (
+	poolsMu sync.Mutex
+	pools   []*KeyPool
+)
+
+func registerPool(p *KeyPool) *KeyPool {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	for i, old := range pools {
+		if old.name == p.name {
+			pools[i] = p
+			return p
+		}
+	}
+	pools = append(pools, p)
+	return p
+}
+
+// Pools: semua pool key yang aktif.
+func Pools() []*KeyPool {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	return append([]*KeyPool(nil), pools...)
+}
@@ -1,13 +1,10 @@
 package llm
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -22,10 +19,12 @@ const (
 	ModerationModeRedeem ModerationMode = "REDEEM"
 )
 
+// ModerationClient menilai pesan terhadap aturan grup lewat backend JSON
+// mode. PERATURAN_APIKEY (opsional) memakai key Gemini khusus dengan model
+// moderationModel; tanpa itu dipakai backend LLM_JSON.
 type ModerationClient struct {
-	apiKey string
+	p      Provider
 	system string
-	httpc  *http.Client
 }
 
 type ModerationInput struct {
@@ -53,42 +52,25 @@ Selalu balas dengan JSON valid dengan format {"violation":bool,"reason":string,"
 - Jika tidak melanggar, violation=false dan reason kosong.
 Jangan tambahkan teks lain selain JSON.`
 	}
-	return &ModerationClient{
-		apiKey: key,
-		system: system,
-		httpc:  &http.Client{Timeout: 45 * time.Second},
+	p := For(CapJSON)
+	if key != "" {
+		p = NewGemini(geminiBase, registerPool(NewKeyPool("peraturan", []string{key})), moderationModel)
 	}
+	return &ModerationClient{p: p, system: system}
 }
 
-func (c *ModerationClient) Ready() bool { return strings.TrimSpace(c.apiKey) != "" }
+func (c *ModerationClient) Ready() bool { return c.p != nil }
 
 func (c *ModerationClient) Evaluate(ctx context.Context, in ModerationInput) (ModerationResult, error) {
 	if !c.Ready() {
-		return ModerationResult{}, errors.New("PERATURAN_APIKEY belum diatur")
+		return ModerationResult{}, errors.New("backend moderasi belum diatur (PERATURAN_APIKEY/LLM_JSON)")
 	}
-	payload := map[string]any{
-		"system_instruction": map[string]any{
-			"role": "system",
-			"parts": []map[string]string{
-				{"text": c.system},
-			},
-		},
-		"contents": []map[string]any{
-			{
-				"role": "user",
-				"parts": []map[string]string{
-					{"text": buildModerationPrompt(in)},
-				},
-			},
-		},
-	}
-	respText, status, err := c.send(ctx, payload)
+	ctx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	respText, err := c.p.JSON(ctx, c.system, buildModerationPrompt(in))
 	if err != nil {
 		return ModerationResult{}, err
 	}
-	if status >= 300 {
-		return ModerationResult{}, errors.New(respText)
-	}
 	clean := normalizeModerationJSON(respText)
 	var res ModerationResult
 	if err := json.Unmarshal([]byte(clean), &res); err != nil {
@@ -97,35 +79,6 @@ func (c *ModerationClient) Evaluate(ctx context.Context, in ModerationInput) (Mo
 	return res, nil
 }
 
-func (c *ModerationClient) send(ctx context.Context, body any) (string, int, error) {
-	b, _ := json.Marshal(body)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://generativelanguage.googleapis.com/v1beta/models/"+moderationModel+":generateContent?key="+c.apiKey,
-		bytes.NewReader(b))
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.httpc.Do(req)
-	if err != nil {
-		return "", 0, err
-	}
-	defer resp.Body.Close()
-	rb, _ := io.ReadAll(resp.Body)
-	var out struct {
-		Candidates []struct {
-			Content struct {
-				Parts []struct {
-					Text string `json:"text"`
-				} `json:"parts"`
-			} `json:"content"`
-		} `json:"candidates"`
-	}
-	if json.Unmarshal(rb, &out) == nil {
-		if len(out.Candidates) > 0 && len(out.Candidates[0].Content.Parts) > 0 {
-			return strings.TrimSpace(out.Candidates[0].Content.Parts[0].Text), resp.StatusCode, nil
-		}
-	}
-	return strings.TrimSpace(string(rb)), resp.StatusCode, nil
-}
-
 func buildModerationPrompt(in ModerationInput) string {
 	var sb strings.Builder
 	sb.WriteString("Mode: ")
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"wa-elaina/internal/i18n"
+)
+
+const (
+	DefaultOllamaBaseURL = "http://localhost:11434"
+	DefaultOllamaModel   = "llama3.1"
+)
+
+// Ollama: backend /api/chat lokal. Vision butuh model multimodal (mis.
+// llava) di OLLAMA_VISION_MODEL; transkripsi tidak didukung.
+type Ollama struct {
+	base        string
+	model       string
+	visionModel string
+}
+
+func NewOllama(base, model, visionModel string) *Ollama {
+	if base == "" {
+		base = DefaultOllamaBaseURL
+	}
+	if model == "" {
+		model = DefaultOllamaModel
+	}
+	if visionModel == "" {
+		visionModel = model
+	}
+	return &Ollama{base: strings.TrimRight(base, "/"), model: model, visionModel: visionModel}
+}
+
+func (o *Ollama) Name() string { return BackendOllama + "/" + o.model }
+
+func (o *Ollama) Text(ctx context.Context, system, user string) (string, error) {
+	return o.chat(ctx, o.model, system, []map[string]any{{"role": "user", "content": user}}, false)
+}
+
+func (o *Ollama) Chat(ctx context.Context, system string, msgs []Message) (string, error) {
+	return o.chat(ctx, o.model, system, chatMessages(msgs), false)
+}
+
+func (o *Ollama) JSON(ctx context.Context, system, user string) (string, error) {
+	return o.chat(ctx, o.model, system, []map[string]any{{"role": "user", "content": user}}, true)
+}
+
+func (o *Ollama) Vision(ctx context.Context, system, prompt string, img []byte, _ string) (string, error) {
+	return o.chat(ctx, o.visionModel, system, []map[string]any{{
+		"role":    "user",
+		"content": prompt,
+		"images":  []string{base64.StdEncoding.EncodeToString(img)},
+	}}, false)
+}
+
+func (o *Ollama) Transcribe(context.Context, []byte, string, i18n.Lang) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (o *Ollama) chat(ctx context.Context, model, system string, turns []map[string]any, jsonMode bool) (string, error) {
+	var msgs []map[string]any
+	if system != "" {
+		msgs = append(msgs, map[string]any{"role": "system", "content": system})
+	}
+	msgs = append(msgs, turns...)
+	body := map[string]any{"model": model, "messages": msgs, "stream": false}
+	if jsonMode {
+		body["format"] = "json"
+	}
+	rb, err := postJSON(ctx, o.base+"/api/chat", nil, body)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(rb, &out); err != nil {
+		return "", err
+	}
+	if out.Error != "" {
+		return "", errors.New("ollama: " + out.Error)
+	}
+	if s := strings.TrimSpace(out.Message.Content); s != "" {
+		return s, nil
+	}
+	return "", fmt.Errorf("%w: ollama", ErrEmpty)
+}
@@ -0,0 +1,179 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"wa-elaina/internal/i18n"
+)
+
+const (
+	DefaultOpenAIBaseURL  = "https://api.openai.com/v1"
+	DefaultOpenAIModel    = "gpt-4o-mini"
+	DefaultOpenAISTTModel = "whisper-1"
+)
+
+// OpenAI: backend /chat/completions yang kompatibel OpenAI (OpenAI,
+// OpenRouter, Groq, LM Studio, vLLM, dst). Transkripsi lewat
+// /audio/transcriptions.
+type OpenAI struct {
+	base     string
+	key      string
+	model    string
+	sttModel string
+}
+
+func NewOpenAI(base, key, model, sttModel string) *OpenAI {
+	if base == "" {
+		base = DefaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+	if sttModel == "" {
+		sttModel = DefaultOpenAISTTModel
+	}
+	return &OpenAI{base: strings.TrimRight(base, "/"), key: key, model: model, sttModel: sttModel}
+}
+
+func (o *OpenAI) Name() string { return BackendOpenAI + "/" + o.model }
+
+func (o *OpenAI) Text(ctx context.Context, system, user string) (string, error) {
+	return o.chat(ctx, system, []map[string]any{{"role": "user", "content": user}}, false)
+}
+
+func (o *OpenAI) Chat(ctx context.Context, system string, msgs []Message) (string, error) {
+	return o.chat(ctx, system, chatMessages(msgs), false)
+}
+
+func (o *OpenAI) JSON(ctx context.Context, system, user string) (string, error) {
+	return o.chat(ctx, system, []map[string]any{{"role": "user", "content": user}}, true)
+}
+
+func (o *OpenAI) Vision(ctx context.Context, system, prompt string, img []byte, mime string) (string, error) {
+	dataURL := "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(img)
+	return o.chat(ctx, system, []map[string]any{{"role": "user", "content": []map[string]any{
+		{"type": "text", "text": prompt},
+		{"type": "image_url", "image_url": map[string]string{"url": dataURL}},
+	}}}, false)
+}
+
+func (o *OpenAI) Transcribe(ctx context.Context, audio []byte, mime string, lang i18n.Lang) (string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("model", o.sttModel)
+	_ = mw.WriteField("language", string(lang)) // kode ISO-639-1 ("id", "en")
+	fw, err := mw.CreateFormFile("file", "audio"+audioExt(mime))
+	if err != nil {
+		return "", err
+	}
+	_, _ = fw.Write(audio)
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.base+"/audio/transcriptions", &buf)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	o.auth(req.Header.Set)
+	rb, err := do(req)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(rb, &out); err != nil {
+		return "", err
+	}
+	if s := strings.TrimSpace(out.Text); s != "" {
+		return s, nil
+	}
+	return "", fmt.Errorf("%w: openai transkrip kosong", ErrEmpty)
+}
+
+// chat mengirim pesan system (terpisah) diikuti turns; content turn boleh
+// string atau array bagian (teks + gambar).
+func (o *OpenAI) chat(ctx context.Context, system string, turns []map[string]any, jsonMode bool) (string, error) {
+	var msgs []map[string]any
+	if system != "" {
+		msgs = append(msgs, map[string]any{"role": "system", "content": system})
+	}
+	msgs = append(msgs, turns...)
+	body := map[string]any{"model": o.model, "messages": msgs}
+	if jsonMode {
+		body["response_format"] = map[string]string{"type": "json_object"}
+	}
+	header := map[string]string{}
+	o.auth(func(k, v string) { header[k] = v })
+	rb, err := postJSON(ctx, o.base+"/chat/completions", header, body)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Choices []struct {
+			FinishReason string `json:"finish_reason"`
+			Message      struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(rb, &out); err != nil {
+		return "", err
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("%w: openai tanpa choices", ErrEmpty)
+	}
+	c := out.Choices[0]
+	if s := strings.TrimSpace(c.Message.Content); s != "" {
+		return s, nil
+	}
+	if c.FinishReason == "content_filter" {
+		return "", fmt.Errorf("%w: openai content_filter", ErrBlocked)
+	}
+	return "", fmt.Errorf("%w: openai finish_reason=%s", ErrEmpty, c.FinishReason)
+}
+
+// chatMessages mengubah riwayat ke format messages OpenAI/Ollama (model →
+// "assistant").
+func chatMessages(msgs []Message) []map[string]any {
+	out := make([]map[string]any, 0, len(msgs))
+	for _, m := range mergeTurns(msgs) {
+		role := "user"
+		if m.Role == RoleModel {
+			role = "assistant"
+		}
+		out = append(out, map[string]any{"role": role, "content": m.Text})
+	}
+	return out
+}
+
+// auth: server lokal sering tanpa key, jadi header hanya dikirim jika ada.
+func (o *OpenAI) auth(set func(k, v string)) {
+	if o.key != "" {
+		set("Authorization", "Bearer "+o.key)
+	}
+}
+
+func audioExt(mime string) string {
+	switch m := strings.ToLower(mime); {
+	case strings.Contains(m, "ogg"), strings.Contains(m, "opus"):
+		return ".ogg"
+	case strings.Contains(m, "mpeg"), strings.Contains(m, "mp3"):
+		return ".mp3"
+	case strings.Contains(m, "wav"):
+		return ".wav"
+	case strings.Contains(m, "mp4"), strings.Contains(m, "m4a"), strings.Contains(m, "aac"):
+		return ".m4a"
+	case strings.Contains(m, "webm"):
+		return ".webm"
+	}
+	return ".ogg"
+}
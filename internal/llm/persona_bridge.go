@@ -6,20 +6,21 @@ import (
 	"time"
 
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/memory"
 )
 
-func AskAsPersona(_ config.Config, persona string, pro bool, userText string, senderJID string, _ time.Time) string {
-	// Cek apakah ini permintaan perubahan nama dari user text ASLI
-	// Ekstrak input user baru dari context yang kompleks
-	actualUserInput := extractActualUserInput(userText)
-	
-	if name, isNameRequest := memory.DetectNameRequest(actualUserInput); isNameRequest {
+// AskAsPersona menjawab userText sebagai persona Elaina dalam bahasa chat
+// (lang). hist dikirim sebagai giliran user/model terpisah; instruksi persona
+// menjadi system instruction.
+func AskAsPersona(_ config.Config, persona string, pro bool, lang i18n.Lang, hist []memory.Turn, userText string, senderJID string, _ time.Time) (string, error) {
+	// Permintaan ganti nama dicek dari teks user apa adanya
+	if name, isNameRequest := memory.DetectNameRequest(userText); isNameRequest {
 		// Simpan nama baru
 		if err := memory.SetUserName(senderJID, name); err == nil {
-			return "*Oke! Mulai sekarang aku akan memanggilmu " + name + "* ✨\n\n_Senang berkenalan denganmu, " + name + "!_ Aku Elaina, penyihir cantik dan berbakat~ 🌟"
+			return lang.T("chat.name_saved", name), nil
 		} else {
-			return "*Maaf, ada masalah saat menyimpan namamu.* Coba lagi ya~ 😅"
+			return lang.T("chat.name_failed"), nil
 		}
 	}
 	
@@ -51,51 +52,20 @@ func AskAsPersona(_ config.Config, persona string, pro bool, userText string, se
 	// Ambil nama pengguna untuk konteks tambahan
 	userName, _ := memory.GetUserName(senderJID)
 	if userName != "" {
-		sys += "\n\nINFO TAMBAHAN: Nama pengguna yang sedang berbicara denganmu adalah " + userName + ". Gunakan nama ini secara natural dalam percakapan, terutama saat menyapa atau merespons."
+		sys += "\n\n" + lang.T("llm.user_name", userName)
 	}
-	
-	return AskText(sys, userText)
-}
 
-// Helper function untuk mengekstrak input user yang sebenarnya dari context
-func extractActualUserInput(contextText string) string {
-	lines := strings.Split(contextText, "\n")
-	
-	// Cari marker "Pertanyaan/teks baru:"
-	foundMarker := false
-	var userInputLines []string
-	
-	for _, line := range lines {
-		if strings.Contains(line, "Pertanyaan/teks baru:") {
-			foundMarker = true
-			continue
-		}
-		if foundMarker && strings.TrimSpace(line) != "" {
-			userInputLines = append(userInputLines, line)
-		}
-	}
-	
-	// Jika ada input setelah marker, gabungkan
-	if len(userInputLines) > 0 {
-		return strings.Join(userInputLines, "\n")
-	}
-	
-	// Fallback: jika tidak ada context, kembalikan seluruh text
-	if !strings.Contains(contextText, "Konteks percakapan") {
-		return contextText
-	}
-	
-	// Fallback lain: ambil baris terakhir yang bukan header
-	for i := len(lines) - 1; i >= 0; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line != "" && 
-		   !strings.HasPrefix(line, "Konteks") && 
-		   !strings.HasPrefix(line, "Pertanyaan") &&
-		   !strings.HasPrefix(line, "Nama pengguna:") &&
-		   !strings.Contains(line, "Elaina:") {
-			return line
+	// Bahasa chat (!lang) ditaruh paling akhir agar menimpa gaya bahasa prompt persona
+	sys += "\n\n" + lang.T("llm.answer_in")
+
+	msgs := make([]Message, 0, len(hist)+1)
+	for _, t := range hist {
+		role := RoleUser
+		if t.Role != "user" {
+			role = RoleModel // memory menyimpan "assistant"
 		}
+		msgs = append(msgs, Message{Role: role, Text: t.Text})
 	}
-	
-	return contextText
-}
\ No newline at end of file
+	msgs = append(msgs, Message{Role: RoleUser, Text: userText})
+	return AskChat(sys, msgs)
+}
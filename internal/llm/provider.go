@@ -0,0 +1,258 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wa-elaina/internal/config"
+	"wa-elaina/internal/i18n"
+)
+
+// Capability adalah jenis pekerjaan LLM yang backend-nya bisa dipilih
+// terpisah lewat config (LLM_TEXT, LLM_VISION, LLM_TRANSCRIBE, LLM_JSON).
+type Capability string
+
+const (
+	CapText       Capability = "text"
+	CapVision     Capability = "vision"
+	CapTranscribe Capability = "transcribe"
+	CapJSON       Capability = "json"
+)
+
+// Nama backend yang dikenal.
+const (
+	BackendGemini = "gemini"
+	BackendOpenAI = "openai"
+	BackendOllama = "ollama"
+)
+
+var (
+	ErrUnsupported = errors.New("kemampuan ini tidak didukung backend")
+	ErrNoKey       = errors.New("LLM key belum diatur")
+)
+
+// Peran dalam percakapan multi-giliran.
+const (
+	RoleUser  = "user"
+	RoleModel = "model"
+)
+
+// Message adalah satu giliran percakapan (RoleUser/RoleModel).
+type Message struct {
+	Role string
+	Text string
+}
+
+// mergeTurns menggabungkan giliran berurutan dengan peran sama (mis. dua
+// pesan user beruntun) agar peran selalu bergantian; teks kosong dibuang.
+func mergeTurns(msgs []Message) []Message {
+	var out []Message
+	for _, m := range msgs {
+		if strings.TrimSpace(m.Text) == "" {
+			continue
+		}
+		if m.Role != RoleModel {
+			m.Role = RoleUser
+		}
+		if n := len(out); n > 0 && out[n-1].Role == m.Role {
+			out[n-1].Text += "\n\n" + m.Text
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// Provider adalah satu backend LLM. Backend yang tidak mendukung suatu
+// kemampuan mengembalikan ErrUnsupported.
+type Provider interface {
+	Name() string
+	// Text: jawaban teks biasa untuk satu pesan user.
+	Text(ctx context.Context, system, user string) (string, error)
+	// Chat: percakapan multi-giliran; msgs berakhir dengan pesan user.
+	Chat(ctx context.Context, system string, msgs []Message) (string, error)
+	// Vision: seperti Text, dengan satu gambar inline.
+	Vision(ctx context.Context, system, prompt string, img []byte, mime string) (string, error)
+	// Transcribe: audio → teks; lang adalah bahasa chat (petunjuk bahasa
+	// transkrip).
+	Transcribe(ctx context.Context, audio []byte, mime string, lang i18n.Lang) (string, error)
+	// JSON: seperti Text, tetapi backend diminta membalas JSON valid.
+	JSON(ctx context.Context, system, user string) (string, error)
+}
+
+const requestTimeout = 60 * time.Second
+
+var (
+	httpc      = &http.Client{Timeout: requestTimeout}
+	backend    = map[Capability]Provider{}
+	geminiKeys *KeyPool
+	imageKeys  *KeyPool
+)
+
+// Init membangun backend dari config dan memetakan tiap kemampuan ke
+// backend pilihannya. Dipanggil sekali saat start.
+func Init(cfg config.Config) {
+	built := map[string]Provider{}
+	get := func(name string) Provider {
+		if p, ok := built[name]; ok {
+			return p
+		}
+		var p Provider
+		switch name {
+		case BackendOpenAI:
+			p = NewOpenAI(cfg.OpenAIBaseURL, cfg.OpenAIKey, cfg.OpenAIModel, cfg.OpenAISTTModel)
+		case BackendOllama:
+			p = NewOllama(cfg.OllamaBaseURL, cfg.OllamaModel, cfg.OllamaVisionModel)
+		default:
+			p = NewGemini(cfg.GeminiBaseURL, geminiKeys, cfg.GeminiModel)
+		}
+		built[name] = p
+		return p
+	}
+	geminiBase = cfg.GeminiBaseURL
+	geminiKeys = registerPool(NewKeyPool("gemini", cfg.GeminiKeys))
+	imageKeys = geminiKeys
+	if len(cfg.GeminiImageKeys) > 0 {
+		imageKeys = registerPool(NewKeyPool("gemini-img", cfg.GeminiImageKeys))
+	}
+	backend = map[Capability]Provider{
+		CapText:       get(cfg.LLMText),
+		CapVision:     get(cfg.LLMVision),
+		CapTranscribe: get(cfg.LLMTranscribe),
+		CapJSON:       get(cfg.LLMJSON),
+	}
+	log.Printf("[LLM] text=%s vision=%s transcribe=%s json=%s",
+		cfg.LLMText, cfg.LLMVision, cfg.LLMTranscribe, cfg.LLMJSON)
+}
+
+// GeminiKeys: pool key Gemini bersama (GEMINI_API_KEYS).
+func GeminiKeys() *KeyPool { return geminiKeys }
+
+// ImageKeys: pool key untuk model Gemini penghasil gambar (imggen, hijabin);
+// GEMINI_IMG_KEYS jika diisi, selain itu sama dengan GeminiKeys.
+func ImageKeys() *KeyPool { return imageKeys }
+
+// For mengembalikan backend untuk kemampuan c (nil sebelum Init).
+func For(c Capability) Provider { return backend[c] }
+
+// AskText: jawaban teks dari backend LLM_TEXT.
+func AskText(system, user string) (string, error) {
+	return call(CapText, func(ctx context.Context, p Provider) (string, error) {
+		return p.Text(ctx, system, user)
+	})
+}
+
+// AskChat: balasan untuk riwayat percakapan msgs dari backend LLM_TEXT.
+// Instruksi sistem dikirim terpisah, bukan digabung ke pesan user.
+func AskChat(system string, msgs []Message) (string, error) {
+	return call(CapText, func(ctx context.Context, p Provider) (string, error) {
+		return p.Chat(ctx, system, msgs)
+	})
+}
+
+// AskTextAsElaina: AskText dengan persona Elaina singkat dalam bahasa lang.
+func AskTextAsElaina(lang i18n.Lang, user string) (string, error) {
+	return AskText(lang.T("llm.elaina_system"), user)
+}
+
+// AskVision: jawaban atas gambar dari backend LLM_VISION.
+func AskVision(system, prompt string, img []byte, mime string) (string, error) {
+	if mime == "" {
+		mime = "image/jpeg"
+	}
+	return call(CapVision, func(ctx context.Context, p Provider) (string, error) {
+		return p.Vision(ctx, system, prompt, img, mime)
+	})
+}
+
+// Transcribe: transkrip audio dari backend LLM_TRANSCRIBE.
+func Transcribe(lang i18n.Lang, audio []byte, mime string) (string, error) {
+	if mime == "" {
+		mime = "audio/ogg"
+	}
+	return call(CapTranscribe, func(ctx context.Context, p Provider) (string, error) {
+		return p.Transcribe(ctx, audio, mime, lang)
+	})
+}
+
+// call menjalankan fn di backend kemampuan c. Detail error dicatat di log;
+// pemanggil menerima error bertipe (lihat errors.go).
+func call(c Capability, fn func(context.Context, Provider) (string, error)) (string, error) {
+	p := For(c)
+	if p == nil {
+		return "", ErrNoKey
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	s, err := fn(ctx, p)
+	if err != nil {
+		log.Printf("[LLM] %s via %s: %v", c, p.Name(), err)
+		return "", typed(err)
+	}
+	return s, nil
+}
+
+// postJSON mengirim body sebagai JSON dan mengembalikan body respons.
+// Status non-2xx menjadi error berisi potongan respons.
+func postJSON(ctx context.Context, url string, header map[string]string, body any) ([]byte, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	return do(req)
+}
+
+func do(req *http.Request) ([]byte, error) {
+	resp, err := httpc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	rb, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return rb, NewStatusError(resp, rb)
+	}
+	return rb, nil
+}
+
+// StatusError: backend membalas status HTTP non-2xx.
+type StatusError struct {
+	Code       int
+	Body       string
+	RetryAfter time.Duration // dari header Retry-After (0 = tidak ada)
+}
+
+// NewStatusError membungkus respons non-2xx (termasuk header Retry-After)
+// agar KeyPool bisa membedakan kuota, auth, dan gangguan sementara.
+func NewStatusError(resp *http.Response, body []byte) *StatusError {
+	se := &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+		se.RetryAfter = time.Duration(sec) * time.Second
+	}
+	return se
+}
+
+func (e *StatusError) Error() string {
+	body := e.Body
+	if len(body) > 300 {
+		body = body[:300] + "…"
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.Code, body)
+}
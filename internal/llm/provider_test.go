@@ -0,0 +1,142 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"wa-elaina/internal/config"
+	"wa-elaina/internal/fakeapi"
+)
+
+// initFakeGemini mengarahkan semua kemampuan ke server Gemini palsu.
+func initFakeGemini(t *testing.T, keys ...string) *fakeapi.GeminiServer {
+	t.Helper()
+	srv := fakeapi.NewGemini()
+	t.Cleanup(srv.Close)
+	Init(config.Config{
+		GeminiKeys:    keys,
+		GeminiBaseURL: srv.URL,
+		GeminiModel:   "gemini-test",
+		LLMText:       BackendGemini,
+		LLMVision:     BackendGemini,
+		LLMTranscribe: BackendGemini,
+		LLMJSON:       BackendGemini,
+	})
+	return srv
+}
+
+func TestGeminiAskText(t *testing.T) {
+	cases := []struct {
+		name    string
+		keys    []string
+		setup   func(s *fakeapi.GeminiServer)
+		want    string
+		wantErr error // nil = harus berhasil
+		hits    int
+	}{
+		{
+			name: "berhasil",
+			keys: []string{"k1"},
+			want: "Halo, aku Elaina (palsu).",
+			hits: 1,
+		},
+		{
+			name:  "429 pindah ke key berikutnya",
+			keys:  []string{"k1", "k2"},
+			setup: func(s *fakeapi.GeminiServer) { s.RateLimit(1) },
+			want:  "Halo, aku Elaina (palsu).",
+			hits:  2,
+		},
+		{
+			name:    "429 di semua key menjadi ErrQuota",
+			keys:    []string{"k1", "k2"},
+			setup:   func(s *fakeapi.GeminiServer) { s.RateLimit(2) },
+			wantErr: ErrQuota,
+			hits:    2,
+		},
+		{
+			name:  "JSON rusak tidak mencoba key lain",
+			keys:  []string{"k1", "k2"},
+			setup: func(s *fakeapi.GeminiServer) { s.Malformed(1) },
+			hits:  1,
+		},
+		{
+			name:    "kandidat kosong menjadi ErrEmpty",
+			keys:    []string{"k1"},
+			setup:   func(s *fakeapi.GeminiServer) { s.Fail(1, 200, `{"candidates":[]}`) },
+			wantErr: ErrEmpty,
+			hits:    1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := initFakeGemini(t, tc.keys...)
+			if tc.setup != nil {
+				tc.setup(srv)
+			}
+			got, err := AskText("sistem", "halo")
+			wantOK := tc.want != ""
+			switch {
+			case wantOK && err != nil:
+				t.Fatalf("error tak terduga: %v", err)
+			case wantOK && got != tc.want:
+				t.Fatalf("balasan = %q, mau %q", got, tc.want)
+			case !wantOK && err == nil:
+				t.Fatalf("mau error, dapat balasan %q", got)
+			case tc.wantErr != nil && !errors.Is(err, tc.wantErr):
+				t.Fatalf("error = %v, mau %v", err, tc.wantErr)
+			}
+			if !wantOK && strings.Contains(Friendly("id", err), "candidates") {
+				t.Fatalf("pesan ramah membocorkan respons mentah: %q", Friendly("id", err))
+			}
+			if n := srv.Hits("/models/gemini-test:generateContent"); n != tc.hits {
+				t.Fatalf("request = %d, mau %d", n, tc.hits)
+			}
+		})
+	}
+}
+
+func TestGeminiQuotaBenchesKey(t *testing.T) {
+	srv := initFakeGemini(t, "k1", "k2")
+	srv.RateLimit(1)
+	for i := 0; i < 3; i++ {
+		if _, err := AskText("", "halo"); err != nil {
+			t.Fatalf("panggilan %d: %v", i, err)
+		}
+	}
+	// k1 kena 429 sekali lalu di-bench: sisa panggilan memakai k2.
+	var k1 int
+	for _, r := range srv.Requests() {
+		if strings.Contains(r.Query, "key=k1") {
+			k1++
+		}
+	}
+	if k1 != 1 {
+		t.Fatalf("k1 dipakai %d kali, mau 1 (di-bench setelah 429)", k1)
+	}
+	st := GeminiKeys().Stats()
+	if st[0].Quota != 1 || st[0].BenchedTo.IsZero() {
+		t.Fatalf("statistik k1 = %+v", st[0])
+	}
+}
+
+func TestGeminiChatRoles(t *testing.T) {
+	srv := initFakeGemini(t, "k1")
+	_, err := AskChat("sistem", []Message{
+		{Role: RoleUser, Text: "hai"},
+		{Role: RoleUser, Text: "kamu siapa?"},
+		{Role: RoleModel, Text: "aku Elaina"},
+		{Role: RoleUser, Text: "oke"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(srv.Requests()[0].Body)
+	if strings.Count(body, `"role":"user"`) != 2 || strings.Count(body, `"role":"model"`) != 1 {
+		t.Fatalf("giliran tidak bergantian: %s", body)
+	}
+	if !strings.Contains(body, `"system_instruction"`) {
+		t.Fatalf("instruksi sistem tidak terpisah: %s", body)
+	}
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wa-elaina/internal/db"
+)
+
+// legacyDir: lokasi file JSON memory versi lama. Setelah diimpor, folder
+// diganti nama menjadi legacyDir+".imported" agar impor hanya sekali.
+const legacyDir = "data/memory"
+
+const legacyNames = "_usernames.json"
+
+// Server JID yang dikenal; nama file lama mengganti "@" dan ":" dengan "_".
+var jidServers = []string{"s.whatsapp.net", "g.us", "lid", "broadcast", "newsletter"}
+
+func importLegacy(s *db.Store, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var turns []db.MemoryTurn
+	nicks := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if e.Name() == legacyNames {
+			if err := json.Unmarshal(data, &nicks); err != nil {
+				log.Printf("[MEMORY] lewati %s: %v", path, err)
+			}
+			continue
+		}
+		var hist []Turn
+		if err := json.Unmarshal(data, &hist); err != nil {
+			log.Printf("[MEMORY] lewati %s: %v", path, err)
+			continue
+		}
+		// File lama tidak menyimpan waktu per giliran: pakai waktu ubah file
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		chat := legacyChatJID(strings.TrimSuffix(e.Name(), ".json"))
+		for _, t := range hist {
+			if strings.TrimSpace(t.Text) == "" {
+				continue
+			}
+			turns = append(turns, db.MemoryTurn{Chat: chat, Role: t.Role, Text: t.Text, At: info.ModTime()})
+		}
+	}
+
+	if err := s.ImportMemory(turns, nicks); err != nil {
+		return err
+	}
+	if err := os.Rename(dir, dir+".imported"); err != nil {
+		return err
+	}
+	log.Printf("[MEMORY] impor %s: %d giliran, %d nama panggilan → state DB", dir, len(turns), len(nicks))
+	return nil
+}
+
+// legacyChatJID membalik sanitize lama ("628xx_s.whatsapp.net" →
+// "628xx@s.whatsapp.net").
+func legacyChatJID(name string) string {
+	for _, server := range jidServers {
+		if user, ok := strings.CutSuffix(name, "_"+server); ok {
+			return user + "@" + server
+		}
+	}
+	return name
+}
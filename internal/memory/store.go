@@ -1,79 +1,107 @@
 package memory
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
+	"log"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
+
+	"wa-elaina/internal/db"
 )
 
+// Turn adalah satu giliran obrolan AI ("user" atau "assistant").
 type Turn struct {
-	Role string `json:"role"`
-	Text string `json:"text"`
+	Role   string    `json:"role"`
+	Text   string    `json:"text"`
+	Sender string    `json:"sender,omitempty"` // kosong untuk balasan bot
+	At     time.Time `json:"at,omitempty"`
 }
 
+const (
+	maxTurns  = 8   // pasangan giliran yang dikirim sebagai konteks
+	keepTurns = 200 // giliran per chat yang disimpan di state DB
+)
+
 var (
 	mu          sync.RWMutex
-	chatHistMap = make(map[string][]Turn)
+	store       *db.Store                 // nil = hanya di memori (sebelum Init)
+	chatHistMap = make(map[string][]Turn) // cache konteks per chat JID
 	userNameMap = make(map[string]string) // key: senderJID, value: nama
-	maxTurns    = 8
-	
+
 	reNameRequest = regexp.MustCompile(`(?i)^(panggil aku|sebut aku|nama aku|namaku|name is|call me)\s+(.+)$`)
 )
 
+// Init memakai state DB untuk riwayat & nama panggilan: mengimpor sekali
+// file lama data/memory/*.json lalu memuat nama panggilan. Riwayat chat
+// dimuat dari DB saat pertama kali dibutuhkan.
+func Init(s *db.Store) error {
+	mu.Lock()
+	defer mu.Unlock()
+	store = s
+	if err := importLegacy(s, legacyDir); err != nil {
+		log.Printf("[MEMORY] impor %s gagal: %v", legacyDir, err)
+	}
+	names, err := s.Nicknames()
+	if err != nil {
+		return err
+	}
+	userNameMap = names
+	chatHistMap = make(map[string][]Turn)
+	log.Printf("[MEMORY] %d nama panggilan dimuat dari state DB", len(names))
+	return nil
+}
+
+// Load mengembalikan giliran terakhir chat (maks maxTurns pasang).
 func Load(chatJID string) ([]Turn, error) {
 	mu.RLock()
-	defer mu.RUnlock()
 	hist, ok := chatHistMap[chatJID]
-	if !ok {
-		return nil, nil
+	mu.RUnlock()
+	if ok || store == nil {
+		return append([]Turn(nil), hist...), nil
+	}
+
+	rows, err := store.RecentMemoryTurns(chatJID, maxTurns*2)
+	if err != nil {
+		return nil, err
+	}
+	hist = make([]Turn, 0, len(rows))
+	for _, r := range rows {
+		hist = append(hist, Turn{Role: r.Role, Text: r.Text, Sender: r.Sender, At: r.At})
 	}
-	return hist, nil
+	mu.Lock()
+	if cur, ok := chatHistMap[chatJID]; ok {
+		hist = cur // SaveTurn lebih dulu mengisi cache
+	} else {
+		chatHistMap[chatJID] = hist
+	}
+	mu.Unlock()
+	return append([]Turn(nil), hist...), nil
 }
 
-func SaveTurn(chatJID, role, text string) error {
+// SaveTurn mencatat satu giliran; senderJID kosong untuk balasan bot.
+func SaveTurn(chatJID, senderJID, role, text string) error {
+	if _, err := Load(chatJID); err != nil {
+		log.Printf("[MEMORY] muat riwayat %s: %v", chatJID, err)
+	}
+	t := Turn{Role: role, Text: text, Sender: senderJID, At: time.Now()}
+
 	mu.Lock()
-	defer mu.Unlock()
-	
-	hist := chatHistMap[chatJID]
-	hist = append(hist, Turn{Role: role, Text: text})
-	
+	hist := append(chatHistMap[chatJID], t)
 	if len(hist) > maxTurns*2 {
 		hist = hist[len(hist)-(maxTurns*2):]
 	}
-	
 	chatHistMap[chatJID] = hist
-	return persistChat(chatJID, hist)
-}
+	mu.Unlock()
 
-func BuildContext(hist []Turn, newUserText, senderJID string) string {
-	var sb strings.Builder
-	
-	userName, exists := GetUserName(senderJID)
-	if !exists || userName == "" {
-		userName = "kamu"
+	if store == nil {
+		return nil
 	}
-	
-	if len(hist) > 0 {
-		sb.WriteString("Konteks percakapan sebelumnya:\n")
-		for _, t := range hist {
-			if t.Role == "user" {
-				sb.WriteString("User: " + t.Text + "\n")
-			} else {
-				sb.WriteString("Elaina: " + t.Text + "\n")
-			}
-		}
-		sb.WriteString("\n")
+	err := store.AddMemoryTurn(db.MemoryTurn{Chat: chatJID, Sender: senderJID, Role: role, Text: text, At: t.At})
+	if err != nil {
+		return err
 	}
-	
-	sb.WriteString("Nama pengguna: " + userName + "\n\n")
-	
-	sb.WriteString("Pertanyaan/teks baru:\n")
-	sb.WriteString(newUserText)
-	
-	return sb.String()
+	return store.PruneMemoryTurns(chatJID, keepTurns)
 }
 
 func DetectNameRequest(text string) (string, bool) {
@@ -90,80 +118,21 @@ func DetectNameRequest(text string) (string, bool) {
 }
 
 func SetUserName(senderJID, name string) error {
+	if store != nil {
+		if err := store.SetNickname(senderJID, name); err != nil {
+			return err
+		}
+	}
 	mu.Lock()
 	defer mu.Unlock()
-	
 	userNameMap[senderJID] = name
-	return persistUserNames()
+	return nil
 }
 
 func GetUserName(senderJID string) (string, bool) {
 	mu.RLock()
 	defer mu.RUnlock()
-	
+
 	name, ok := userNameMap[senderJID]
 	return name, ok
 }
-
-func persistChat(chatJID string, hist []Turn) error {
-	dir := "data/memory"
-	os.MkdirAll(dir, 0755)
-	
-	path := filepath.Join(dir, sanitize(chatJID)+".json")
-	data, _ := json.MarshalIndent(hist, "", "  ")
-	return os.WriteFile(path, data, 0644)
-}
-
-func persistUserNames() error {
-	dir := "data/memory"
-	os.MkdirAll(dir, 0755)
-	
-	path := filepath.Join(dir, "_usernames.json")
-	data, _ := json.MarshalIndent(userNameMap, "", "  ")
-	return os.WriteFile(path, data, 0644)
-}
-
-func LoadAll() {
-	mu.Lock()
-	defer mu.Unlock()
-	
-	loadUserNames()
-	
-	dir := "data/memory"
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return
-	}
-	
-	for _, e := range entries {
-		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") && e.Name() != "_usernames.json" {
-			path := filepath.Join(dir, e.Name())
-			data, err := os.ReadFile(path)
-			if err != nil {
-				continue
-			}
-			
-			var hist []Turn
-			if json.Unmarshal(data, &hist) == nil {
-				chatJID := strings.TrimSuffix(e.Name(), ".json")
-				chatHistMap[chatJID] = hist
-			}
-		}
-	}
-}
-
-func loadUserNames() {
-	path := filepath.Join("data/memory", "_usernames.json")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return
-	}
-	
-	json.Unmarshal(data, &userNameMap)
-}
-
-func sanitize(s string) string {
-	s = strings.ReplaceAll(s, "@", "_")
-	s = strings.ReplaceAll(s, ":", "_")
-	return s
-}
\ No newline at end of file
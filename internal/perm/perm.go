@@ -0,0 +1,272 @@
+// Package perm menyimpan role pengguna (owner, co-owner, moderator, premium,
+// banned) dan menyediakan cek izin yang bisa dipanggil semua fitur.
+//
+// Role bersifat global (bukan per chat) dan disimpan di db (tabel user_roles).
+// Owner selalu berasal dari ENV (owner.Detector), tidak bisa diberikan lewat
+// perintah.
+package perm
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/wa"
+)
+
+// Role terurut: makin besar makin tinggi. Cek izin memakai >= sehingga role
+// tinggi otomatis mencakup izin role di bawahnya.
+type Role int
+
+const (
+	Banned Role = iota - 1
+	User
+	Premium
+	Moderator
+	CoOwner
+	Owner
+)
+
+var roleNames = map[Role]string{
+	Banned:    "banned",
+	User:      "user",
+	Premium:   "premium",
+	Moderator: "moderator",
+	CoOwner:   "co-owner",
+	Owner:     "owner",
+}
+
+func (r Role) String() string {
+	if n, ok := roleNames[r]; ok {
+		return n
+	}
+	return fmt.Sprintf("role(%d)", int(r))
+}
+
+// Parse menerima nama role (juga "coowner", "mod", "vip", "ban").
+func Parse(s string) (Role, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "banned", "ban":
+		return Banned, true
+	case "user", "none", "member":
+		return User, true
+	case "premium", "vip":
+		return Premium, true
+	case "moderator", "mod":
+		return Moderator, true
+	case "co-owner", "coowner", "co_owner":
+		return CoOwner, true
+	case "owner":
+		return Owner, true
+	}
+	return User, false
+}
+
+// Grantable: role yang boleh diberikan lewat perintah (tanpa owner & user).
+func Grantable() []Role { return []Role{CoOwner, Moderator, Premium, Banned} }
+
+// ParseRequirements membaca spec "fitur=role,fitur2=role" (FEATURE_ROLES);
+// "none" berarti tanpa batasan.
+func ParseRequirements(spec string) (map[string]Role, error) {
+	out := map[string]Role{}
+	if strings.EqualFold(strings.TrimSpace(spec), "none") {
+		return out, nil
+	}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, role, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("format salah %q (fitur=role)", part)
+		}
+		r, ok := Parse(role)
+		if !ok || r == Banned {
+			return nil, fmt.Errorf("role tidak dikenal %q", role)
+		}
+		out[strings.ToLower(strings.TrimSpace(name))] = r
+	}
+	return out, nil
+}
+
+// Key: kunci penyimpanan role untuk JID (tanpa device).
+func Key(j types.JID) string { return j.ToNonAD().String() }
+
+// AltFunc mengembalikan alamat lain user yang sama (LID ↔ nomor HP);
+// ok=false bila pasangannya belum diketahui.
+type AltFunc func(j types.JID) (alt types.JID, ok bool)
+
+// Entry adalah satu role tersimpan.
+type Entry struct {
+	User    string
+	Role    Role
+	GrantBy string
+}
+
+// Checker membaca role dari db dengan cache in-memory.
+//
+// User yang sama bisa muncul sebagai nomor HP (@s.whatsapp.net) atau LID
+// (@lid). Dengan UseAlt, role disimpan di bawah nomor HP bila diketahui dan
+// dicari di kedua bentuk, sehingga role yang diberikan ke satu bentuk tetap
+// berlaku untuk bentuk lainnya.
+type Checker struct {
+	store *db.Store
+	alt   AltFunc
+
+	mu    sync.RWMutex
+	cache map[string]Role
+}
+
+func New(store *db.Store) *Checker {
+	return &Checker{store: store, cache: map[string]Role{}}
+}
+
+// UseAlt memasang pemetaan LID ↔ nomor HP (mis. LID store whatsmeow).
+func (c *Checker) UseAlt(fn AltFunc) {
+	c.mu.Lock()
+	c.alt = fn
+	c.cache = map[string]Role{}
+	c.mu.Unlock()
+}
+
+// keys: kunci penyimpanan user; keys[0] adalah bentuk nomor HP bila
+// diketahui, disusul bentuk lainnya. final=false bila UseAlt terpasang tapi
+// pasangan user belum diketahui: hasil cek bisa berubah begitu pemetaannya
+// masuk, jadi jangan di-cache.
+func (c *Checker) keys(user types.JID) (keys []string, final bool) {
+	key := Key(user)
+	c.mu.RLock()
+	alt := c.alt
+	c.mu.RUnlock()
+	if alt == nil {
+		return []string{key}, true
+	}
+	other, ok := alt(user.ToNonAD())
+	if !ok || other.IsEmpty() || Key(other) == key {
+		return []string{key}, false
+	}
+	if user.Server == types.HiddenUserServer {
+		return []string{Key(other), key}, true
+	}
+	return []string{key, Key(other)}, true
+}
+
+// Of mengembalikan role tersimpan untuk user (User jika tidak ada).
+func (c *Checker) Of(user types.JID) Role {
+	if c == nil || c.store == nil {
+		return User
+	}
+	key := Key(user)
+	c.mu.RLock()
+	r, ok := c.cache[key]
+	c.mu.RUnlock()
+	if ok {
+		return r
+	}
+	r = User
+	keys, final := c.keys(user)
+	for _, k := range keys {
+		name, err := c.store.GetRole(k)
+		if err != nil {
+			log.Printf("[ROLE] gagal memuat role %s: %v", k, err)
+			return r
+		}
+		if name == "" {
+			continue
+		}
+		if parsed, ok := Parse(name); ok {
+			r = parsed
+		}
+		break
+	}
+	if final {
+		c.mu.Lock()
+		c.cache[key] = r
+		c.mu.Unlock()
+	}
+	return r
+}
+
+// RoleOf: role efektif pengirim; owner dari ENV selalu Owner.
+func (c *Checker) RoleOf(sender types.JID, isOwner bool) Role {
+	if isOwner {
+		return Owner
+	}
+	return c.Of(sender)
+}
+
+// CanGrant: actor boleh mengubah role target (dari cur ke next) hanya jika
+// role actor lebih tinggi dari keduanya. Owner boleh apa saja selain Owner.
+func CanGrant(actor, cur, next Role) bool {
+	if next == Owner || cur == Owner {
+		return false
+	}
+	if actor == Owner {
+		return true
+	}
+	return actor > cur && actor > next
+}
+
+// Grant menyimpan role user; User berarti cabut (hapus baris). Role disimpan
+// di bawah bentuk nomor HP bila diketahui; baris di bentuk lain dihapus.
+func (c *Checker) Grant(user types.JID, role Role, by string) error {
+	keys, _ := c.keys(user)
+	stale := keys
+	if role != User {
+		if err := c.store.SetRole(keys[0], role.String(), by); err != nil {
+			return err
+		}
+		stale = keys[1:]
+	}
+	for _, k := range stale {
+		if err := c.store.DeleteRole(k); err != nil {
+			return err
+		}
+	}
+	// Cache dikunci per bentuk JID yang ditanyakan: kosongkan semuanya.
+	c.mu.Lock()
+	c.cache = map[string]Role{}
+	c.mu.Unlock()
+	return nil
+}
+
+// List mengembalikan semua role tersimpan, urut dari role tertinggi.
+func (c *Checker) List() ([]Entry, error) {
+	rows, err := c.store.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Entry, 0, len(rows))
+	for _, row := range rows {
+		r, ok := Parse(row.Role)
+		if !ok {
+			continue
+		}
+		out = append(out, Entry{User: row.User, Role: r, GrantBy: row.GrantBy})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Role > out[j].Role })
+	return out, nil
+}
+
+// IsGroupAdmin: true jika sender adalah admin/superadmin grup.
+func IsGroupAdmin(cli wa.Client, group, sender types.JID) bool {
+	if cli == nil {
+		return false
+	}
+	info, err := cli.GetGroupInfo(group)
+	if err != nil || info == nil {
+		return false
+	}
+	for _, p := range info.Participants {
+		if p.JID.String() == sender.String() {
+			return p.IsAdmin || p.IsSuperAdmin
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package perm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+)
+
+func newChecker(t *testing.T) *Checker {
+	t.Helper()
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return New(store)
+}
+
+func TestRoleAcrossLIDAndPhone(t *testing.T) {
+	pn := types.NewJID("6282222222222", types.DefaultUserServer)
+	lid := types.NewJID("123456789012345", types.HiddenUserServer)
+	pair := func(j types.JID) (types.JID, bool) {
+		switch j {
+		case pn:
+			return lid, true
+		case lid:
+			return pn, true
+		}
+		return types.JID{}, false
+	}
+
+	cases := []struct {
+		name    string
+		grantTo types.JID
+		askAs   types.JID
+	}{
+		{"grant ke nomor, kirim dari LID", pn, lid},
+		{"grant ke LID, kirim dari nomor", lid, pn},
+		{"grant ke LID, kirim dari LID dengan device", lid, types.JID{User: lid.User, Server: lid.Server, Device: 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newChecker(t)
+			c.UseAlt(pair)
+			if got := c.Of(tc.askAs); got != User {
+				t.Fatalf("role awal = %s", got)
+			}
+			if err := c.Grant(tc.grantTo, Premium, "test"); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Of(tc.askAs); got != Premium {
+				t.Fatalf("role = %s, mau premium", got)
+			}
+			list, err := c.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list) != 1 || list[0].User != pn.String() {
+				t.Fatalf("tersimpan %+v, mau satu baris di %s", list, pn)
+			}
+			if err := c.Grant(tc.askAs, User, "test"); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Of(tc.grantTo); got != User {
+				t.Fatalf("role setelah dicabut = %s", got)
+			}
+		})
+	}
+}
+
+func TestRoleLegacyLIDRow(t *testing.T) {
+	pn := types.NewJID("6282222222222", types.DefaultUserServer)
+	lid := types.NewJID("123456789012345", types.HiddenUserServer)
+	c := newChecker(t)
+	// Baris lama tersimpan di bentuk LID sebelum pemetaan diketahui.
+	if err := c.Grant(lid, Moderator, "test"); err != nil {
+		t.Fatal(err)
+	}
+	c.UseAlt(func(j types.JID) (types.JID, bool) {
+		if j == pn {
+			return lid, true
+		}
+		return pn, j == lid
+	})
+	if got := c.Of(pn); got != Moderator {
+		t.Fatalf("role dari nomor = %s, mau moderator", got)
+	}
+}
+
+// Role yang dicek dari LID sebelum pemetaan LID → nomor diketahui tidak boleh
+// tertahan di cache: begitu pemetaan masuk, role di nomor HP harus berlaku.
+func TestRoleMappingLearnedLater(t *testing.T) {
+	pn := types.NewJID("6282222222222", types.DefaultUserServer)
+	lid := types.NewJID("123456789012345", types.HiddenUserServer)
+	c := newChecker(t)
+	known := false
+	c.UseAlt(func(j types.JID) (types.JID, bool) {
+		if !known {
+			return types.JID{}, false
+		}
+		switch j {
+		case pn:
+			return lid, true
+		case lid:
+			return pn, true
+		}
+		return types.JID{}, false
+	})
+	if err := c.Grant(pn, Moderator, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Of(lid); got != User {
+		t.Fatalf("role LID tanpa pemetaan = %s, mau user", got)
+	}
+	known = true
+	if got := c.Of(lid); got != Moderator {
+		t.Fatalf("role LID setelah pemetaan diketahui = %s, mau moderator", got)
+	}
+}
@@ -0,0 +1,225 @@
+// Package quota membatasi pemakaian fitur mahal (API berbayar) per user:
+// cooldown antar pemakaian dan kuota harian, dengan override per chat.
+package quota
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/i18n"
+)
+
+// DefaultLimits dipakai jika QUOTA_LIMITS tidak mengatur fitur tersebut.
+// Format: fitur=cooldown/harian (harian 0 = tanpa batas harian).
+const DefaultLimits = "imggen=60s/5,hijabin=60s/5,tts=30s/20,vision=15s/30"
+
+// Decision adalah hasil Take.
+type Decision struct {
+	OK    bool
+	Wait  time.Duration // sisa cooldown (jika ditolak karena cooldown)
+	Used  int           // pemakaian hari ini (termasuk yang barusan jika OK)
+	Daily int           // batas harian yang berlaku (0 = tanpa batas)
+
+	// Pemakaian yang dicatat Take (kosong jika tidak ada), untuk Refund.
+	user, feature, day string
+	at, prevLast       time.Time
+}
+
+type Limiter struct {
+	store    *db.Store
+	defaults map[string]db.QuotaLimit
+	loc      *time.Location // batas hari kuota harian
+
+	mu     sync.Mutex
+	pruned string
+	now    func() time.Time
+}
+
+// New membuat limiter; spec (QUOTA_LIMITS) menimpa DefaultLimits per fitur.
+// Kuota harian direset tengah malam di zona waktu loc (TIMEZONE).
+func New(store *db.Store, spec string, loc *time.Location) (*Limiter, error) {
+	defs, _ := ParseLimits(DefaultLimits)
+	over, err := ParseLimits(spec)
+	for k, v := range over {
+		defs[k] = v
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Limiter{store: store, defaults: defs, loc: loc, now: time.Now}, err
+}
+
+// ParseLimits membaca "imggen=60s/5,tts=30s" (cooldown wajib, harian opsional).
+func ParseLimits(spec string) (map[string]db.QuotaLimit, error) {
+	out := map[string]db.QuotaLimit{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return out, fmt.Errorf("quota: format salah %q (contoh: tts=30s/20)", part)
+		}
+		cd, daily, _ := strings.Cut(val, "/")
+		lim, err := ParseLimit(cd, daily)
+		if err != nil {
+			return out, fmt.Errorf("quota: %s: %w", name, err)
+		}
+		out[strings.ToLower(strings.TrimSpace(name))] = lim
+	}
+	return out, nil
+}
+
+// ParseLimit membaca cooldown ("30s", "2m", "0") dan harian ("5", "" = 0).
+func ParseLimit(cooldown, daily string) (db.QuotaLimit, error) {
+	var lim db.QuotaLimit
+	cooldown = strings.TrimSpace(cooldown)
+	if cooldown != "" && cooldown != "0" {
+		d, err := time.ParseDuration(cooldown)
+		if err != nil || d < 0 {
+			return lim, fmt.Errorf("cooldown tidak valid %q", cooldown)
+		}
+		lim.Cooldown = d.Truncate(time.Second)
+	}
+	if daily = strings.TrimSpace(daily); daily != "" {
+		n, err := strconv.Atoi(daily)
+		if err != nil || n < 0 {
+			return lim, fmt.Errorf("kuota harian tidak valid %q", daily)
+		}
+		lim.Daily = n
+	}
+	return lim, nil
+}
+
+// Features mengembalikan nama fitur yang punya batas bawaan (urut abjad).
+func (l *Limiter) Features() []string {
+	out := make([]string, 0, len(l.defaults))
+	for k := range l.defaults {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Limited: fitur ini diatur limiter (punya batas bawaan).
+func (l *Limiter) Limited(feature string) bool {
+	_, ok := l.defaults[feature]
+	return ok
+}
+
+// Limits mengembalikan batas efektif semua fitur untuk satu chat.
+func (l *Limiter) Limits(chat string) (map[string]db.QuotaLimit, error) {
+	out := make(map[string]db.QuotaLimit, len(l.defaults))
+	for k, v := range l.defaults {
+		out[k] = v
+	}
+	over, err := l.store.ChatQuotas(chat)
+	if err != nil {
+		return out, err
+	}
+	for k, v := range over {
+		if _, ok := out[k]; ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// Take mengecek cooldown & kuota harian, lalu mencatat pemakaian jika lolos.
+func (l *Limiter) Take(chat, user, feature string) (Decision, error) {
+	if !l.Limited(feature) {
+		return Decision{OK: true}, nil
+	}
+	limits, err := l.Limits(chat)
+	if err != nil {
+		return Decision{OK: true}, err
+	}
+	lim := limits[feature]
+	if lim.Cooldown <= 0 && lim.Daily <= 0 {
+		return Decision{OK: true}, nil
+	}
+
+	// Satu user bisa memakai fitur dari beberapa chat sekaligus (worker paralel).
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	day := l.day(now)
+	l.prune(now)
+
+	u, err := l.store.GetQuotaUsage(user, feature, day)
+	if err != nil {
+		return Decision{OK: true}, err
+	}
+	dec := Decision{Used: u.Uses, Daily: lim.Daily}
+	if lim.Cooldown > 0 && !u.LastUsed.IsZero() {
+		if wait := u.LastUsed.Add(lim.Cooldown).Sub(now); wait > 0 {
+			dec.Wait = wait
+			return dec, nil
+		}
+	}
+	if lim.Daily > 0 && u.Uses >= lim.Daily {
+		return dec, nil
+	}
+	used, err := l.store.AddQuotaUsage(user, feature, day, now)
+	if err != nil {
+		return Decision{OK: true}, err
+	}
+	dec.OK, dec.Used = true, used
+	dec.user, dec.feature, dec.day = user, feature, day
+	dec.at, dec.prevLast = now, u.LastUsed
+	return dec, nil
+}
+
+// Refund membatalkan pemakaian yang dicatat Take (fitur gagal atau tidak
+// jadi dijalankan): jumlah harian dikurangi dan cooldown kembali ke
+// pemakaian sebelumnya. Decision tanpa catatan diabaikan.
+func (l *Limiter) Refund(dec Decision) error {
+	if dec.user == "" {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.store.RefundQuotaUsage(dec.user, dec.feature, dec.day, dec.at, dec.prevLast)
+}
+
+// Usage mengembalikan pemakaian user hari ini per fitur terbatas.
+func (l *Limiter) Usage(user string) map[string]int {
+	day := l.day(l.now())
+	out := map[string]int{}
+	for name := range l.defaults {
+		if u, err := l.store.GetQuotaUsage(user, name, day); err == nil {
+			out[name] = u.Uses
+		}
+	}
+	return out
+}
+
+// prune menghapus catatan sebelum kemarin sekali per hari; catatan kemarin
+// tetap disimpan agar cooldown yang melewati tengah malam tetap berlaku.
+func (l *Limiter) prune(now time.Time) {
+	day := l.day(now)
+	if l.pruned == day {
+		return
+	}
+	l.pruned = day
+	_ = l.store.PruneQuotaUsage(l.day(now.AddDate(0, 0, -1)))
+}
+
+// day: tanggal kuota (YYYY-MM-DD) di zona waktu limiter.
+func (l *Limiter) day(t time.Time) string { return t.In(l.loc).Format("2006-01-02") }
+
+// Message menyusun balasan ramah untuk permintaan yang ditolak.
+func (d Decision) Message(l i18n.Lang, feature string) string {
+	if d.Wait > 0 {
+		secs := int((d.Wait + time.Second - 1) / time.Second)
+		return l.T("kuota.wait", secs, feature)
+	}
+	return l.T("kuota.exhausted", feature, d.Used, d.Daily)
+}
@@ -0,0 +1,112 @@
+package quota
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"wa-elaina/internal/db"
+)
+
+func TestDailyResetInTimezone(t *testing.T) {
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	jkt, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("tzdata Asia/Jakarta tidak tersedia")
+	}
+	l, err := New(store, "imggen=0/1", jkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 16:30 UTC = 23:30 WIB; 17:30 UTC = 00:30 WIB hari berikutnya (UTC
+	// masih hari yang sama, jadi kuota hanya reset jika memakai TIMEZONE).
+	steps := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2025, 3, 10, 16, 30, 0, 0, time.UTC), true},
+		{time.Date(2025, 3, 10, 16, 45, 0, 0, time.UTC), false},
+		{time.Date(2025, 3, 10, 17, 30, 0, 0, time.UTC), true},
+		{time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC), false},
+	}
+	for i, st := range steps {
+		l.now = func() time.Time { return st.at }
+		dec, err := l.Take("chat", "user", "imggen")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dec.OK != st.want {
+			t.Fatalf("langkah %d (%s): OK = %t, mau %t", i, st.at.In(jkt).Format(time.RFC3339), dec.OK, st.want)
+		}
+	}
+}
+
+func TestRefundRestoresQuota(t *testing.T) {
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	l, err := New(store, "imggen=60s/2", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	take := func(offset time.Duration) Decision {
+		t.Helper()
+		l.now = func() time.Time { return at.Add(offset) }
+		dec, err := l.Take("chat", "user", "imggen")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dec
+	}
+
+	// Pemakaian pertama gagal → dikembalikan: tidak ada cooldown, kuota utuh.
+	first := take(0)
+	if !first.OK || first.Used != 1 {
+		t.Fatalf("take pertama = %+v", first)
+	}
+	if err := l.Refund(first); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Usage("user")["imggen"]; got != 0 {
+		t.Fatalf("pemakaian setelah refund = %d, mau 0", got)
+	}
+	if dec := take(time.Second); !dec.OK || dec.Used != 1 {
+		t.Fatalf("take setelah refund = %+v, mau OK tanpa cooldown", dec)
+	}
+
+	// Pemakaian kedua (setelah cooldown) gagal → cooldown kembali ke
+	// pemakaian yang berhasil sebelumnya, jumlah harian tetap 1.
+	second := take(2 * time.Minute)
+	if !second.OK || second.Used != 2 {
+		t.Fatalf("take kedua = %+v", second)
+	}
+	if err := l.Refund(second); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Usage("user")["imggen"]; got != 1 {
+		t.Fatalf("pemakaian setelah refund kedua = %d, mau 1", got)
+	}
+	if dec := take(2 * time.Minute); !dec.OK || dec.Used != 2 {
+		t.Fatalf("take setelah refund kedua = %+v, mau OK (kuota harian belum habis)", dec)
+	}
+
+	// Decision yang ditolak tidak mencatat apa pun; Refund diabaikan.
+	denied := take(2*time.Minute + time.Second)
+	if denied.OK {
+		t.Fatalf("take dalam cooldown lolos: %+v", denied)
+	}
+	if err := l.Refund(denied); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Usage("user")["imggen"]; got != 2 {
+		t.Fatalf("refund decision ditolak mengubah pemakaian: %d", got)
+	}
+}
@@ -0,0 +1,350 @@
+package reminder
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoTime = errors.New("waktu pengingat tidak ditemukan")
+	ErrPast   = errors.New("waktu pengingat sudah lewat")
+	ErrTooFar = errors.New("waktu pengingat terlalu jauh")
+)
+
+// MaxHorizon: pengingat paling jauh yang diterima.
+const MaxHorizon = 366 * 24 * time.Hour
+
+// Request adalah hasil parse kalimat pengingat.
+type Request struct {
+	At   time.Time
+	Text string // isi pengingat (boleh kosong)
+}
+
+var reIntent = regexp.MustCompile(`(?i)\b(?:(?:tolong|please|pls)\s+)?(?:ingatkan|ingetin|ingatin|ingetkan|remind)(?:\s+(?:aku|saya|gue|gw|kami|kita|me|us))?\b|\bset\s+(?:a\s+)?reminder\b|\bbuat(?:kan|in)?\s+pengingat\b`)
+
+// Detect: teks berisi permintaan pengingat ("ingatkan aku ...", "remind me ...").
+func Detect(text string) bool { return reIntent.MatchString(text) }
+
+const (
+	numAlt  = `\d+(?:[.,]\d+)?|setengah|satu|dua belas|dua|tiga|empat|lima|enam|tujuh|delapan|sembilan|sepuluh|sebelas|half an?|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve`
+	unitAlt = `detik|menit|mnt|jam|hari|minggu|bulan|seconds?|secs?|minutes?|mins?|hours?|hrs?|days?|weeks?|months?`
+	partPat = `(?:(?:` + numAlt + `)\s*(?:` + unitAlt + `)|se(?:detik|menit|jam|hari|minggu|bulan))`
+)
+
+var (
+	reRel  = regexp.MustCompile(`(?i)\b(?:(dalam|in)\s+)?(` + partPat + `(?:\s+(?:dan\s+|and\s+)?` + partPat + `)*)\b(?:\s+(lagi|dari\s+sekarang|from\s+now|later))?`)
+	rePart = regexp.MustCompile(`(?i)(` + numAlt + `|se)\s*(` + unitAlt + `)`)
+
+	reISODate = regexp.MustCompile(`\b(\d{4})-(\d{1,2})-(\d{1,2})\b`)
+	reNumDate = regexp.MustCompile(`\b(?:(?:tanggal|tgl|on)\s+)?(\d{1,2})[/-](\d{1,2})(?:[/-](\d{2,4}))?\b`)
+	reIDDate  = regexp.MustCompile(`(?i)\b(?:(?:tanggal|tgl|on)\s+)?(?:the\s+)?(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?(` + monthAlt + `)\b(?:\s+(\d{4}))?`)
+	reENDate  = regexp.MustCompile(`(?i)\b(?:on\s+)?(` + monthAlt + `)\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s+(\d{4}))?`)
+	reDayOnly = regexp.MustCompile(`(?i)\b(?:(?:tanggal|tgl)\s+(\d{1,2})\b|(?:on\s+)?the\s+(\d{1,2})(?:st|nd|rd|th)\b)`)
+	reDayWord = regexp.MustCompile(`(?i)\b(hari\s+ini|today|besok\s+lusa|lusa|besok|tomorrow|day\s+after\s+tomorrow|tonight|malam\s+ini|nanti\s+malam|minggu\s+depan|next\s+week)\b`)
+	reWeekday = regexp.MustCompile(`(?i)\b(?:(?:hari|on|next|this)\s+)?(senin|selasa|rabu|kamis|jum'?at|sabtu|minggu|monday|tuesday|wednesday|thursday|friday|saturday|sunday)(?:\s+(?:depan|ini|nanti))?\b`)
+	reClock   = regexp.MustCompile(`(?i)\b(?:jam|pukul|pkl|at)\s*(\d{1,2})(?:[:.](\d{2}))?(?:\s*(am|pm|pagi|siang|sore|malam))?\b`)
+	reClockAP = regexp.MustCompile(`(?i)\b(\d{1,2})(?:[:.](\d{2}))?\s*(am|pm)\b`)
+	reClockHM = regexp.MustCompile(`\b(\d{1,2})[:.](\d{2})\b`)
+	rePartDay = regexp.MustCompile(`(?i)\b(pagi|siang|sore|malam|morning|noon|afternoon|evening|night)\b`)
+	reFillerL = regexp.MustCompile(`(?i)^(?:untuk|buat|utk|bwt|agar|supaya|biar|kalau|kalo|bahwa|tentang|soal|nanti|ya|dong|to|that|about|for|of|please|pls|tolong)\b\s*`)
+	reFillerR = regexp.MustCompile(`(?i)\s*\b(?:ya|yaa|yah|dong|deh|please|pls|nanti|lagi)\s*$`)
+	reSpaces  = regexp.MustCompile(`\s+`)
+)
+
+const monthAlt = `januari|februari|maret|april|mei|juni|juli|agustus|september|oktober|november|desember|january|february|march|may|june|july|august|october|december|jan|feb|mar|apr|jun|jul|agu|agt|aug|sep|sept|okt|oct|nov|des|dec`
+
+var months = map[string]time.Month{
+	"januari": 1, "january": 1, "jan": 1,
+	"februari": 2, "february": 2, "feb": 2,
+	"maret": 3, "march": 3, "mar": 3,
+	"april": 4, "apr": 4,
+	"mei": 5, "may": 5,
+	"juni": 6, "june": 6, "jun": 6,
+	"juli": 7, "july": 7, "jul": 7,
+	"agustus": 8, "august": 8, "agu": 8, "agt": 8, "aug": 8,
+	"september": 9, "sep": 9, "sept": 9,
+	"oktober": 10, "october": 10, "okt": 10, "oct": 10,
+	"november": 11, "nov": 11,
+	"desember": 12, "december": 12, "des": 12, "dec": 12,
+}
+
+var weekdays = map[string]time.Weekday{
+	"minggu": time.Sunday, "sunday": time.Sunday,
+	"senin": time.Monday, "monday": time.Monday,
+	"selasa": time.Tuesday, "tuesday": time.Tuesday,
+	"rabu": time.Wednesday, "wednesday": time.Wednesday,
+	"kamis": time.Thursday, "thursday": time.Thursday,
+	"jumat": time.Friday, "jum'at": time.Friday, "friday": time.Friday,
+	"sabtu": time.Saturday, "saturday": time.Saturday,
+}
+
+var numWords = map[string]float64{
+	"se": 1, "setengah": 0.5, "half a": 0.5, "half an": 0.5, "a": 1, "an": 1,
+	"satu": 1, "one": 1, "dua": 2, "two": 2, "tiga": 3, "three": 3,
+	"empat": 4, "four": 4, "lima": 5, "five": 5, "enam": 6, "six": 6,
+	"tujuh": 7, "seven": 7, "delapan": 8, "eight": 8, "sembilan": 9, "nine": 9,
+	"sepuluh": 10, "ten": 10, "sebelas": 11, "eleven": 11, "dua belas": 12, "twelve": 12,
+}
+
+// jam bawaan untuk keterangan waktu tanpa jam ("besok pagi")
+var partHours = map[string]int{
+	"pagi": 8, "morning": 8,
+	"siang": 12, "noon": 12,
+	"sore": 16, "afternoon": 15,
+	"malam": 19, "evening": 18, "night": 20, "tonight": 20,
+}
+
+const defaultHour = 9 // tanggal tanpa jam → 09:00
+
+// Parse membaca waktu (relatif: "10 menit lagi", "in 2 hours"; absolut:
+// "besok jam 7", "senin 08:30", "20/10 jam 19", "october 20 at 7pm") dan isi
+// pengingat dari teks. now menentukan zona waktu hasil.
+func Parse(text string, now time.Time) (Request, error) {
+	s := " " + reSpaces.ReplaceAllString(strings.TrimSpace(text), " ") + " "
+	s = reIntent.ReplaceAllString(s, " ")
+
+	// 1) Relatif: "dalam 10 menit", "2 jam lagi", "in an hour"
+	for _, mt := range reRel.FindAllStringSubmatchIndex(s, -1) {
+		if mt[2] < 0 && mt[6] < 0 {
+			continue // "meeting 2 jam" tanpa dalam/lagi bukan waktu
+		}
+		d, months := relDuration(s[mt[4]:mt[5]])
+		at := now.AddDate(0, months, 0).Add(d)
+		return finish(Request{At: at, Text: s[:mt[0]] + " " + s[mt[1]:]}, now)
+	}
+
+	loc := now.Location()
+	y, mo, d := now.Date()
+	var (
+		hasDate, hasClock, explicitYear bool
+		rollYear, rollMonth             bool // tanggal tanpa tahun/bulan: geser jika lewat
+		hour, minute                    int
+		partHour                        = -1
+		dayOffset                       int
+		addDay                          bool
+	)
+
+	cut := func(re *regexp.Regexp) []string {
+		idx := re.FindStringSubmatchIndex(s)
+		if idx == nil {
+			return nil
+		}
+		out := make([]string, len(idx)/2)
+		for i := range out {
+			if idx[2*i] >= 0 {
+				out[i] = s[idx[2*i]:idx[2*i+1]]
+			}
+		}
+		s = s[:idx[0]] + " " + s[idx[1]:]
+		return out
+	}
+
+	// 2) Tanggal
+	if m := cut(reISODate); m != nil {
+		y, mo, d = atoi(m[1]), time.Month(atoi(m[2])), atoi(m[3])
+		hasDate, explicitYear = true, true
+	} else if m := cut(reIDDate); m != nil {
+		d, mo = atoi(m[1]), months[strings.ToLower(m[2])]
+		hasDate = true
+		if m[3] != "" {
+			y, explicitYear = atoi(m[3]), true
+		}
+	} else if m := cut(reENDate); m != nil {
+		mo, d = months[strings.ToLower(m[1])], atoi(m[2])
+		hasDate = true
+		if m[3] != "" {
+			y, explicitYear = atoi(m[3]), true
+		}
+	} else if m := cut(reNumDate); m != nil {
+		d, mo = atoi(m[1]), time.Month(atoi(m[2]))
+		hasDate = true
+		if m[3] != "" {
+			y, explicitYear = atoi(m[3]), true
+			if y < 100 {
+				y += 2000
+			}
+		}
+	} else if m := cut(reDayOnly); m != nil {
+		d = atoi(m[1] + m[2])
+		hasDate, rollMonth = true, true
+	}
+	if hasDate && !explicitYear && !rollMonth {
+		rollYear = true
+	}
+	if hasDate && (mo < 1 || mo > 12 || d < 1 || d > 31) {
+		return Request{}, ErrNoTime
+	}
+
+	if !hasDate {
+		if m := cut(reDayWord); m != nil {
+			hasDate = true
+			switch w := strings.Join(strings.Fields(strings.ToLower(m[1])), " "); w {
+			case "besok", "tomorrow":
+				dayOffset = 1
+			case "lusa", "besok lusa", "day after tomorrow":
+				dayOffset = 2
+			case "minggu depan", "next week":
+				dayOffset = 7
+			case "tonight", "malam ini", "nanti malam":
+				partHour = partHours["malam"]
+				if w == "tonight" {
+					partHour = partHours["tonight"]
+				}
+			}
+		} else if m := cut(reWeekday); m != nil {
+			hasDate = true
+			wd := weekdays[strings.ToLower(m[1])]
+			dayOffset = (int(wd) - int(now.Weekday()) + 7) % 7
+			if dayOffset == 0 {
+				dayOffset = 7
+			}
+		}
+	}
+
+	// 3) Jam
+	mer := ""
+	if m := cut(reClock); m != nil {
+		hour, minute, mer, hasClock = atoi(m[1]), atoi(m[2]), strings.ToLower(m[3]), true
+	} else if m := cut(reClockAP); m != nil {
+		hour, minute, mer, hasClock = atoi(m[1]), atoi(m[2]), strings.ToLower(m[3]), true
+	} else if m := cut(reClockHM); m != nil {
+		hour, minute, hasClock = atoi(m[1]), atoi(m[2]), true
+	}
+	if hasClock && mer == "" {
+		if m := cut(rePartDay); m != nil {
+			mer = strings.ToLower(m[1])
+		}
+	}
+	switch {
+	case mer != "":
+		hour, addDay = meridiem(hour, mer)
+	case hasClock && partHour < 0 && hour >= 1 && hour <= 6:
+		hour += 12 // "jam 2" tanpa keterangan biasanya siang/sore
+	case !hasClock:
+		if m := cut(rePartDay); m != nil {
+			partHour = partHours[strings.ToLower(m[1])]
+		}
+	}
+	if hasClock && partHour >= 17 && hour < 12 {
+		hour += 12 // "nanti malam jam 9"
+	}
+	if !hasClock && partHour >= 0 {
+		hour, hasClock = partHour, true
+	}
+	if hour > 23 || minute > 59 {
+		return Request{}, ErrNoTime
+	}
+	if !hasDate && !hasClock {
+		return Request{}, ErrNoTime
+	}
+	if !hasClock {
+		hour = defaultHour
+	}
+
+	at := time.Date(y, mo, d+dayOffset, hour, minute, 0, 0, loc)
+	if addDay {
+		at = at.AddDate(0, 0, 1)
+	}
+	switch {
+	case !hasDate && !at.After(now):
+		at = at.AddDate(0, 0, 1) // "jam 7" yang sudah lewat → besok
+	case rollMonth && !at.After(now):
+		at = at.AddDate(0, 1, 0)
+	case rollYear && !at.After(now):
+		at = at.AddDate(1, 0, 0)
+	}
+	return finish(Request{At: at, Text: s}, now)
+}
+
+func finish(r Request, now time.Time) (Request, error) {
+	r.Text = cleanText(r.Text)
+	if !r.At.After(now) {
+		return r, ErrPast
+	}
+	if r.At.Sub(now) > MaxHorizon {
+		return r, ErrTooFar
+	}
+	return r, nil
+}
+
+// meridiem menyesuaikan jam 12-an dengan am/pm/pagi/siang/sore/malam.
+// nextDay=true untuk "jam 12 malam" (tengah malam berikutnya).
+func meridiem(h int, part string) (hour int, nextDay bool) {
+	switch part {
+	case "am", "pagi", "morning":
+		if h == 12 {
+			return 0, false
+		}
+	case "pm", "sore", "afternoon", "evening":
+		if h < 12 {
+			return h + 12, false
+		}
+	case "siang", "noon":
+		if h < 7 {
+			return h + 12, false
+		}
+	case "malam", "night":
+		if h == 12 {
+			return 0, true
+		}
+		if h >= 5 && h < 12 {
+			return h + 12, false
+		}
+	}
+	return h, false
+}
+
+func relDuration(s string) (time.Duration, int) {
+	var total time.Duration
+	months := 0
+	for _, m := range rePart.FindAllStringSubmatch(s, -1) {
+		n := parseNum(strings.ToLower(m[1]))
+		switch unit := strings.ToLower(m[2]); {
+		case unit == "detik" || strings.HasPrefix(unit, "sec"):
+			total += time.Duration(n * float64(time.Second))
+		case unit == "menit" || unit == "mnt" || strings.HasPrefix(unit, "min"):
+			total += time.Duration(n * float64(time.Minute))
+		case unit == "jam" || strings.HasPrefix(unit, "hour") || strings.HasPrefix(unit, "hr"):
+			total += time.Duration(n * float64(time.Hour))
+		case unit == "hari" || strings.HasPrefix(unit, "day"):
+			total += time.Duration(n * 24 * float64(time.Hour))
+		case unit == "minggu" || strings.HasPrefix(unit, "week"):
+			total += time.Duration(n * 7 * 24 * float64(time.Hour))
+		case unit == "bulan" || strings.HasPrefix(unit, "month"):
+			months += int(math.Round(n))
+		}
+	}
+	return total, months
+}
+
+func parseNum(s string) float64 {
+	if v, ok := numWords[strings.Join(strings.Fields(s), " ")]; ok {
+		return v
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func cleanText(s string) string {
+	s = strings.Trim(reSpaces.ReplaceAllString(s, " "), " ,.:;-!")
+	for {
+		t := strings.TrimSpace(reFillerR.ReplaceAllString(reFillerL.ReplaceAllString(s, ""), ""))
+		t = strings.Trim(t, " ,.:;-!")
+		if t == s {
+			return s
+		}
+		s = t
+	}
+}
@@ -0,0 +1,76 @@
+package reminder
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	jkt, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Kamis, 15 Oktober 2026 10:00 WIB
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, jkt)
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 10, day, hour, min, 0, 0, jkt) }
+
+	cases := []struct {
+		in   string
+		at   time.Time
+		text string
+		err  error
+	}{
+		{in: "ingatkan aku besok jam 7 buat meeting", at: at(16, 7, 0), text: "meeting"},
+		{in: "remind me in 2 hours to drink water", at: at(15, 12, 0), text: "drink water"},
+		{in: "ingatkan aku jam 7 malam makan", at: at(15, 19, 0), text: "makan"},
+		{in: "ingetin jam 8 pagi olahraga", at: at(16, 8, 0), text: "olahraga"}, // sudah lewat → besok
+		{in: "ingatkan aku 30 menit lagi angkat jemuran", at: at(15, 10, 30), text: "angkat jemuran"},
+		{in: "ingatkan aku jam 2 rapat", at: at(15, 14, 0), text: "rapat"},
+		{in: "ingatkan aku jam 12 malam", at: at(16, 0, 0)},
+		{in: "ingatkan aku senin 08:30 laporan", at: at(19, 8, 30), text: "laporan"},
+		{in: "remind me on october 20 at 7pm call mom", at: at(20, 19, 0), text: "call mom"},
+		{in: "ingatkan aku tanggal 1 bayar listrik", at: time.Date(2026, 11, 1, 9, 0, 0, 0, jkt), text: "bayar listrik"},
+		{in: "ingatkan aku nanti ya", err: ErrNoTime},
+		{in: "ingatkan aku jam 25", err: ErrNoTime},
+		{in: "ingatkan aku 2020-01-01 jam 9", err: ErrPast},
+		{in: "ingatkan aku 2028-01-01", err: ErrTooFar},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			req, err := Parse(tc.in, now)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("err = %v, mau %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !req.At.Equal(tc.at) {
+				t.Errorf("At = %s, mau %s", req.At.Format(time.RFC3339), tc.at.Format(time.RFC3339))
+			}
+			if req.At.Location() != jkt {
+				t.Errorf("zona = %s, mau Asia/Jakarta", req.At.Location())
+			}
+			if req.Text != tc.text {
+				t.Errorf("Text = %q, mau %q", req.Text, tc.text)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for in, want := range map[string]bool{
+		"ingatkan aku besok jam 7": true,
+		"remind me in 10 minutes":  true,
+		"tolong buatkan pengingat": true,
+		"aku ingat kamu kok":       false,
+		"jam berapa sekarang?":     false,
+	} {
+		if got := Detect(in); got != want {
+			t.Errorf("Detect(%q) = %t, mau %t", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package reminder menyediakan pengingat bahasa alami ("ingatkan aku besok
+// jam 7 buat meeting"): parser waktu (Indonesia & Inggris, relatif & absolut)
+// dan scheduler yang menyimpan job di state DB sehingga tetap jalan setelah
+// restart.
+package reminder
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+	_ "time/tzdata" // zona waktu tetap tersedia di image tanpa tzdata
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+)
+
+const (
+	MaxPerUser   = 20               // pengingat aktif maksimum per user
+	pollInterval = 30 * time.Second // cek ulang saat WA belum siap / kirim gagal
+	lateAfter    = 2 * time.Minute  // lewat dari ini dianggap terlambat (bot mati)
+	keepSent     = 7 * 24 * time.Hour
+	maxAttempts  = 10 // kirim gagal sementara maksimum (≈5 menit) sebelum menyerah
+)
+
+var ErrLimit = errors.New("pengingat aktif sudah maksimal")
+
+// ErrPermanent dibungkus Deliver untuk kegagalan yang tidak akan sembuh bila
+// diulang (JID tidak valid, bot sudah keluar dari grup): pengingat langsung
+// ditandai gagal.
+var ErrPermanent = errors.New("pengingat tidak bisa dikirim")
+
+// Deliver mengirim satu pengingat; error = dicoba lagi di putaran berikutnya
+// (maksimal maxAttempts kali), error yang membungkus ErrPermanent = menyerah.
+type Deliver func(r db.Reminder, late bool) error
+
+// LoadLocation memuat zona waktu (mis. "Asia/Jakarta"); gagal → WIB (UTC+7).
+func LoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("[REMINDER] zona waktu %q: %v, pakai WIB", name, err)
+		return time.FixedZone("WIB", 7*3600)
+	}
+	return loc
+}
+
+type Scheduler struct {
+	store   *db.Store
+	loc     *time.Location
+	ready   *atomic.Bool
+	deliver Deliver
+	wake    chan struct{}
+}
+
+func NewScheduler(store *db.Store, loc *time.Location, ready *atomic.Bool, deliver Deliver) *Scheduler {
+	return &Scheduler{
+		store:   store,
+		loc:     loc,
+		ready:   ready,
+		deliver: deliver,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Now: waktu sekarang di zona waktu scheduler (dipakai Parse).
+func (s *Scheduler) Now() time.Time { return time.Now().In(s.loc) }
+
+func (s *Scheduler) Location() *time.Location { return s.loc }
+
+// Add menyimpan pengingat baru lalu membangunkan loop agar timer dihitung ulang.
+func (s *Scheduler) Add(chat, user types.JID, req Request) (int64, error) {
+	key := user.ToNonAD().String()
+	pending, err := s.store.PendingReminders(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(pending) >= MaxPerUser {
+		return 0, ErrLimit
+	}
+	id, err := s.store.AddReminder(db.Reminder{
+		Chat:    chat.String(),
+		User:    key,
+		Text:    req.Text,
+		Due:     req.At,
+		Created: time.Now(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.Wake()
+	return id, nil
+}
+
+// Pending: pengingat aktif milik user.
+func (s *Scheduler) Pending(user types.JID) ([]db.Reminder, error) {
+	return s.store.PendingReminders(user.ToNonAD().String())
+}
+
+// Delete menghapus pengingat aktif milik user; false jika tidak ditemukan.
+func (s *Scheduler) Delete(id int64, user types.JID) (bool, error) {
+	return s.store.DeleteReminder(id, user.ToNonAD().String())
+}
+
+func (s *Scheduler) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run menjalankan loop sampai ctx selesai. Pengingat yang jatuh tempo saat
+// bot mati dikirim begitu WA siap (ditandai terlambat).
+func (s *Scheduler) Run(ctx context.Context) {
+	var lastPrune time.Time
+	for {
+		s.fire()
+		if time.Since(lastPrune) > 24*time.Hour {
+			if err := s.store.PruneReminders(time.Now().Add(-keepSent)); err != nil {
+				log.Printf("[REMINDER] prune: %v", err)
+			}
+			lastPrune = time.Now()
+		}
+
+		wait := pollInterval
+		if next, ok, err := s.store.NextReminderDue(); err != nil {
+			log.Printf("[REMINDER] jadwal berikutnya: %v", err)
+		} else if ok {
+			if d := time.Until(next); d > 0 && d < wait {
+				wait = d
+			}
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-s.wake:
+			t.Stop()
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Scheduler) fire() {
+	if s.ready != nil && !s.ready.Load() {
+		return
+	}
+	now := time.Now()
+	due, err := s.store.DueReminders(now)
+	if err != nil {
+		log.Printf("[REMINDER] baca jadwal: %v", err)
+		return
+	}
+	for _, r := range due {
+		if err := s.deliver(r, now.Sub(r.Due) > lateAfter); err != nil {
+			s.failed(r, err, now)
+			continue
+		}
+		if err := s.store.MarkReminderSent(r.ID, now); err != nil {
+			log.Printf("[REMINDER] tandai #%d: %v", r.ID, err)
+		}
+	}
+}
+
+// failed mencatat kirim gagal: error permanen atau percobaan yang sudah
+// habis menandai pengingat gagal, selain itu dicoba lagi putaran berikutnya.
+func (s *Scheduler) failed(r db.Reminder, err error, now time.Time) {
+	if !errors.Is(err, ErrPermanent) {
+		n, aerr := s.store.RecordReminderAttempt(r.ID)
+		if aerr != nil {
+			log.Printf("[REMINDER] catat percobaan #%d: %v", r.ID, aerr)
+		}
+		if aerr != nil || n < maxAttempts {
+			log.Printf("[REMINDER] kirim #%d ke %s (percobaan %d/%d): %v", r.ID, r.Chat, n, maxAttempts, err)
+			return
+		}
+	}
+	log.Printf("[REMINDER] #%d ke %s gagal, tidak dicoba lagi: %v", r.ID, r.Chat, err)
+	if err := s.store.MarkReminderFailed(r.ID, now); err != nil {
+		log.Printf("[REMINDER] tandai gagal #%d: %v", r.ID, err)
+	}
+}
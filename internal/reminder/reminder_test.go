@@ -0,0 +1,131 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"wa-elaina/internal/db"
+)
+
+// Pengingat yang jatuh tempo saat bot mati tetap terkirim oleh scheduler
+// baru (setelah restart) begitu WA siap, ditandai terlambat, dan hanya sekali.
+func TestFirePendingAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	chat := types.NewJID("6282222222222", types.DefaultUserServer)
+	loc := LoadLocation("Asia/Jakarta")
+
+	// Sebelum restart: satu pengingat sudah lewat, satu masih di masa depan.
+	store, err := db.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := NewScheduler(store, loc, nil, func(db.Reminder, bool) error { return nil })
+	if _, err := before.Add(chat, chat, Request{At: time.Now().Add(-10 * time.Minute), Text: "minum obat"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := before.Add(chat, chat, Request{At: time.Now().Add(time.Hour), Text: "rapat"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// Setelah restart
+	store, err = db.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	var ready atomic.Bool
+	var got []db.Reminder
+	var lates []bool
+	fail := true
+	s := NewScheduler(store, loc, &ready, func(r db.Reminder, late bool) error {
+		if fail {
+			return errors.New("kirim gagal")
+		}
+		got = append(got, r)
+		lates = append(lates, late)
+		return nil
+	})
+
+	s.fire() // WA belum siap
+	if len(got) != 0 {
+		t.Fatalf("terkirim sebelum WA siap: %+v", got)
+	}
+	ready.Store(true)
+	s.fire() // kirim gagal → tetap antre
+	if pending, _ := s.Pending(chat); len(pending) != 2 {
+		t.Fatalf("pending setelah gagal kirim = %d, mau 2", len(pending))
+	}
+
+	fail = false
+	s.fire()
+	if len(got) != 1 || got[0].Text != "minum obat" || !lates[0] {
+		t.Fatalf("terkirim %+v (late %v), mau satu pengingat terlambat", got, lates)
+	}
+	s.fire()
+	if len(got) != 1 {
+		t.Fatalf("pengingat terkirim dua kali: %+v", got)
+	}
+	pending, err := s.Pending(chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Text != "rapat" {
+		t.Fatalf("pending = %+v, mau hanya \"rapat\"", pending)
+	}
+}
+
+// Error permanen langsung menandai pengingat gagal; error sementara dicoba
+// ulang paling banyak maxAttempts kali, tidak selamanya.
+func TestFireGivesUp(t *testing.T) {
+	store, err := db.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	chat := types.NewJID("6282222222222", types.DefaultUserServer)
+	calls := map[string]int{}
+	s := NewScheduler(store, LoadLocation("Asia/Jakarta"), nil, func(r db.Reminder, _ bool) error {
+		calls[r.Text]++
+		if r.Text == "grup lama" {
+			return fmt.Errorf("%w: %w", ErrPermanent, errors.New("not in group"))
+		}
+		return errors.New("timeout")
+	})
+	for _, text := range []string{"grup lama", "jaringan"} {
+		if _, err := s.Add(chat, chat, Request{At: time.Now().Add(-time.Minute), Text: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.fire()
+	if calls["grup lama"] != 1 {
+		t.Fatalf("error permanen dikirim %d kali, mau 1", calls["grup lama"])
+	}
+	pending, err := s.Pending(chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Text != "jaringan" || pending[0].Attempts != 1 {
+		t.Fatalf("pending = %+v, mau hanya \"jaringan\" dengan 1 percobaan", pending)
+	}
+
+	for i := 0; i < 2*maxAttempts; i++ {
+		s.fire()
+	}
+	if calls["grup lama"] != 1 || calls["jaringan"] != maxAttempts {
+		t.Fatalf("percobaan = %v, mau grup lama=1 jaringan=%d", calls, maxAttempts)
+	}
+	if pending, _ := s.Pending(chat); len(pending) != 0 {
+		t.Fatalf("masih pending setelah percobaan habis: %+v", pending)
+	}
+	if _, ok, _ := store.NextReminderDue(); ok {
+		t.Fatal("pengingat gagal masih dijadwalkan")
+	}
+}
@@ -1,13 +1,16 @@
 package tiktok
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
 	"go.mau.fi/whatsmeow/types"
 
 	dl "wa-elaina/downloader"
+	"wa-elaina/internal/i18n"
 	"wa-elaina/internal/util"
 	"wa-elaina/internal/wa"
 )
@@ -25,19 +28,44 @@ type Handler struct {
 	L      Limits
 }
 
-func (h *Handler) TryHandle(text string, chat types.JID) bool {
+// TryHandle mengunduh TikTok dari text lalu mengirim ke info.Chat; pesan
+// info diberi reaksi progres ⏳ → ✅/❌. Status dan error ditulis ke satu
+// pesan placeholder yang diedit, bukan pesan terpisah. Jika ctx dibatalkan
+// (pesan ditarik), unduhan berhenti di langkah berikutnya dan placeholder
+// ditarik.
+func (h *Handler) TryHandle(ctx context.Context, info types.MessageInfo, text string) bool {
 	urls := dl.DetectTikTokURLs(text)
 	if len(urls) == 0 {
 		return false
 	}
+	pg := wa.StartProgress(ctx, h.Send.C, info, wa.PresenceNone)
+	ok := false
+	defer func() { pg.Done(ok) }()
+
+	dst := wa.DestJID(info.Chat)
+	lang := i18n.For(info.Chat.String())
+	ph, _ := h.Send.Placeholder(dst, lang.T("tiktok.fetching"))
+	canceled := func() bool {
+		if ctx.Err() == nil {
+			return false
+		}
+		log.Printf("[TIKTOK] dibatalkan chat=%s id=%s", info.Chat.String(), info.ID)
+		_ = ph.Revoke()
+		return true
+	}
 
 	videoURL, audioURL, images, err := dl.GetTikTokFromTikwm(h.Client, urls)
+	if canceled() {
+		return true
+	}
 	if err != nil {
-		_ = h.Send.Text(wa.DestJID(chat), "Maaf, gagal mengambil media TikTok. Coba kirim lagi ya.")
+		_ = ph.Edit(lang.T("tiktok.fetch_failed"))
 		return true
 	}
-
-	dst := wa.DestJID(chat)
+	audio := ""
+	if a := strings.TrimSpace(audioURL); a != "" {
+		audio = "\n" + lang.T("tiktok.audio", a)
+	}
 
 	// Slides
 	if len(images) > 0 {
@@ -45,44 +73,65 @@ func (h *Handler) TryHandle(text string, chat types.JID) bool {
 		if h.L.Slides > 0 && total > h.L.Slides {
 			total = h.L.Slides
 		}
+		sent := 0
 		for i := 0; i < total; i++ {
+			if canceled() {
+				return true
+			}
+			_ = ph.Edit(lang.T("tiktok.slide_step", i+1, total))
 			imgURL := images[i]
 			size, ctype, _ := util.HeadInfo(h.Client, imgURL)
 
 			// if too big for image but OK for doc
 			if size > 0 && h.L.Image > 0 && size > h.L.Image && (h.L.Doc <= 0 || size <= h.L.Doc) {
 				if data, mime, err := util.DownloadBytes(h.Client, imgURL, h.L.Doc); err == nil {
+					if canceled() {
+						return true
+					}
 					if mime == "" { mime = ctype }
 					if mime == "" { mime = "image/jpeg" }
-					_ = h.Send.Document(dst, data, mime, fmt.Sprintf("slide_%d.jpg", i+1), fmt.Sprintf("TikTok 🖼️ slide %d/%d (dokumen)", i+1, total))
+					if h.Send.Document(dst, data, mime, fmt.Sprintf("slide_%d.jpg", i+1), lang.T("tiktok.slide_doc_caption", i+1, total)) == nil {
+						sent++
+					}
 				}
 				continue
 			}
 
 			if data, mime, err := util.DownloadBytes(h.Client, imgURL, h.L.Image); err == nil {
+				if canceled() {
+					return true
+				}
 				if mime == "" { mime = "image/jpeg" }
-				_ = h.Send.Image(dst, data, mime, fmt.Sprintf("TikTok 🖼️ slide %d/%d", i+1, total))
+				if h.Send.Image(dst, data, mime, lang.T("tiktok.slide_caption", i+1, total)) == nil {
+					sent++
+				}
 			}
 		}
-		if s := strings.TrimSpace(audioURL); s != "" {
-			_ = h.Send.Text(dst, "🔊 Audio: "+s)
+		ok = sent > 0
+		if ok {
+			_ = ph.Edit(lang.T("tiktok.slides_done", sent, total) + audio)
+		} else {
+			_ = ph.Edit(lang.T("tiktok.slides_failed") + audio)
 		}
 		return true
 	}
 
 	// Video
 	if s := strings.TrimSpace(videoURL); s != "" {
+		_ = ph.Edit(lang.T("tiktok.video_step"))
 		size, ctype, _ := util.HeadInfo(h.Client, s)
 
 		// fallback document
 		if size > 0 && h.L.Video > 0 && size > h.L.Video && (h.L.Doc <= 0 || size <= h.L.Doc) {
 			if data, mime, err := util.DownloadBytes(h.Client, s, h.L.Doc); err == nil {
+				if canceled() {
+					return true
+				}
 				if mime == "" { mime = ctype }
 				if mime == "" { mime = "video/mp4" }
-				if h.Send.Document(dst, data, mime, "tiktok.mp4", "TikTok 🎬 (dokumen)") == nil {
-					if a := strings.TrimSpace(audioURL); a != "" {
-						_ = h.Send.Text(dst, "🔊 Audio: "+a)
-					}
+				if h.Send.Document(dst, data, mime, "tiktok.mp4", lang.T("tiktok.video_doc_caption")) == nil {
+					ok = true
+					_ = ph.Edit(lang.T("tiktok.video_done") + audio)
 					return true
 				}
 			}
@@ -90,22 +139,28 @@ func (h *Handler) TryHandle(text string, chat types.JID) bool {
 
 		// normal video
 		if data, mime, err := util.DownloadBytes(h.Client, s, h.L.Video); err == nil {
+			if canceled() {
+				return true
+			}
 			if mime == "" { mime = "video/mp4" }
-			if h.Send.Video(dst, data, mime, "TikTok 🎬") == nil {
-				if a := strings.TrimSpace(audioURL); a != "" {
-					_ = h.Send.Text(dst, "🔊 Audio: "+a)
-				}
+			if h.Send.Video(dst, data, mime, lang.T("tiktok.video_caption")) == nil {
+				ok = true
+				_ = ph.Edit(lang.T("tiktok.video_done") + audio)
 				return true
 			}
 		}
 	}
 
+	if canceled() {
+		return true
+	}
+
 	// Audio only (rare)
-	if a := strings.TrimSpace(audioURL); a != "" {
-		_ = h.Send.Text(dst, "🔊 Audio: "+a)
+	if audio != "" {
+		ok = ph.Edit(strings.TrimPrefix(audio, "\n")) == nil
 		return true
 	}
 
-	_ = h.Send.Text(dst, "Maaf, tidak menemukan media valid dari TikTok.")
+	_ = ph.Edit(lang.T("tiktok.no_media"))
 	return true
 }
@@ -0,0 +1,187 @@
+// Package trigger menyediakan satu matcher nama panggilan bot (trigger +
+// alias) per chat, dipakai bersama oleh router dan semua fitur.
+//
+// Alias per chat disimpan di db (tabel chat_triggers) dan diatur admin lewat
+// !trigger; chat tanpa pengaturan memakai daftar bawaan dari ENV TRIGGER.
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"wa-elaina/internal/db"
+)
+
+// Fallback dipakai jika ENV TRIGGER kosong.
+const Fallback = "elaina"
+
+const (
+	MaxAliases = 8
+	maxLen     = 32
+)
+
+var reValid = regexp.MustCompile(`^[\p{L}\p{N}_-]+( [\p{L}\p{N}_-]+)*$`)
+
+type compiled struct {
+	words []string
+	re    *regexp.Regexp
+}
+
+// Matcher menyimpan cache regex per chat; aman dipakai dari banyak goroutine.
+type Matcher struct {
+	store    *db.Store
+	defaults compiled
+
+	mu    sync.RWMutex
+	cache map[string]compiled
+}
+
+// New membuat matcher dengan daftar bawaan spec ("elaina" atau "elaina,ela").
+// store boleh nil (hanya bawaan, tanpa alias per chat).
+func New(store *db.Store, spec string) *Matcher {
+	words, err := ParseList(spec)
+	if err != nil || len(words) == 0 {
+		if err != nil {
+			log.Printf("[TRIGGER] TRIGGER=%q: %v, pakai %q", spec, err, Fallback)
+		}
+		words = []string{Fallback}
+	}
+	return &Matcher{
+		store:    store,
+		defaults: compile(words),
+		cache:    map[string]compiled{},
+	}
+}
+
+// ParseList memecah daftar alias (pemisah koma), normalisasi huruf kecil &
+// spasi, buang duplikat, lalu validasi (huruf/angka/-/_, maks 32 karakter).
+func ParseList(s string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ",") {
+		w := strings.Join(strings.Fields(strings.ToLower(part)), " ")
+		if w == "" || seen[w] {
+			continue
+		}
+		if len([]rune(w)) > maxLen || !reValid.MatchString(w) {
+			return nil, fmt.Errorf("alias tidak valid: %q", w)
+		}
+		seen[w] = true
+		out = append(out, w)
+	}
+	if len(out) > MaxAliases {
+		return nil, fmt.Errorf("maksimal %d alias", MaxAliases)
+	}
+	return out, nil
+}
+
+func compile(words []string) compiled {
+	alts := make([]string, len(words))
+	for i, w := range words {
+		alts[i] = strings.ReplaceAll(regexp.QuoteMeta(w), " ", `\s+`)
+	}
+	return compiled{
+		words: words,
+		re:    regexp.MustCompile(`(?i)\b(?:` + strings.Join(alts, "|") + `)\b`),
+	}
+}
+
+func (m *Matcher) get(chat string) compiled {
+	m.mu.RLock()
+	c, ok := m.cache[chat]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+	c = m.defaults
+	if m.store != nil && chat != "" {
+		words, err := m.store.ChatTriggers(chat)
+		if err != nil {
+			log.Printf("[TRIGGER] gagal memuat alias %s: %v", chat, err)
+			return c
+		}
+		if len(words) > 0 {
+			c = compile(words)
+		}
+	}
+	m.mu.Lock()
+	m.cache[chat] = c
+	m.mu.Unlock()
+	return c
+}
+
+// Words mengembalikan alias aktif chat (alias pertama = nama utama).
+func (m *Matcher) Words(chat string) []string {
+	return append([]string(nil), m.get(chat).words...)
+}
+
+// Primary: nama panggilan utama chat (dipakai di teks bantuan).
+func (m *Matcher) Primary(chat string) string { return m.get(chat).words[0] }
+
+// Has: true jika w termasuk alias aktif chat.
+func (m *Matcher) Has(chat, w string) bool {
+	w = strings.ToLower(strings.TrimSpace(w))
+	for _, a := range m.get(chat).words {
+		if a == w {
+			return true
+		}
+	}
+	return false
+}
+
+// Regexp mengembalikan regex gabungan alias chat (case-insensitive, per kata).
+func (m *Matcher) Regexp(chat string) *regexp.Regexp { return m.get(chat).re }
+
+// Match: teks menyebut salah satu alias chat.
+func (m *Matcher) Match(chat, text string) bool { return m.get(chat).re.MatchString(text) }
+
+// Strip menghapus semua alias chat dari teks lalu merapikan spasi tepi.
+func (m *Matcher) Strip(chat, text string) string {
+	return strings.TrimSpace(m.get(chat).re.ReplaceAllString(text, ""))
+}
+
+// Custom: true jika chat memakai alias sendiri (bukan bawaan).
+func (m *Matcher) Custom(chat string) bool {
+	if m.store == nil {
+		return false
+	}
+	words, err := m.store.ChatTriggers(chat)
+	return err == nil && len(words) > 0
+}
+
+// Set menyimpan alias chat (menggantikan yang lama).
+func (m *Matcher) Set(chat string, words []string) error {
+	if m.store == nil {
+		return errors.New("state db tidak tersedia")
+	}
+	if len(words) == 0 {
+		return m.Reset(chat)
+	}
+	if err := m.store.SetChatTriggers(chat, words); err != nil {
+		return err
+	}
+	m.forget(chat)
+	return nil
+}
+
+// Reset menghapus alias chat sehingga kembali ke bawaan.
+func (m *Matcher) Reset(chat string) error {
+	if m.store == nil {
+		return errors.New("state db tidak tersedia")
+	}
+	if err := m.store.DeleteChatTriggers(chat); err != nil {
+		return err
+	}
+	m.forget(chat)
+	return nil
+}
+
+func (m *Matcher) forget(chat string) {
+	m.mu.Lock()
+	delete(m.cache, chat)
+	m.mu.Unlock()
+}
@@ -0,0 +1,25 @@
+package wa
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Client adalah bagian whatsmeow.Client yang dipakai router, Sender, dan fitur.
+// *whatsmeow.Client memenuhi interface ini; untuk tes tersedia watest.FakeClient.
+type Client interface {
+	SendMessage(ctx context.Context, to types.JID, message *waProto.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
+	Upload(ctx context.Context, plaintext []byte, appInfo whatsmeow.MediaType) (whatsmeow.UploadResponse, error)
+	Download(ctx context.Context, msg whatsmeow.DownloadableMessage) ([]byte, error)
+	GetGroupInfo(jid types.JID) (*types.GroupInfo, error)
+	UpdateGroupParticipants(jid types.JID, participantChanges []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error)
+	BuildRevoke(chat, sender types.JID, id types.MessageID) *waProto.Message
+	BuildEdit(chat types.JID, id types.MessageID, newContent *waProto.Message) *waProto.Message
+	BuildReaction(chat, sender types.JID, id types.MessageID, reaction string) *waProto.Message
+	SendChatPresence(jid types.JID, state types.ChatPresence, media types.ChatPresenceMedia) error
+}
+
+var _ Client = (*whatsmeow.Client)(nil)
@@ -0,0 +1,148 @@
+package wa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+)
+
+// Status login yang dilaporkan lewat LoginState (mis. ke /login/status).
+const (
+	LoginIdle       = "idle"
+	LoginConnecting = "connecting"
+	LoginWaitQR     = "qr"        // menunggu QR discan
+	LoginWaitPair   = "pair_code" // menunggu kode pairing dimasukkan
+	LoginLoggedIn   = "logged_in"
+	LoginTimeout    = "timeout"
+	LoginError      = "error"
+)
+
+// ErrLoginTimeout: QR/kode pairing kedaluwarsa sebelum dipakai; Login boleh diulang.
+var ErrLoginTimeout = errors.New("login: QR/kode pairing kedaluwarsa")
+
+// LoginSnapshot adalah salinan state login pada satu waktu.
+type LoginSnapshot struct {
+	Status   string    `json:"status"`
+	QR       string    `json:"-"` // isi QR mentah; dirender jadi PNG oleh httpapi
+	HasQR    bool      `json:"has_qr"`
+	PairCode string    `json:"pair_code,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	Expires  time.Time `json:"expires,omitempty"`
+	Updated  time.Time `json:"updated"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// LoginState dibagikan antara alur login dan HTTP API.
+type LoginState struct {
+	mu   sync.RWMutex
+	snap LoginSnapshot
+}
+
+func NewLoginState() *LoginState {
+	return &LoginState{snap: LoginSnapshot{Status: LoginIdle, Updated: time.Now()}}
+}
+
+func (s *LoginState) Snapshot() LoginSnapshot {
+	if s == nil {
+		return LoginSnapshot{Status: LoginIdle}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+// set mengganti state; QR/kode pairing lama dibuang kecuali diisi ulang.
+func (s *LoginState) set(status string, fn func(*LoginSnapshot)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	phone := s.snap.Phone
+	s.snap = LoginSnapshot{Status: status, Phone: phone, Updated: time.Now()}
+	if fn != nil {
+		fn(&s.snap)
+	}
+	s.snap.HasQR = s.snap.QR != ""
+}
+
+// Login menghubungkan client. Jika belum ada sesi tersimpan, jalankan alur
+// QR (default) atau kode pairing bila phone (nomor internasional tanpa +) diisi.
+func Login(ctx context.Context, cli *whatsmeow.Client, phone string, st *LoginState) error {
+	phone = digitsOnly(phone)
+	if cli.Store.ID != nil {
+		st.set(LoginConnecting, nil)
+		if err := cli.Connect(); err != nil {
+			st.set(LoginError, func(s *LoginSnapshot) { s.Error = err.Error() })
+			return err
+		}
+		st.set(LoginLoggedIn, nil)
+		return nil
+	}
+
+	qr, err := cli.GetQRChannel(ctx)
+	if err != nil {
+		return err
+	}
+	st.set(LoginConnecting, func(s *LoginSnapshot) { s.Phone = phone })
+	if err := cli.Connect(); err != nil {
+		st.set(LoginError, func(s *LoginSnapshot) { s.Error = err.Error() })
+		return err
+	}
+
+	paired := false
+	for e := range qr {
+		switch e.Event {
+		case whatsmeow.QRChannelEventCode:
+			if phone == "" {
+				code, exp := e.Code, time.Now().Add(e.Timeout)
+				st.set(LoginWaitQR, func(s *LoginSnapshot) { s.QR, s.Expires = code, exp })
+				log.Println("Scan QR (code):", e.Code)
+				log.Println("QR juga tersedia di GET /login/qr.png (perlu LOGIN_API_KEY)")
+				continue
+			}
+			// Kode pairing diminta setelah QR pertama muncul (socket siap).
+			if paired {
+				continue
+			}
+			code, err := cli.PairPhone(ctx, phone, true, whatsmeow.PairClientChrome, "Chrome (Linux)")
+			if err != nil {
+				st.set(LoginError, func(s *LoginSnapshot) { s.Error = err.Error() })
+				return fmt.Errorf("pair phone: %w", err)
+			}
+			paired = true
+			st.set(LoginWaitPair, func(s *LoginSnapshot) { s.PairCode = code })
+			log.Printf("Kode pairing untuk %s: %s (WhatsApp > Perangkat tertaut > Tautkan dengan nomor telepon)", phone, code)
+		case whatsmeow.QRChannelSuccess.Event:
+			st.set(LoginLoggedIn, nil)
+			log.Println("Login success")
+			return nil
+		case whatsmeow.QRChannelTimeout.Event:
+			st.set(LoginTimeout, nil)
+			return ErrLoginTimeout
+		case whatsmeow.QRChannelEventError:
+			st.set(LoginError, func(s *LoginSnapshot) { s.Error = fmt.Sprint(e.Error) })
+			return e.Error
+		default:
+			st.set(LoginError, func(s *LoginSnapshot) { s.Error = e.Event })
+			return fmt.Errorf("login: %s", e.Event)
+		}
+	}
+	return ErrLoginTimeout
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package wa
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Placeholder adalah pesan teks sementara milik bot ("⏳ Mengunduh…") yang
+// nanti diedit menjadi hasil akhir atau pesan error, sehingga alur
+// multi-langkah tidak mengirim banyak pesan terpisah.
+//
+// Jika pengiriman awal gagal, Placeholder tetap bisa dipakai: Edit akan
+// mengirim pesan baru sebagai gantinya.
+type Placeholder struct {
+	s     *Sender
+	to    types.JID
+	id    types.MessageID
+	quote *waProto.ContextInfo // reply ke pesan user (nil = teks biasa)
+}
+
+// Placeholder mengirim teks sementara ke to dan mengingat ID pesannya.
+func (s *Sender) Placeholder(to types.JID, text string) (*Placeholder, error) {
+	p := &Placeholder{s: s, to: to}
+	return p, p.send(text)
+}
+
+// PlaceholderReply seperti Placeholder, tetapi sebagai reply ke pesan m.
+func (s *Sender) PlaceholderReply(m *events.Message, text string) (*Placeholder, error) {
+	p := &Placeholder{
+		s:  s,
+		to: m.Info.Chat,
+		quote: &waProto.ContextInfo{
+			StanzaID:      proto.String(m.Info.ID),
+			QuotedMessage: m.Message,
+			Participant:   proto.String(m.Info.Sender.String()),
+			RemoteJID:     proto.String(m.Info.Chat.String()),
+		},
+	}
+	return p, p.send(text)
+}
+
+// ID pesan placeholder ("" jika pengiriman awal gagal).
+func (p *Placeholder) ID() types.MessageID { return p.id }
+
+// Edit mengganti isi placeholder. Jika placeholder tidak pernah terkirim
+// atau edit ditolak (mis. lewat batas waktu edit WA), teks dikirim sebagai
+// pesan baru dan pesan baru itu yang diedit berikutnya.
+func (p *Placeholder) Edit(text string) error {
+	if p.id == "" {
+		return p.send(text)
+	}
+	msg := p.s.C.BuildEdit(p.to, p.id, p.content(text))
+	if _, err := p.s.C.SendMessage(context.Background(), p.to, msg); err != nil {
+		log.Printf("[WA] edit placeholder %s gagal, kirim baru: %v", p.id, err)
+		return p.send(text)
+	}
+	return nil
+}
+
+// Revoke menarik placeholder (mis. pesan user yang dilayani sudah ditarik).
+func (p *Placeholder) Revoke() error {
+	if p.id == "" {
+		return nil
+	}
+	msg := p.s.C.BuildRevoke(p.to, types.EmptyJID, p.id)
+	_, err := p.s.C.SendMessage(context.Background(), p.to, msg)
+	return err
+}
+
+func (p *Placeholder) content(text string) *waProto.Message {
+	if p.quote == nil {
+		return &waProto.Message{Conversation: proto.String(text)}
+	}
+	return &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: p.quote,
+		},
+	}
+}
+
+func (p *Placeholder) send(text string) error {
+	msg := p.content(text)
+	resp, err := p.s.C.SendMessage(context.Background(), p.to, msg)
+	if err != nil && strings.Contains(err.Error(), "479") {
+		time.Sleep(2 * time.Second)
+		resp, err = p.s.C.SendMessage(context.Background(), p.to, msg)
+	}
+	if err != nil {
+		return err
+	}
+	p.id = resp.ID
+	return nil
+}
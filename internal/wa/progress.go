@@ -0,0 +1,122 @@
+package wa
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Reaksi penanda proses di pesan user.
+const (
+	ReactWait = "⏳"
+	ReactDone = "✅"
+	ReactFail = "❌"
+)
+
+// Presence yang ditampilkan selama proses berjalan.
+type Presence int
+
+const (
+	PresenceNone   Presence = iota
+	PresenceTyping          // "mengetik…"
+	PresenceAudio           // "merekam audio…"
+)
+
+const presenceRefresh = 8 * time.Second // WA menghapus status mengetik setelah ~10 detik
+
+var (
+	progressReactions = true
+	progressPresence  = true
+)
+
+// ConfigureProgress menyalakan/mematikan reaksi dan presence (PROGRESS_REACTIONS,
+// PROGRESS_PRESENCE). Dipanggil sekali saat start.
+func ConfigureProgress(reactions, presence bool) {
+	progressReactions, progressPresence = reactions, presence
+}
+
+// Progress memberi umpan balik untuk fitur lambat (imggen, hijabin, tts,
+// sticker, tiktok): reaksi ⏳ saat mulai lalu ✅/❌ di akhir, plus presence
+// opsional selama menunggu. Nil-safe.
+type Progress struct {
+	ctx  context.Context
+	c    Client
+	info types.MessageInfo
+	stop chan struct{}
+	once sync.Once
+}
+
+// StartProgress memasang ⏳ di pesan info dan (opsional) presence di chat.
+// ctx adalah konteks pesan (feature.Msg.Ctx): jika dibatalkan karena pesan
+// ditarik, Done tidak lagi mereaksi pesan yang sudah terhapus. Wajib ditutup
+// dengan Done.
+func StartProgress(ctx context.Context, c Client, info types.MessageInfo, presence Presence) *Progress {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p := &Progress{ctx: ctx, c: c, info: info, stop: make(chan struct{})}
+	p.react(ReactWait)
+	if presence != PresenceNone && progressPresence {
+		go p.keepPresence(presence)
+	}
+	return p
+}
+
+// Done mengganti ⏳ dengan ✅ (ok) atau ❌ dan menghentikan presence.
+// Reaksi akhir dilewati bila pesan sudah ditarik. Panggilan berikutnya
+// diabaikan.
+func (p *Progress) Done(ok bool) {
+	if p == nil {
+		return
+	}
+	p.once.Do(func() {
+		close(p.stop)
+		switch {
+		case p.ctx.Err() != nil:
+			log.Printf("[PROGRESS] pesan ditarik, tanpa reaksi akhir chat=%s id=%s", p.info.Chat.String(), p.info.ID)
+		case ok:
+			p.react(ReactDone)
+		default:
+			p.react(ReactFail)
+		}
+	})
+}
+
+func (p *Progress) react(emoji string) {
+	if !progressReactions || p.info.ID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg := p.c.BuildReaction(p.info.Chat, p.info.Sender, p.info.ID, emoji)
+	if _, err := p.c.SendMessage(ctx, p.info.Chat, msg); err != nil {
+		log.Printf("[PROGRESS] reaksi %s chat=%s: %v", emoji, p.info.Chat.String(), err)
+	}
+}
+
+func (p *Progress) keepPresence(kind Presence) {
+	media := types.ChatPresenceMediaText
+	if kind == PresenceAudio {
+		media = types.ChatPresenceMediaAudio
+	}
+	t := time.NewTicker(presenceRefresh)
+	defer t.Stop()
+	for {
+		if err := p.c.SendChatPresence(p.info.Chat, types.ChatPresenceComposing, media); err != nil {
+			log.Printf("[PROGRESS] presence chat=%s: %v", p.info.Chat.String(), err)
+			return
+		}
+		select {
+		case <-p.stop:
+			_ = p.c.SendChatPresence(p.info.Chat, types.ChatPresencePaused, media)
+			return
+		case <-p.ctx.Done():
+			_ = p.c.SendChatPresence(p.info.Chat, types.ChatPresencePaused, media)
+			return
+		case <-t.C:
+		}
+	}
+}
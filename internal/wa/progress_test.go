@@ -0,0 +1,59 @@
+package wa_test
+
+import (
+	"context"
+	"testing"
+
+	"wa-elaina/internal/wa"
+	"wa-elaina/internal/wa/watest"
+)
+
+func reactions(c *watest.FakeClient) []string {
+	var out []string
+	for _, s := range c.Sent() {
+		if r := s.Message.GetReactionMessage(); r != nil {
+			out = append(out, r.GetText())
+		}
+	}
+	return out
+}
+
+func TestProgressSkipsFinalReactionWhenRevoked(t *testing.T) {
+	u := watest.UserJID("6282222222222")
+	info := watest.Text(u, u, "elaina buatin gambar kucing").Info
+
+	cases := []struct {
+		name    string
+		revoked bool
+		ok      bool
+		want    []string
+	}{
+		{"berhasil", false, true, []string{wa.ReactWait, wa.ReactDone}},
+		{"gagal", false, false, []string{wa.ReactWait, wa.ReactFail}},
+		{"pesan ditarik", true, false, []string{wa.ReactWait}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := watest.NewFakeClient()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			pg := wa.StartProgress(ctx, client, info, wa.PresenceNone)
+			if tc.revoked {
+				cancel()
+			}
+			pg.Done(tc.ok)
+			pg.Done(true) // panggilan kedua diabaikan
+
+			got := reactions(client)
+			if len(got) != len(tc.want) {
+				t.Fatalf("reaksi = %q, mau %q", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("reaksi = %q, mau %q", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -26,7 +26,7 @@ func TextMentionMsg(text string, jids []types.JID) *waProto.Message {
 	}
 }
 
-func SendImageURL(client *whatsmeow.Client, to types.JID, url, caption string) error {
+func SendImageURL(client Client, to types.JID, url, caption string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	resp, err := http.Get(url)
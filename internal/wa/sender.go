@@ -12,9 +12,9 @@ import (
 	"go.mau.fi/whatsmeow/types"
 )
 
-type Sender struct{ C *whatsmeow.Client }
+type Sender struct{ C Client }
 
-func NewSender(c *whatsmeow.Client) *Sender { return &Sender{C: c} }
+func NewSender(c Client) *Sender { return &Sender{C: c} }
 
 func DestJID(j types.JID) types.JID {
 	if j.Server == types.GroupServer {
@@ -37,6 +37,13 @@ func (s *Sender) Text(to types.JID, text string) error {
 	return s.retry(msg, to, err)
 }
 
+// TextMention mengirim teks dengan mention (teks berisi "@nomor").
+func (s *Sender) TextMention(to types.JID, text string, mentions []types.JID) error {
+	msg := TextMentionMsg(text, mentions)
+	_, err := s.C.SendMessage(context.Background(), to, msg)
+	return s.retry(msg, to, err)
+}
+
 func (s *Sender) Audio(to types.JID, audio []byte, mime string, ptt bool, seconds uint32) error {
 	up, err := s.C.Upload(context.Background(), audio, whatsmeow.MediaAudio)
 	if err != nil { return err }
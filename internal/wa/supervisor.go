@@ -0,0 +1,278 @@
+package wa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Supervisor menjaga koneksi WhatsApp tetap hidup di luar auto-reconnect
+// bawaan whatsmeow: reconnect dengan backoff eksponensial setelah
+// StreamReplaced/TemporaryBan/ConnectFailure, pairing ulang setelah logout,
+// dan melaporkan alasan putus ke owner setelah tersambung kembali.
+//
+// Login, reconnect, dan pairing ulang dijalankan satu state machine: paling
+// banyak satu goroutine yang bekerja; permintaan yang lebih penting
+// (logout > login > reconnect) menghentikan pekerjaan yang sedang berjalan.
+type Supervisor struct {
+	Client    *SwitchClient
+	Login     *LoginState
+	PairPhone string
+
+	// NewClient membuat client dengan device kosong (event handler sudah
+	// terpasang) untuk pairing ulang setelah logout; device lama yang sudah
+	// dihapus whatsmeow tidak dipakai ulang.
+	NewClient func() *whatsmeow.Client
+	// Notify menerima alasan putus (dengan waktu) setelah tersambung kembali;
+	// dipanggil di goroutine sendiri. Teks laporan disusun pemanggil (i18n).
+	Notify func(reasons []string)
+
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	ctx context.Context
+
+	mu      sync.Mutex
+	running bool   // goroutine run sedang aktif
+	cur     action // pekerjaan yang sedang dijalankan run
+	want    action // pekerjaan berikutnya
+	delay   time.Duration
+	pending []string
+
+	// Pengganti Login dan sleep untuk tes; nil = implementasi sungguhan.
+	loginFn func() error
+	sleepFn func(time.Duration) bool
+}
+
+// action: pekerjaan state machine, urut menurut prioritas.
+type action int
+
+const (
+	actNone      action = iota
+	actReconnect        // sesi ada, koneksi putus
+	actLogin            // login awal (QR/kode pairing jika belum ada sesi)
+	actRepair           // logout: client & device baru lalu pairing ulang
+)
+
+func NewSupervisor(ctx context.Context, cli *SwitchClient, login *LoginState, pairPhone string) *Supervisor {
+	return &Supervisor{
+		Client:     cli,
+		Login:      login,
+		PairPhone:  pairPhone,
+		MinBackoff: 2 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+		ctx:        ctx,
+	}
+}
+
+// Start menjalankan alur login (QR/pairing) di background.
+func (s *Supervisor) Start() { s.schedule(actLogin, 0) }
+
+// schedule meminta pekerjaan a. Diabaikan jika pekerjaan yang sama atau lebih
+// penting sudah berjalan/antre; selain itu pekerjaan berjalan dihentikan di
+// titik cek berikutnya (lihat preempted).
+func (s *Supervisor) schedule(a action, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a <= s.cur || a <= s.want {
+		return
+	}
+	s.want, s.delay = a, delay
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+}
+
+func (s *Supervisor) run() {
+	for {
+		s.mu.Lock()
+		a, delay := s.want, s.delay
+		s.want, s.cur = actNone, a
+		if a == actNone || s.ctx.Err() != nil {
+			s.running, s.cur = false, actNone
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		switch a {
+		case actReconnect:
+			s.reconnect(delay)
+		case actLogin:
+			s.loginLoop()
+		case actRepair:
+			s.repair()
+		}
+	}
+}
+
+// preempted: ada permintaan yang lebih penting dari pekerjaan saat ini.
+func (s *Supervisor) preempted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.want > s.cur
+}
+
+// loginLoop mengulang Login sampai berhasil. QR/kode yang kedaluwarsa langsung
+// diganti; error lain ditunggu dengan backoff yang sama seperti reconnect.
+func (s *Supervisor) loginLoop() {
+	bo := s.newBackoff()
+	for s.ctx.Err() == nil && !s.preempted() {
+		err := s.login()
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrLoginTimeout) {
+			log.Println("Login kedaluwarsa, membuat QR/kode pairing baru...")
+			bo.reset()
+			continue
+		}
+		delay := bo.next()
+		log.Printf("[WA] login gagal: %v; coba lagi dalam %s", err, delay)
+		if !s.sleep(delay) {
+			return
+		}
+	}
+}
+
+func (s *Supervisor) login() error {
+	if s.loginFn != nil {
+		return s.loginFn()
+	}
+	return Login(s.ctx, s.Client.Current(), s.PairPhone, s.Login)
+}
+
+// backoff: jeda eksponensial antar percobaan, digandakan tiap gagal dalam
+// rentang MinBackoff..MaxBackoff.
+type backoff struct {
+	min, max, cur time.Duration
+}
+
+func (s *Supervisor) newBackoff() *backoff {
+	return &backoff{min: s.MinBackoff, max: s.MaxBackoff}
+}
+
+// next mengembalikan jeda berikutnya lalu menggandakannya.
+func (b *backoff) next() time.Duration {
+	d := min(max(b.cur, b.min), b.max)
+	b.cur = d * 2
+	return d
+}
+
+// reset mengembalikan jeda ke MinBackoff (setelah berhasil).
+func (b *backoff) reset() { b.cur = 0 }
+
+// HandleEvent dipasang di AddEventHandler client aktif.
+func (s *Supervisor) HandleEvent(e any) {
+	switch ev := e.(type) {
+	case *events.Connected:
+		s.flush()
+	case *events.LoggedOut:
+		// whatsmeow sudah menghapus device dari store sebelum event ini.
+		reason := "logout (" + ev.Reason.String() + ")"
+		log.Printf("[WA] %s; sesi dihapus, mulai pairing ulang", reason)
+		s.note(reason)
+		s.schedule(actRepair, 0)
+	case *events.StreamReplaced:
+		// Sesi lain memakai kunci yang sama; jangan langsung rebut kembali.
+		s.note("stream replaced (sesi lain login dengan kredensial yang sama)")
+		s.schedule(actReconnect, s.MaxBackoff/4)
+	case *events.TemporaryBan:
+		s.note(ev.String())
+		wait := ev.Expire
+		if wait <= 0 {
+			wait = s.MaxBackoff
+		}
+		log.Printf("[WA] %s; reconnect dalam %s", ev.String(), wait)
+		s.schedule(actReconnect, wait)
+	case *events.ConnectFailure:
+		s.note(fmt.Sprintf("connect failure %s %s", ev.Reason.String(), strings.TrimSpace(ev.Message)))
+		s.schedule(actReconnect, s.MinBackoff)
+	}
+}
+
+// reconnect mencoba Connect dengan backoff eksponensial sampai berhasil.
+func (s *Supervisor) reconnect(first time.Duration) {
+	delay := first
+	bo := s.newBackoff()
+	bo.cur = first * 2
+	for attempt := 1; ; attempt++ {
+		if !s.sleep(delay) || s.preempted() {
+			return
+		}
+		cli := s.Client.Current()
+		if cli.IsConnected() {
+			return
+		}
+		if cli.Store.ID == nil {
+			// Sesi hilang di tengah jalan: pairing ulang dengan device baru.
+			s.schedule(actRepair, 0)
+			return
+		}
+		err := cli.Connect()
+		if err == nil || errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+			log.Printf("[WA] reconnect berhasil (percobaan %d)", attempt)
+			return
+		}
+		delay = bo.next()
+		log.Printf("[WA] reconnect gagal (percobaan %d): %v; ulang dalam %s", attempt, err, delay)
+	}
+}
+
+// repair memutus client lama, memasang client baru dengan device kosong,
+// lalu memulai alur QR/kode pairing dari awal.
+func (s *Supervisor) repair() {
+	old := s.Client.Current()
+	old.Disconnect()
+	if old.Store.ID != nil {
+		if err := old.Store.Delete(context.Background()); err != nil {
+			log.Printf("[WA] hapus device: %v", err)
+		}
+	}
+	if s.NewClient == nil {
+		log.Println("[WA] NewClient belum diatur; tidak bisa pairing ulang")
+		return
+	}
+	s.Client.Set(s.NewClient())
+	s.loginLoop()
+}
+
+func (s *Supervisor) note(reason string) {
+	s.mu.Lock()
+	s.pending = append(s.pending, time.Now().Format("2006-01-02 15:04:05")+" — "+reason)
+	s.mu.Unlock()
+}
+
+// flush mengirim alasan putus yang tertunda ke owner.
+func (s *Supervisor) flush() {
+	s.mu.Lock()
+	reasons := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(reasons) == 0 || s.Notify == nil {
+		return
+	}
+	go s.Notify(reasons)
+}
+
+func (s *Supervisor) sleep(d time.Duration) bool {
+	if s.sleepFn != nil {
+		return s.sleepFn(d)
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,57 @@
+package wa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoginLoopBackoff(t *testing.T) {
+	errFail := errors.New("pairing gagal")
+	results := []error{errFail, errFail, errFail, errFail, errFail, ErrLoginTimeout, errFail, errFail, nil}
+
+	s := NewSupervisor(context.Background(), nil, nil, "")
+	s.MinBackoff, s.MaxBackoff = 2*time.Second, 10*time.Second
+	calls := 0
+	s.loginFn = func() error {
+		err := results[calls]
+		calls++
+		return err
+	}
+	var slept []time.Duration
+	s.sleepFn = func(d time.Duration) bool {
+		slept = append(slept, d)
+		return true
+	}
+
+	s.loginLoop()
+
+	if calls != len(results) {
+		t.Fatalf("Login dipanggil %d kali, mau %d", calls, len(results))
+	}
+	// Digandakan sampai MaxBackoff, kembali ke MinBackoff setelah QR kedaluwarsa.
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second, 2 * time.Second, 4 * time.Second}
+	if !reflect.DeepEqual(slept, want) {
+		t.Fatalf("jeda = %v, mau %v", slept, want)
+	}
+}
+
+func TestLoginLoopStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewSupervisor(ctx, nil, nil, "")
+	calls := 0
+	s.loginFn = func() error {
+		calls++
+		return errors.New("gagal")
+	}
+	s.sleepFn = func(time.Duration) bool {
+		cancel()
+		return false
+	}
+	s.loginLoop()
+	if calls != 1 {
+		t.Fatalf("Login dipanggil %d kali setelah dibatalkan, mau 1", calls)
+	}
+}
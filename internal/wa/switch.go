@@ -0,0 +1,92 @@
+package wa
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SwitchClient adalah wa.Client yang meneruskan panggilan ke
+// *whatsmeow.Client aktif. Setelah logout, Supervisor memasang client baru
+// (device baru) lewat Set tanpa mengubah client lama yang masih dipakai
+// goroutine whatsmeow; Sender, router, dan fitur cukup memegang SwitchClient.
+type SwitchClient struct {
+	cur atomic.Pointer[whatsmeow.Client]
+}
+
+var _ Client = (*SwitchClient)(nil)
+
+func NewSwitchClient(cli *whatsmeow.Client) *SwitchClient {
+	s := &SwitchClient{}
+	s.cur.Store(cli)
+	return s
+}
+
+// Current mengembalikan client aktif.
+func (s *SwitchClient) Current() *whatsmeow.Client { return s.cur.Load() }
+
+// Set mengganti client aktif.
+func (s *SwitchClient) Set(cli *whatsmeow.Client) { s.cur.Store(cli) }
+
+// AltJID: pasangan LID ↔ nomor HP user dari LID store whatsmeow.
+func (s *SwitchClient) AltJID(j types.JID) (types.JID, bool) {
+	cli := s.Current()
+	if cli == nil || cli.Store == nil || cli.Store.LIDs == nil {
+		return types.JID{}, false
+	}
+	var (
+		alt types.JID
+		err error
+	)
+	switch j.Server {
+	case types.HiddenUserServer:
+		alt, err = cli.Store.LIDs.GetPNForLID(context.Background(), j.ToNonAD())
+	case types.DefaultUserServer:
+		alt, err = cli.Store.LIDs.GetLIDForPN(context.Background(), j.ToNonAD())
+	default:
+		return types.JID{}, false
+	}
+	if err != nil || alt.IsEmpty() {
+		return types.JID{}, false
+	}
+	return alt, true
+}
+
+func (s *SwitchClient) SendMessage(ctx context.Context, to types.JID, message *waProto.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+	return s.Current().SendMessage(ctx, to, message, extra...)
+}
+
+func (s *SwitchClient) Upload(ctx context.Context, plaintext []byte, appInfo whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	return s.Current().Upload(ctx, plaintext, appInfo)
+}
+
+func (s *SwitchClient) Download(ctx context.Context, msg whatsmeow.DownloadableMessage) ([]byte, error) {
+	return s.Current().Download(ctx, msg)
+}
+
+func (s *SwitchClient) GetGroupInfo(jid types.JID) (*types.GroupInfo, error) {
+	return s.Current().GetGroupInfo(jid)
+}
+
+func (s *SwitchClient) UpdateGroupParticipants(jid types.JID, participantChanges []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error) {
+	return s.Current().UpdateGroupParticipants(jid, participantChanges, action)
+}
+
+func (s *SwitchClient) BuildRevoke(chat, sender types.JID, id types.MessageID) *waProto.Message {
+	return s.Current().BuildRevoke(chat, sender, id)
+}
+
+func (s *SwitchClient) BuildEdit(chat types.JID, id types.MessageID, newContent *waProto.Message) *waProto.Message {
+	return s.Current().BuildEdit(chat, id, newContent)
+}
+
+func (s *SwitchClient) BuildReaction(chat, sender types.JID, id types.MessageID, reaction string) *waProto.Message {
+	return s.Current().BuildReaction(chat, sender, id, reaction)
+}
+
+func (s *SwitchClient) SendChatPresence(jid types.JID, state types.ChatPresence, media types.ChatPresenceMedia) error {
+	return s.Current().SendChatPresence(jid, state, media)
+}
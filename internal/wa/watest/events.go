@@ -0,0 +1,87 @@
+package watest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+var eventSeq atomic.Int64
+
+// UserJID membuat JID pengguna dari nomor, mis. UserJID("628123").
+func UserJID(phone string) types.JID { return types.NewJID(phone, types.DefaultUserServer) }
+
+// GroupJID membuat JID grup dari id, mis. GroupJID("1203630").
+func GroupJID(id string) types.JID { return types.NewJID(id, types.GroupServer) }
+
+// Incoming membangun events.Message masuk dari sender di chat.
+// Untuk chat pribadi, isi chat dengan JID sender.
+func Incoming(chat, sender types.JID, msg *waProto.Message) *events.Message {
+	id := types.MessageID(fmt.Sprintf("IN%06d", eventSeq.Add(1)))
+	return &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:     chat,
+				Sender:   sender,
+				IsGroup:  chat.Server == types.GroupServer,
+				IsFromMe: false,
+			},
+			ID:        id,
+			PushName:  sender.User,
+			Timestamp: time.Now(),
+		},
+		Message: msg,
+	}
+}
+
+// Text membangun pesan teks masuk.
+func Text(chat, sender types.JID, text string) *events.Message {
+	return Incoming(chat, sender, &waProto.Message{Conversation: proto.String(text)})
+}
+
+// Reply membangun pesan teks yang me-reply pesan lain.
+func Reply(chat, sender types.JID, text string, quoted *events.Message) *events.Message {
+	return Incoming(chat, sender, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waProto.ContextInfo{
+				StanzaID:      proto.String(quoted.Info.ID),
+				Participant:   proto.String(quoted.Info.Sender.String()),
+				QuotedMessage: quoted.Message,
+			},
+		},
+	})
+}
+
+// Edit membangun event edit ("edit pesan") yang mengganti isi orig dengan
+// teks baru, dalam bentuk yang dikirim whatsmeow (ProtocolMessage MESSAGE_EDIT).
+func Edit(orig *events.Message, text string) *events.Message {
+	return Incoming(orig.Info.Chat, orig.Info.Sender, &waProto.Message{
+		ProtocolMessage: &waProto.ProtocolMessage{
+			Key: &waProto.MessageKey{
+				ID:        proto.String(orig.Info.ID),
+				RemoteJID: proto.String(orig.Info.Chat.String()),
+			},
+			Type:          waProto.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			EditedMessage: &waProto.Message{Conversation: proto.String(text)},
+			TimestampMS:   proto.Int64(time.Now().UnixMilli()),
+		},
+	})
+}
+
+// Group membuat info grup sederhana; admins ikut dimasukkan sebagai peserta.
+func Group(jid types.JID, name string, admins []types.JID, members ...types.JID) *types.GroupInfo {
+	info := &types.GroupInfo{JID: jid, GroupName: types.GroupName{Name: name}}
+	for _, a := range admins {
+		info.Participants = append(info.Participants, types.GroupParticipant{JID: a, IsAdmin: true})
+	}
+	for _, m := range members {
+		info.Participants = append(info.Participants, types.GroupParticipant{JID: m})
+	}
+	return info
+}
@@ -0,0 +1,260 @@
+// Package watest menyediakan klien WhatsApp palsu (in-memory) untuk tes
+// offline: semua pesan keluar dicatat, tanpa koneksi ke server WhatsApp.
+package watest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+
+	"wa-elaina/internal/wa"
+)
+
+// Sent adalah satu pesan yang dikirim lewat FakeClient.
+type Sent struct {
+	To      types.JID
+	Message *waProto.Message
+	ID      types.MessageID
+}
+
+// Text mengembalikan isi teks pesan (conversation/extended/caption).
+func (s Sent) Text() string { return MessageText(s.Message) }
+
+// Upload adalah satu media yang di-upload lewat FakeClient.
+type Upload struct {
+	Type whatsmeow.MediaType
+	Data []byte
+}
+
+// ParticipantChange mencatat panggilan UpdateGroupParticipants.
+type ParticipantChange struct {
+	Group  types.JID
+	Users  []types.JID
+	Action whatsmeow.ParticipantChange
+}
+
+// PresenceUpdate mencatat panggilan SendChatPresence.
+type PresenceUpdate struct {
+	Chat  types.JID
+	State types.ChatPresence
+	Media types.ChatPresenceMedia
+}
+
+// FakeClient memenuhi wa.Client dan aman dipakai dari banyak goroutine.
+type FakeClient struct {
+	mu sync.Mutex
+
+	sent     []Sent
+	uploads  []Upload
+	changes  []ParticipantChange
+	presence []PresenceUpdate
+	seq      int
+
+	// Groups: info grup yang dikembalikan GetGroupInfo.
+	Groups map[types.JID]*types.GroupInfo
+	// Media: isi yang dikembalikan Download (kunci = DirectPath media).
+	Media map[string][]byte
+
+	// SendErr/UploadErr/DownloadErr: paksa error untuk menguji jalur gagal.
+	SendErr     error
+	UploadErr   error
+	DownloadErr error
+}
+
+var _ wa.Client = (*FakeClient)(nil)
+
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		Groups: map[types.JID]*types.GroupInfo{},
+		Media:  map[string][]byte{},
+	}
+}
+
+func (f *FakeClient) SendMessage(_ context.Context, to types.JID, message *waProto.Message, _ ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.SendErr != nil {
+		return whatsmeow.SendResponse{}, f.SendErr
+	}
+	f.seq++
+	id := types.MessageID(fmt.Sprintf("FAKE%04d", f.seq))
+	f.sent = append(f.sent, Sent{To: to, Message: message, ID: id})
+	return whatsmeow.SendResponse{ID: id, Timestamp: time.Now()}, nil
+}
+
+func (f *FakeClient) Upload(_ context.Context, plaintext []byte, appInfo whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.UploadErr != nil {
+		return whatsmeow.UploadResponse{}, f.UploadErr
+	}
+	f.uploads = append(f.uploads, Upload{Type: appInfo, Data: append([]byte(nil), plaintext...)})
+	path := fmt.Sprintf("/fake/%d", len(f.uploads))
+	return whatsmeow.UploadResponse{
+		URL:        "https://mmg.fake" + path,
+		DirectPath: path,
+		FileLength: uint64(len(plaintext)),
+	}, nil
+}
+
+func (f *FakeClient) Download(_ context.Context, msg whatsmeow.DownloadableMessage) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.DownloadErr != nil {
+		return nil, f.DownloadErr
+	}
+	if data, ok := f.Media[msg.GetDirectPath()]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("watest: media %q tidak ada", msg.GetDirectPath())
+}
+
+func (f *FakeClient) GetGroupInfo(jid types.JID) (*types.GroupInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if info, ok := f.Groups[jid]; ok {
+		return info, nil
+	}
+	return nil, whatsmeow.ErrGroupNotFound
+}
+
+func (f *FakeClient) UpdateGroupParticipants(jid types.JID, users []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changes = append(f.changes, ParticipantChange{Group: jid, Users: users, Action: action})
+	out := make([]types.GroupParticipant, 0, len(users))
+	for _, u := range users {
+		out = append(out, types.GroupParticipant{JID: u})
+	}
+	return out, nil
+}
+
+func (f *FakeClient) BuildRevoke(chat, sender types.JID, id types.MessageID) *waProto.Message {
+	return &waProto.Message{
+		ProtocolMessage: &waProto.ProtocolMessage{
+			Type: waProto.ProtocolMessage_REVOKE.Enum(),
+			Key: &waProto.MessageKey{
+				RemoteJID: proto.String(chat.String()),
+				FromMe:    proto.Bool(sender.IsEmpty()),
+				ID:        proto.String(id),
+			},
+		},
+	}
+}
+
+func (f *FakeClient) BuildEdit(chat types.JID, id types.MessageID, newContent *waProto.Message) *waProto.Message {
+	return &waProto.Message{
+		EditedMessage: &waProto.FutureProofMessage{
+			Message: &waProto.Message{
+				ProtocolMessage: &waProto.ProtocolMessage{
+					Key: &waProto.MessageKey{
+						FromMe:    proto.Bool(true),
+						ID:        proto.String(id),
+						RemoteJID: proto.String(chat.String()),
+					},
+					Type:          waProto.ProtocolMessage_MESSAGE_EDIT.Enum(),
+					EditedMessage: newContent,
+					TimestampMS:   proto.Int64(time.Now().UnixMilli()),
+				},
+			},
+		},
+	}
+}
+
+func (f *FakeClient) BuildReaction(chat, sender types.JID, id types.MessageID, reaction string) *waProto.Message {
+	return &waProto.Message{
+		ReactionMessage: &waProto.ReactionMessage{
+			Key: &waProto.MessageKey{
+				RemoteJID: proto.String(chat.String()),
+				FromMe:    proto.Bool(sender.IsEmpty()),
+				ID:        proto.String(id),
+			},
+			Text:              proto.String(reaction),
+			SenderTimestampMS: proto.Int64(time.Now().UnixMilli()),
+		},
+	}
+}
+
+func (f *FakeClient) SendChatPresence(jid types.JID, state types.ChatPresence, media types.ChatPresenceMedia) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.presence = append(f.presence, PresenceUpdate{Chat: jid, State: state, Media: media})
+	return nil
+}
+
+// Sent mengembalikan salinan semua pesan terkirim.
+func (f *FakeClient) Sent() []Sent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Sent(nil), f.sent...)
+}
+
+// SentTo menyaring pesan terkirim ke satu JID.
+func (f *FakeClient) SentTo(to types.JID) []Sent {
+	var out []Sent
+	for _, s := range f.Sent() {
+		if s.To == to {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Texts mengembalikan teks tiap pesan terkirim sesuai urutan.
+func (f *FakeClient) Texts() []string {
+	var out []string
+	for _, s := range f.Sent() {
+		out = append(out, s.Text())
+	}
+	return out
+}
+
+func (f *FakeClient) Uploads() []Upload {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Upload(nil), f.uploads...)
+}
+
+func (f *FakeClient) ParticipantChanges() []ParticipantChange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ParticipantChange(nil), f.changes...)
+}
+
+func (f *FakeClient) Presences() []PresenceUpdate {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]PresenceUpdate(nil), f.presence...)
+}
+
+// Reset menghapus semua catatan (pesan, upload, perubahan peserta, presence).
+func (f *FakeClient) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent, f.uploads, f.changes, f.presence = nil, nil, nil, nil
+}
+
+// MessageText mengambil teks dari pesan keluar/masuk.
+func MessageText(m *waProto.Message) string {
+	switch {
+	case m == nil:
+		return ""
+	case m.GetConversation() != "":
+		return m.GetConversation()
+	case m.GetExtendedTextMessage() != nil:
+		return m.GetExtendedTextMessage().GetText()
+	case m.GetImageMessage() != nil:
+		return m.GetImageMessage().GetCaption()
+	case m.GetVideoMessage() != nil:
+		return m.GetVideoMessage().GetCaption()
+	case m.GetDocumentMessage() != nil:
+		return m.GetDocumentMessage().GetCaption()
+	}
+	return ""
+}
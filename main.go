@@ -4,7 +4,11 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,9 +21,14 @@ import (
 
 	"wa-elaina/internal/bot"
 	"wa-elaina/internal/config"
+	"wa-elaina/internal/db"
+	"wa-elaina/internal/dispatch"
 	"wa-elaina/internal/httpapi"
+	"wa-elaina/internal/i18n"
+	"wa-elaina/internal/memory"
 	"wa-elaina/internal/wa"
-	"wa-elaina/internal/db"
+
+	"wa-elaina/internal/feature/owner"
 
 	// Welcome handler
 	wel "wa-elaina/internal/feature/welcome"
@@ -47,7 +56,9 @@ func main() {
 		device = container.NewDevice()
 	}
 
-	client := whatsmeow.NewClient(device, nil)
+	// SwitchClient: client aktif bisa diganti Supervisor (device baru setelah
+	// logout) tanpa membangun ulang sender/router.
+	client := wa.NewSwitchClient(whatsmeow.NewClient(device, nil))
 	sender := wa.NewSender(client)
 
 	// === OPEN STATE DB (persona & pro-mode persist) ===
@@ -55,15 +66,46 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer func() { _ = stateStore.Close() }()
+	// Memory obrolan AI (riwayat & nama panggilan) di state DB
+	if err := memory.Init(stateStore); err != nil {
+		log.Printf("[MEMORY] init: %v", err)
+	}
+
+	// SIGINT/SIGTERM (docker stop) → graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Router semua fitur (untuk pesan/chat)
 	rt := bot.NewRouter(cfg, sender, &waReady, stateStore)
+	rt.UseAltJID(client.AltJID)
+
+	// Scheduler pengingat (job di state DB, lanjut setelah restart)
+	go rt.Reminders().Run(ctx)
+	go rt.Broadcasts().Run(ctx)
 
 	// Welcome handler dari ENV
 	welH := wel.NewFromEnv()
+	welH.UseAccess(rt.Access())
+
+	login := wa.NewLoginState()
+	sup := wa.NewSupervisor(ctx, client, login, cfg.PairPhone)
+	sup.MinBackoff, sup.MaxBackoff = cfg.ReconnectMin, cfg.ReconnectMax
+	if ownerJID, ok := owner.NewFromEnv().NotifyJID(); ok {
+		sup.Notify = func(reasons []string) {
+			text := i18n.For(ownerJID.String()).T("wa.reconnected", strings.Join(reasons, "\n- "))
+			if err := sender.Text(wa.DestJID(ownerJID), text); err != nil {
+				log.Printf("[WA] gagal lapor owner: %v", err)
+			}
+		}
+	}
+
+	// Worker pool: handler lambat tidak memblokir event loop whatsmeow
+	disp := dispatch.New(cfg.DispatchWorkers, cfg.DispatchQueue)
+	disp.OnDrop = func(chat, _ string) { go rt.NotifyBusy(chat) }
+
+	onEvent := func(e any) {
+		sup.HandleEvent(e)
 
-	client.AddEventHandler(func(e any) {
 		switch ev := e.(type) {
 		case *events.Connected, *events.AppStateSyncComplete:
 			waReady.Store(true)
@@ -71,43 +113,96 @@ func main() {
 		case *events.Disconnected:
 			waReady.Store(false)
 			log.Println("WhatsApp state: DISCONNECTED")
+		case *events.LoggedOut, *events.StreamReplaced, *events.TemporaryBan, *events.ConnectFailure:
+			waReady.Store(false)
+			log.Printf("WhatsApp state: OFFLINE (%T)", ev)
 
-		// Pesan masuk → serahkan ke router
+		// Pesan masuk → antrekan ke router (urut per chat). Revoke diproses
+		// langsung agar bisa membatalkan job pesan asli yang masih antre/berjalan.
 		case *events.Message:
-			if waReady.Load() {
-				rt.HandleMessage(client, ev)
+			if id, ok := bot.RevokedID(ev); ok {
+				rt.CancelMessage(ev.Info.Chat, id, disp.Cancel(ev.Info.Chat.String(), id))
+				break
+			}
+			if waReady.Load() && ctx.Err() == nil {
+				disp.Submit(ev.Info.Chat.String(), ev.Info.ID, func() { rt.HandleMessage(client, ev) })
 			}
 		}
 
 		// Welcome handler (peserta grup baru)
 		_ = welH.TryHandle(client, e)
-	})
-
-	log.Printf("Bot %s is running...", cfg.BotName)
-
-	// Connect WA
-	if client.Store.ID == nil {
-		qr, _ := client.GetQRChannel(context.Background())
-		if err := client.Connect(); err != nil {
-			log.Fatal(err)
-		}
-		for e := range qr {
-			if e.Event == "code" {
-				log.Println("Scan QR (code):", e.Code)
-			}
-			if e.Event == "success" {
-				log.Println("Login success")
+	}
+	// Event dari client lama (setelah diganti) diabaikan.
+	watch := func(c *whatsmeow.Client) *whatsmeow.Client {
+		c.AddEventHandler(func(e any) {
+			if client.Current() == c {
+				onEvent(e)
 			}
-		}
-	} else if err := client.Connect(); err != nil {
-		log.Fatal(err)
+		})
+		return c
 	}
+	watch(client.Current())
+	sup.NewClient = func() *whatsmeow.Client {
+		return watch(whatsmeow.NewClient(container.NewDevice(), nil))
+	}
+
+	log.Printf("Bot %s is running...", cfg.BotName)
 
-	// HTTP API
+	// HTTP API (dinyalakan sebelum login agar /login/qr.png bisa diakses)
 	api := httpapi.New(cfg, sender, &waReady)
+	api.UseLogin(login)
+	api.UseAudit(stateStore)
+	api.UseBroadcasts(rt.Broadcasts())
 	api.RegisterHandlers(http.DefaultServeMux)
 
+	// Connect WA: QR (default) atau kode pairing jika PAIR_PHONE diisi.
+	// Supervisor juga menangani reconnect, logout (pairing ulang) & lapor owner.
+	sup.Start()
+
 	log.Printf("Mode: %s | Trigger: %q | HTTP :%s", cfg.Mode, cfg.Trigger, cfg.Port)
 	srv := &http.Server{Addr: ":" + cfg.Port, ReadHeaderTimeout: 10 * time.Second}
-	log.Fatal(srv.ListenAndServe())
+	srvErr := make(chan error, 1)
+	go func() { srvErr <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		log.Println("Sinyal berhenti diterima, shutdown...")
+	case err := <-srvErr:
+		log.Printf("HTTP server berhenti: %v", err)
+	}
+	stop()
+	shutdown(cfg.ShutdownTimeout, disp, srv, client, stateStore, container)
+}
+
+// shutdown mematikan komponen berurutan dalam satu batas waktu: pesan baru
+// ditolak, antrean handler dikuras, HTTP dimatikan, WA diputus, DB ditutup
+// (kecuali masih ada handler yang berjalan).
+func shutdown(timeout time.Duration, disp *dispatch.Dispatcher, srv *http.Server, client *wa.SwitchClient, state *db.Store, session *sqlstore.Container) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Event handler sudah menolak pesan baru (ctx sinyal selesai); waReady
+	// tetap true selama drain agar pesan yang sudah antre tetap diproses.
+	if err := disp.Shutdown(ctx); err != nil {
+		log.Printf("[SHUTDOWN] antrean tidak habis: %v", err)
+	}
+	waReady.Store(false)
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("[SHUTDOWN] http: %v", err)
+	}
+	client.Current().Disconnect()
+	// Handler yang lewat batas waktu masih berjalan & memakai DB: biarkan
+	// terbuka (proses segera keluar; SQLite aman tanpa Close).
+	if n := disp.Stats().Running; n > 0 {
+		log.Printf("[SHUTDOWN] %d handler masih berjalan, DB tidak ditutup", n)
+		log.Println("Shutdown selesai.")
+		return
+	}
+	if err := state.Close(); err != nil {
+		log.Printf("[SHUTDOWN] state db: %v", err)
+	}
+	if err := session.Close(); err != nil {
+		log.Printf("[SHUTDOWN] session db: %v", err)
+	}
+	log.Println("Shutdown selesai.")
 }